@@ -0,0 +1,44 @@
+package main
+
+import (
+	"log"
+
+	"github.com/mikestefanello/pagoda/pkg/services"
+	"github.com/mikestefanello/pagoda/pkg/tasks"
+)
+
+// The scheduler queues periodic tasks at their intervals. Unlike the web server and the worker, only one instance
+// of the scheduler must run, otherwise each instance would queue every periodic task.
+func main() {
+	// Start a new container so the periodic tasks can be configured
+	c := services.NewContainer()
+	defer func() {
+		if err := c.Shutdown(); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	// Register the periodic tasks with the scheduler
+	err := c.Tasks.
+		New(tasks.TypePruneRevisions).
+		Periodic(c.Config.App.PostRevisions.PruneInterval).
+		Save()
+
+	if err != nil {
+		log.Fatalf("unable to register periodic task: %v", err)
+	}
+
+	err = c.Tasks.
+		New(tasks.TypeNewsletterDigest).
+		Periodic(c.Config.Newsletter.DigestInterval).
+		Save()
+
+	if err != nil {
+		log.Fatalf("unable to register periodic task: %v", err)
+	}
+
+	// Start the scheduler service, which runs until the process is interrupted
+	if err = c.Tasks.StartScheduler(); err != nil {
+		log.Fatalf("scheduler shutdown: %v", err)
+	}
+}
@@ -3,18 +3,25 @@ package main
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/hibiken/asynq"
-	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/pkg/services"
 	"github.com/mikestefanello/pagoda/pkg/tasks"
+
+	// Register the WebP encoder so image variants are also generated in WebP
+	_ "github.com/mikestefanello/pagoda/pkg/imaging/webp"
 )
 
 func main() {
-	// Load the configuration
-	cfg, err := config.GetConfig()
-	if err != nil {
-		panic(fmt.Sprintf("failed to load config: %v", err))
-	}
+	// Start a new container so task processors have access to the services they depend on
+	c := services.NewContainer()
+	defer func() {
+		if err := c.Shutdown(); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	cfg := c.Config
 
 	// Build the worker server
 	srv := asynq.NewServer(
@@ -31,12 +38,25 @@ func main() {
 				"default":  3,
 				"low":      1,
 			},
+			// Email is retried with an exponential backoff, all other tasks use the default
+			RetryDelayFunc: func(n int, err error, t *asynq.Task) time.Duration {
+				if t.Type() == tasks.TypeSendMail {
+					return tasks.SendMailRetryDelay(n)
+				}
+				return asynq.DefaultRetryDelayFunc(n, err, t)
+			},
 		},
 	)
 
 	// Map task types to the handlers
 	mux := asynq.NewServeMux()
 	mux.Handle(tasks.TypeExample, new(tasks.ExampleProcessor))
+	mux.Handle(tasks.TypeCommentNotification, tasks.NewCommentNotificationProcessor(c))
+	mux.Handle(tasks.TypePublishPost, tasks.NewPublishPostProcessor(c))
+	mux.Handle(tasks.TypePruneRevisions, tasks.NewPruneRevisionsProcessor(c))
+	mux.Handle(tasks.TypeProcessMedia, tasks.NewProcessMediaProcessor(c))
+	mux.Handle(tasks.TypeSendMail, tasks.NewSendMailProcessor(c))
+	mux.Handle(tasks.TypeNewsletterDigest, tasks.NewNewsletterDigestProcessor(c))
 
 	// Start the worker server
 	if err := srv.Run(mux); err != nil {
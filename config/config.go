@@ -41,6 +41,28 @@ const (
 	EnvProduction environment = "prod"
 )
 
+// StorageDriver is a backend that files can be stored in
+type StorageDriver string
+
+const (
+	// StorageDriverLocal stores files on the local filesystem
+	StorageDriverLocal StorageDriver = "local"
+
+	// StorageDriverS3 stores files in an S3-compatible bucket, such as MinIO
+	StorageDriverS3 StorageDriver = "s3"
+)
+
+// RateLimitKey is what the requests limited by a rate limit policy are counted by
+type RateLimitKey string
+
+const (
+	// RateLimitKeyIP counts requests by the IP address of the client
+	RateLimitKeyIP RateLimitKey = "ip"
+
+	// RateLimitKeyUser counts requests by the authenticated user, or by IP address for requests without one
+	RateLimitKeyUser RateLimitKey = "user"
+)
+
 // SwitchEnvironment sets the environment variable used to dictate which environment the application is
 // currently running in.
 // This must be called prior to loading the configuration in order for it to take effect.
@@ -53,11 +75,16 @@ func SwitchEnvironment(env environment) {
 type (
 	// Config stores complete configuration
 	Config struct {
-		HTTP     HTTPConfig
-		App      AppConfig
-		Cache    CacheConfig
-		Database DatabaseConfig
-		Mail     MailConfig
+		HTTP       HTTPConfig
+		App        AppConfig
+		Cache      CacheConfig
+		Database   DatabaseConfig
+		Mail       MailConfig
+		Newsletter NewsletterConfig
+		OIDC       OIDCConfig
+		RateLimit  RateLimitConfig
+		Robots     RobotsConfig
+		Storage    StorageConfig
 	}
 
 	// HTTPConfig stores HTTP configuration
@@ -77,14 +104,49 @@ type (
 	// AppConfig stores application configuration
 	AppConfig struct {
 		Name          string
+		URL           string
 		Environment   environment
 		EncryptionKey string
 		Timeout       time.Duration
+		Session       struct {
+			Expiration time.Duration
+		}
 		PasswordToken struct {
 			Expiration time.Duration
 			Length     int
 		}
 		EmailVerificationTokenExpiration time.Duration
+		PostRevisions                    struct {
+			Limit         int
+			PruneInterval string
+		}
+		Login LoginConfig
+	}
+
+	// LoginConfig stores the limits placed on failed login attempts
+	LoginConfig struct {
+		// Window is how far back failed login attempts are counted
+		Window time.Duration
+
+		// MaxAttemptsPerIP is the amount of failed attempts an IP address can make within the window, after which
+		// its attempts are refused
+		MaxAttemptsPerIP int
+
+		// MaxAttemptsPerEmail is the amount of failed attempts which can be made for an email address within the
+		// window, after which the account is locked
+		MaxAttemptsPerEmail int
+
+		// DelayAfter is the amount of failed attempts within the window after which each attempt is delayed
+		DelayAfter int
+
+		// Delay is the delay of the first delayed attempt, which doubles with each further failed attempt
+		Delay time.Duration
+
+		// MaxDelay is the longest an attempt is delayed
+		MaxDelay time.Duration
+
+		// LockoutDuration is how long an account remains locked, unless it is unlocked from the emailed link
+		LockoutDuration time.Duration
 	}
 
 	// CacheConfig stores the cache configuration
@@ -97,6 +159,7 @@ type (
 		Expiration   struct {
 			StaticFile time.Duration
 			Page       time.Duration
+			Markdown   time.Duration
 		}
 	}
 
@@ -117,6 +180,75 @@ type (
 		User        string
 		Password    string
 		FromAddress string
+		MaxRetries  int
+	}
+
+	// NewsletterConfig stores the newsletter configuration
+	NewsletterConfig struct {
+		ConfirmationTokenExpiration time.Duration
+		DigestInterval              string
+		BatchSize                   int
+	}
+
+	// OIDCConfig stores the configuration of the OpenID Connect providers users can sign in with
+	OIDCConfig struct {
+		Providers []OIDCProviderConfig
+	}
+
+	// OIDCProviderConfig stores the configuration of an OpenID Connect provider
+	OIDCProviderConfig struct {
+		// Name identifies the provider within URLs and linked identities, so it should not be changed
+		Name         string
+		Label        string
+		Issuer       string
+		ClientID     string
+		ClientSecret string
+		Scopes       []string
+	}
+
+	// RateLimitConfig stores the rate limit policies which routes can be limited by
+	RateLimitConfig struct {
+		Enabled  bool
+		Policies map[string]RateLimitPolicy
+	}
+
+	// RateLimitPolicy stores a named rate limit, which allows bursts of up to Limit requests and refills at a
+	// steady rate so that Limit requests are allowed per Period
+	RateLimitPolicy struct {
+		Limit  int
+		Period time.Duration
+		Key    RateLimitKey
+	}
+
+	// StorageConfig stores the configuration of the storage used for uploaded media
+	StorageConfig struct {
+		Driver        StorageDriver
+		MaxUploadSize int64
+		AllowedTypes  []string
+		Local         LocalStorageConfig
+		S3            S3StorageConfig
+	}
+
+	// LocalStorageConfig stores the configuration of storage on the local filesystem
+	LocalStorageConfig struct {
+		Directory string
+		URLPrefix string
+	}
+
+	// S3StorageConfig stores the configuration of S3-compatible storage
+	S3StorageConfig struct {
+		Endpoint  string
+		Region    string
+		Bucket    string
+		AccessKey string
+		SecretKey string
+		UseSSL    bool
+		PublicURL string
+	}
+
+	// RobotsConfig stores the configuration used to generate robots.txt
+	RobotsConfig struct {
+		Disallow []string
 	}
 )
 
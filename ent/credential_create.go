@@ -0,0 +1,419 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mikestefanello/pagoda/ent/credential"
+	"github.com/mikestefanello/pagoda/ent/user"
+)
+
+// CredentialCreate is the builder for creating a Credential entity.
+type CredentialCreate struct {
+	config
+	mutation *CredentialMutation
+	hooks    []Hook
+}
+
+// SetCredentialID sets the "credential_id" field.
+func (cc *CredentialCreate) SetCredentialID(b []byte) *CredentialCreate {
+	cc.mutation.SetCredentialID(b)
+	return cc
+}
+
+// SetPublicKey sets the "public_key" field.
+func (cc *CredentialCreate) SetPublicKey(b []byte) *CredentialCreate {
+	cc.mutation.SetPublicKey(b)
+	return cc
+}
+
+// SetName sets the "name" field.
+func (cc *CredentialCreate) SetName(s string) *CredentialCreate {
+	cc.mutation.SetName(s)
+	return cc
+}
+
+// SetAttestationType sets the "attestation_type" field.
+func (cc *CredentialCreate) SetAttestationType(s string) *CredentialCreate {
+	cc.mutation.SetAttestationType(s)
+	return cc
+}
+
+// SetNillableAttestationType sets the "attestation_type" field if the given value is not nil.
+func (cc *CredentialCreate) SetNillableAttestationType(s *string) *CredentialCreate {
+	if s != nil {
+		cc.SetAttestationType(*s)
+	}
+	return cc
+}
+
+// SetTransports sets the "transports" field.
+func (cc *CredentialCreate) SetTransports(s []string) *CredentialCreate {
+	cc.mutation.SetTransports(s)
+	return cc
+}
+
+// SetAaguid sets the "aaguid" field.
+func (cc *CredentialCreate) SetAaguid(b []byte) *CredentialCreate {
+	cc.mutation.SetAaguid(b)
+	return cc
+}
+
+// SetSignCount sets the "sign_count" field.
+func (cc *CredentialCreate) SetSignCount(u uint32) *CredentialCreate {
+	cc.mutation.SetSignCount(u)
+	return cc
+}
+
+// SetNillableSignCount sets the "sign_count" field if the given value is not nil.
+func (cc *CredentialCreate) SetNillableSignCount(u *uint32) *CredentialCreate {
+	if u != nil {
+		cc.SetSignCount(*u)
+	}
+	return cc
+}
+
+// SetBackupEligible sets the "backup_eligible" field.
+func (cc *CredentialCreate) SetBackupEligible(b bool) *CredentialCreate {
+	cc.mutation.SetBackupEligible(b)
+	return cc
+}
+
+// SetNillableBackupEligible sets the "backup_eligible" field if the given value is not nil.
+func (cc *CredentialCreate) SetNillableBackupEligible(b *bool) *CredentialCreate {
+	if b != nil {
+		cc.SetBackupEligible(*b)
+	}
+	return cc
+}
+
+// SetBackupState sets the "backup_state" field.
+func (cc *CredentialCreate) SetBackupState(b bool) *CredentialCreate {
+	cc.mutation.SetBackupState(b)
+	return cc
+}
+
+// SetNillableBackupState sets the "backup_state" field if the given value is not nil.
+func (cc *CredentialCreate) SetNillableBackupState(b *bool) *CredentialCreate {
+	if b != nil {
+		cc.SetBackupState(*b)
+	}
+	return cc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (cc *CredentialCreate) SetCreatedAt(t time.Time) *CredentialCreate {
+	cc.mutation.SetCreatedAt(t)
+	return cc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (cc *CredentialCreate) SetNillableCreatedAt(t *time.Time) *CredentialCreate {
+	if t != nil {
+		cc.SetCreatedAt(*t)
+	}
+	return cc
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (cc *CredentialCreate) SetLastUsedAt(t time.Time) *CredentialCreate {
+	cc.mutation.SetLastUsedAt(t)
+	return cc
+}
+
+// SetNillableLastUsedAt sets the "last_used_at" field if the given value is not nil.
+func (cc *CredentialCreate) SetNillableLastUsedAt(t *time.Time) *CredentialCreate {
+	if t != nil {
+		cc.SetLastUsedAt(*t)
+	}
+	return cc
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (cc *CredentialCreate) SetUserID(id int) *CredentialCreate {
+	cc.mutation.SetUserID(id)
+	return cc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (cc *CredentialCreate) SetUser(u *User) *CredentialCreate {
+	return cc.SetUserID(u.ID)
+}
+
+// Mutation returns the CredentialMutation object of the builder.
+func (cc *CredentialCreate) Mutation() *CredentialMutation {
+	return cc.mutation
+}
+
+// Save creates the Credential in the database.
+func (cc *CredentialCreate) Save(ctx context.Context) (*Credential, error) {
+	cc.defaults()
+	return withHooks(ctx, cc.sqlSave, cc.mutation, cc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (cc *CredentialCreate) SaveX(ctx context.Context) *Credential {
+	v, err := cc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (cc *CredentialCreate) Exec(ctx context.Context) error {
+	_, err := cc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cc *CredentialCreate) ExecX(ctx context.Context) {
+	if err := cc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (cc *CredentialCreate) defaults() {
+	if _, ok := cc.mutation.SignCount(); !ok {
+		v := credential.DefaultSignCount
+		cc.mutation.SetSignCount(v)
+	}
+	if _, ok := cc.mutation.BackupEligible(); !ok {
+		v := credential.DefaultBackupEligible
+		cc.mutation.SetBackupEligible(v)
+	}
+	if _, ok := cc.mutation.BackupState(); !ok {
+		v := credential.DefaultBackupState
+		cc.mutation.SetBackupState(v)
+	}
+	if _, ok := cc.mutation.CreatedAt(); !ok {
+		v := credential.DefaultCreatedAt()
+		cc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cc *CredentialCreate) check() error {
+	if _, ok := cc.mutation.CredentialID(); !ok {
+		return &ValidationError{Name: "credential_id", err: errors.New(`ent: missing required field "Credential.credential_id"`)}
+	}
+	if v, ok := cc.mutation.CredentialID(); ok {
+		if err := credential.CredentialIDValidator(v); err != nil {
+			return &ValidationError{Name: "credential_id", err: fmt.Errorf(`ent: validator failed for field "Credential.credential_id": %w`, err)}
+		}
+	}
+	if _, ok := cc.mutation.PublicKey(); !ok {
+		return &ValidationError{Name: "public_key", err: errors.New(`ent: missing required field "Credential.public_key"`)}
+	}
+	if v, ok := cc.mutation.PublicKey(); ok {
+		if err := credential.PublicKeyValidator(v); err != nil {
+			return &ValidationError{Name: "public_key", err: fmt.Errorf(`ent: validator failed for field "Credential.public_key": %w`, err)}
+		}
+	}
+	if _, ok := cc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Credential.name"`)}
+	}
+	if v, ok := cc.mutation.Name(); ok {
+		if err := credential.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Credential.name": %w`, err)}
+		}
+	}
+	if _, ok := cc.mutation.SignCount(); !ok {
+		return &ValidationError{Name: "sign_count", err: errors.New(`ent: missing required field "Credential.sign_count"`)}
+	}
+	if _, ok := cc.mutation.BackupEligible(); !ok {
+		return &ValidationError{Name: "backup_eligible", err: errors.New(`ent: missing required field "Credential.backup_eligible"`)}
+	}
+	if _, ok := cc.mutation.BackupState(); !ok {
+		return &ValidationError{Name: "backup_state", err: errors.New(`ent: missing required field "Credential.backup_state"`)}
+	}
+	if _, ok := cc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Credential.created_at"`)}
+	}
+	if _, ok := cc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Credential.user"`)}
+	}
+	return nil
+}
+
+func (cc *CredentialCreate) sqlSave(ctx context.Context) (*Credential, error) {
+	if err := cc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := cc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, cc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	cc.mutation.id = &_node.ID
+	cc.mutation.done = true
+	return _node, nil
+}
+
+func (cc *CredentialCreate) createSpec() (*Credential, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Credential{config: cc.config}
+		_spec = sqlgraph.NewCreateSpec(credential.Table, sqlgraph.NewFieldSpec(credential.FieldID, field.TypeInt))
+	)
+	if value, ok := cc.mutation.CredentialID(); ok {
+		_spec.SetField(credential.FieldCredentialID, field.TypeBytes, value)
+		_node.CredentialID = value
+	}
+	if value, ok := cc.mutation.PublicKey(); ok {
+		_spec.SetField(credential.FieldPublicKey, field.TypeBytes, value)
+		_node.PublicKey = value
+	}
+	if value, ok := cc.mutation.Name(); ok {
+		_spec.SetField(credential.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := cc.mutation.AttestationType(); ok {
+		_spec.SetField(credential.FieldAttestationType, field.TypeString, value)
+		_node.AttestationType = value
+	}
+	if value, ok := cc.mutation.Transports(); ok {
+		_spec.SetField(credential.FieldTransports, field.TypeJSON, value)
+		_node.Transports = value
+	}
+	if value, ok := cc.mutation.Aaguid(); ok {
+		_spec.SetField(credential.FieldAaguid, field.TypeBytes, value)
+		_node.Aaguid = value
+	}
+	if value, ok := cc.mutation.SignCount(); ok {
+		_spec.SetField(credential.FieldSignCount, field.TypeUint32, value)
+		_node.SignCount = value
+	}
+	if value, ok := cc.mutation.BackupEligible(); ok {
+		_spec.SetField(credential.FieldBackupEligible, field.TypeBool, value)
+		_node.BackupEligible = value
+	}
+	if value, ok := cc.mutation.BackupState(); ok {
+		_spec.SetField(credential.FieldBackupState, field.TypeBool, value)
+		_node.BackupState = value
+	}
+	if value, ok := cc.mutation.CreatedAt(); ok {
+		_spec.SetField(credential.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := cc.mutation.LastUsedAt(); ok {
+		_spec.SetField(credential.FieldLastUsedAt, field.TypeTime, value)
+		_node.LastUsedAt = &value
+	}
+	if nodes := cc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   credential.UserTable,
+			Columns: []string{credential.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.credential_user = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// CredentialCreateBulk is the builder for creating many Credential entities in bulk.
+type CredentialCreateBulk struct {
+	config
+	err      error
+	builders []*CredentialCreate
+}
+
+// Save creates the Credential entities in the database.
+func (ccb *CredentialCreateBulk) Save(ctx context.Context) ([]*Credential, error) {
+	if ccb.err != nil {
+		return nil, ccb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ccb.builders))
+	nodes := make([]*Credential, len(ccb.builders))
+	mutators := make([]Mutator, len(ccb.builders))
+	for i := range ccb.builders {
+		func(i int, root context.Context) {
+			builder := ccb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*CredentialMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ccb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ccb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ccb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ccb *CredentialCreateBulk) SaveX(ctx context.Context) []*Credential {
+	v, err := ccb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ccb *CredentialCreateBulk) Exec(ctx context.Context) error {
+	_, err := ccb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ccb *CredentialCreateBulk) ExecX(ctx context.Context) {
+	if err := ccb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
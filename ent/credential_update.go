@@ -0,0 +1,762 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/mikestefanello/pagoda/ent/credential"
+	"github.com/mikestefanello/pagoda/ent/predicate"
+	"github.com/mikestefanello/pagoda/ent/user"
+)
+
+// CredentialUpdate is the builder for updating Credential entities.
+type CredentialUpdate struct {
+	config
+	hooks     []Hook
+	mutation  *CredentialMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// Where appends a list predicates to the CredentialUpdate builder.
+func (cu *CredentialUpdate) Where(ps ...predicate.Credential) *CredentialUpdate {
+	cu.mutation.Where(ps...)
+	return cu
+}
+
+// SetCredentialID sets the "credential_id" field.
+func (cu *CredentialUpdate) SetCredentialID(b []byte) *CredentialUpdate {
+	cu.mutation.SetCredentialID(b)
+	return cu
+}
+
+// SetPublicKey sets the "public_key" field.
+func (cu *CredentialUpdate) SetPublicKey(b []byte) *CredentialUpdate {
+	cu.mutation.SetPublicKey(b)
+	return cu
+}
+
+// SetName sets the "name" field.
+func (cu *CredentialUpdate) SetName(s string) *CredentialUpdate {
+	cu.mutation.SetName(s)
+	return cu
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (cu *CredentialUpdate) SetNillableName(s *string) *CredentialUpdate {
+	if s != nil {
+		cu.SetName(*s)
+	}
+	return cu
+}
+
+// SetAttestationType sets the "attestation_type" field.
+func (cu *CredentialUpdate) SetAttestationType(s string) *CredentialUpdate {
+	cu.mutation.SetAttestationType(s)
+	return cu
+}
+
+// SetNillableAttestationType sets the "attestation_type" field if the given value is not nil.
+func (cu *CredentialUpdate) SetNillableAttestationType(s *string) *CredentialUpdate {
+	if s != nil {
+		cu.SetAttestationType(*s)
+	}
+	return cu
+}
+
+// ClearAttestationType clears the value of the "attestation_type" field.
+func (cu *CredentialUpdate) ClearAttestationType() *CredentialUpdate {
+	cu.mutation.ClearAttestationType()
+	return cu
+}
+
+// SetTransports sets the "transports" field.
+func (cu *CredentialUpdate) SetTransports(s []string) *CredentialUpdate {
+	cu.mutation.SetTransports(s)
+	return cu
+}
+
+// AppendTransports appends s to the "transports" field.
+func (cu *CredentialUpdate) AppendTransports(s []string) *CredentialUpdate {
+	cu.mutation.AppendTransports(s)
+	return cu
+}
+
+// ClearTransports clears the value of the "transports" field.
+func (cu *CredentialUpdate) ClearTransports() *CredentialUpdate {
+	cu.mutation.ClearTransports()
+	return cu
+}
+
+// SetAaguid sets the "aaguid" field.
+func (cu *CredentialUpdate) SetAaguid(b []byte) *CredentialUpdate {
+	cu.mutation.SetAaguid(b)
+	return cu
+}
+
+// ClearAaguid clears the value of the "aaguid" field.
+func (cu *CredentialUpdate) ClearAaguid() *CredentialUpdate {
+	cu.mutation.ClearAaguid()
+	return cu
+}
+
+// SetSignCount sets the "sign_count" field.
+func (cu *CredentialUpdate) SetSignCount(u uint32) *CredentialUpdate {
+	cu.mutation.ResetSignCount()
+	cu.mutation.SetSignCount(u)
+	return cu
+}
+
+// SetNillableSignCount sets the "sign_count" field if the given value is not nil.
+func (cu *CredentialUpdate) SetNillableSignCount(u *uint32) *CredentialUpdate {
+	if u != nil {
+		cu.SetSignCount(*u)
+	}
+	return cu
+}
+
+// AddSignCount adds u to the "sign_count" field.
+func (cu *CredentialUpdate) AddSignCount(u int32) *CredentialUpdate {
+	cu.mutation.AddSignCount(u)
+	return cu
+}
+
+// SetBackupEligible sets the "backup_eligible" field.
+func (cu *CredentialUpdate) SetBackupEligible(b bool) *CredentialUpdate {
+	cu.mutation.SetBackupEligible(b)
+	return cu
+}
+
+// SetNillableBackupEligible sets the "backup_eligible" field if the given value is not nil.
+func (cu *CredentialUpdate) SetNillableBackupEligible(b *bool) *CredentialUpdate {
+	if b != nil {
+		cu.SetBackupEligible(*b)
+	}
+	return cu
+}
+
+// SetBackupState sets the "backup_state" field.
+func (cu *CredentialUpdate) SetBackupState(b bool) *CredentialUpdate {
+	cu.mutation.SetBackupState(b)
+	return cu
+}
+
+// SetNillableBackupState sets the "backup_state" field if the given value is not nil.
+func (cu *CredentialUpdate) SetNillableBackupState(b *bool) *CredentialUpdate {
+	if b != nil {
+		cu.SetBackupState(*b)
+	}
+	return cu
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (cu *CredentialUpdate) SetCreatedAt(t time.Time) *CredentialUpdate {
+	cu.mutation.SetCreatedAt(t)
+	return cu
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (cu *CredentialUpdate) SetNillableCreatedAt(t *time.Time) *CredentialUpdate {
+	if t != nil {
+		cu.SetCreatedAt(*t)
+	}
+	return cu
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (cu *CredentialUpdate) SetLastUsedAt(t time.Time) *CredentialUpdate {
+	cu.mutation.SetLastUsedAt(t)
+	return cu
+}
+
+// SetNillableLastUsedAt sets the "last_used_at" field if the given value is not nil.
+func (cu *CredentialUpdate) SetNillableLastUsedAt(t *time.Time) *CredentialUpdate {
+	if t != nil {
+		cu.SetLastUsedAt(*t)
+	}
+	return cu
+}
+
+// ClearLastUsedAt clears the value of the "last_used_at" field.
+func (cu *CredentialUpdate) ClearLastUsedAt() *CredentialUpdate {
+	cu.mutation.ClearLastUsedAt()
+	return cu
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (cu *CredentialUpdate) SetUserID(id int) *CredentialUpdate {
+	cu.mutation.SetUserID(id)
+	return cu
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (cu *CredentialUpdate) SetUser(u *User) *CredentialUpdate {
+	return cu.SetUserID(u.ID)
+}
+
+// Mutation returns the CredentialMutation object of the builder.
+func (cu *CredentialUpdate) Mutation() *CredentialMutation {
+	return cu.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (cu *CredentialUpdate) ClearUser() *CredentialUpdate {
+	cu.mutation.ClearUser()
+	return cu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (cu *CredentialUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, cu.sqlSave, cu.mutation, cu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cu *CredentialUpdate) SaveX(ctx context.Context) int {
+	affected, err := cu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (cu *CredentialUpdate) Exec(ctx context.Context) error {
+	_, err := cu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cu *CredentialUpdate) ExecX(ctx context.Context) {
+	if err := cu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cu *CredentialUpdate) check() error {
+	if v, ok := cu.mutation.CredentialID(); ok {
+		if err := credential.CredentialIDValidator(v); err != nil {
+			return &ValidationError{Name: "credential_id", err: fmt.Errorf(`ent: validator failed for field "Credential.credential_id": %w`, err)}
+		}
+	}
+	if v, ok := cu.mutation.PublicKey(); ok {
+		if err := credential.PublicKeyValidator(v); err != nil {
+			return &ValidationError{Name: "public_key", err: fmt.Errorf(`ent: validator failed for field "Credential.public_key": %w`, err)}
+		}
+	}
+	if v, ok := cu.mutation.Name(); ok {
+		if err := credential.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Credential.name": %w`, err)}
+		}
+	}
+	if _, ok := cu.mutation.UserID(); cu.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Credential.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (cu *CredentialUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *CredentialUpdate {
+	cu.modifiers = append(cu.modifiers, modifiers...)
+	return cu
+}
+
+func (cu *CredentialUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := cu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(credential.Table, credential.Columns, sqlgraph.NewFieldSpec(credential.FieldID, field.TypeInt))
+	if ps := cu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cu.mutation.CredentialID(); ok {
+		_spec.SetField(credential.FieldCredentialID, field.TypeBytes, value)
+	}
+	if value, ok := cu.mutation.PublicKey(); ok {
+		_spec.SetField(credential.FieldPublicKey, field.TypeBytes, value)
+	}
+	if value, ok := cu.mutation.Name(); ok {
+		_spec.SetField(credential.FieldName, field.TypeString, value)
+	}
+	if value, ok := cu.mutation.AttestationType(); ok {
+		_spec.SetField(credential.FieldAttestationType, field.TypeString, value)
+	}
+	if cu.mutation.AttestationTypeCleared() {
+		_spec.ClearField(credential.FieldAttestationType, field.TypeString)
+	}
+	if value, ok := cu.mutation.Transports(); ok {
+		_spec.SetField(credential.FieldTransports, field.TypeJSON, value)
+	}
+	if value, ok := cu.mutation.AppendedTransports(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, credential.FieldTransports, value)
+		})
+	}
+	if cu.mutation.TransportsCleared() {
+		_spec.ClearField(credential.FieldTransports, field.TypeJSON)
+	}
+	if value, ok := cu.mutation.Aaguid(); ok {
+		_spec.SetField(credential.FieldAaguid, field.TypeBytes, value)
+	}
+	if cu.mutation.AaguidCleared() {
+		_spec.ClearField(credential.FieldAaguid, field.TypeBytes)
+	}
+	if value, ok := cu.mutation.SignCount(); ok {
+		_spec.SetField(credential.FieldSignCount, field.TypeUint32, value)
+	}
+	if value, ok := cu.mutation.AddedSignCount(); ok {
+		_spec.AddField(credential.FieldSignCount, field.TypeUint32, value)
+	}
+	if value, ok := cu.mutation.BackupEligible(); ok {
+		_spec.SetField(credential.FieldBackupEligible, field.TypeBool, value)
+	}
+	if value, ok := cu.mutation.BackupState(); ok {
+		_spec.SetField(credential.FieldBackupState, field.TypeBool, value)
+	}
+	if value, ok := cu.mutation.CreatedAt(); ok {
+		_spec.SetField(credential.FieldCreatedAt, field.TypeTime, value)
+	}
+	if value, ok := cu.mutation.LastUsedAt(); ok {
+		_spec.SetField(credential.FieldLastUsedAt, field.TypeTime, value)
+	}
+	if cu.mutation.LastUsedAtCleared() {
+		_spec.ClearField(credential.FieldLastUsedAt, field.TypeTime)
+	}
+	if cu.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   credential.UserTable,
+			Columns: []string{credential.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := cu.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   credential.UserTable,
+			Columns: []string{credential.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(cu.modifiers...)
+	if n, err = sqlgraph.UpdateNodes(ctx, cu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{credential.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	cu.mutation.done = true
+	return n, nil
+}
+
+// CredentialUpdateOne is the builder for updating a single Credential entity.
+type CredentialUpdateOne struct {
+	config
+	fields    []string
+	hooks     []Hook
+	mutation  *CredentialMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// SetCredentialID sets the "credential_id" field.
+func (cuo *CredentialUpdateOne) SetCredentialID(b []byte) *CredentialUpdateOne {
+	cuo.mutation.SetCredentialID(b)
+	return cuo
+}
+
+// SetPublicKey sets the "public_key" field.
+func (cuo *CredentialUpdateOne) SetPublicKey(b []byte) *CredentialUpdateOne {
+	cuo.mutation.SetPublicKey(b)
+	return cuo
+}
+
+// SetName sets the "name" field.
+func (cuo *CredentialUpdateOne) SetName(s string) *CredentialUpdateOne {
+	cuo.mutation.SetName(s)
+	return cuo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (cuo *CredentialUpdateOne) SetNillableName(s *string) *CredentialUpdateOne {
+	if s != nil {
+		cuo.SetName(*s)
+	}
+	return cuo
+}
+
+// SetAttestationType sets the "attestation_type" field.
+func (cuo *CredentialUpdateOne) SetAttestationType(s string) *CredentialUpdateOne {
+	cuo.mutation.SetAttestationType(s)
+	return cuo
+}
+
+// SetNillableAttestationType sets the "attestation_type" field if the given value is not nil.
+func (cuo *CredentialUpdateOne) SetNillableAttestationType(s *string) *CredentialUpdateOne {
+	if s != nil {
+		cuo.SetAttestationType(*s)
+	}
+	return cuo
+}
+
+// ClearAttestationType clears the value of the "attestation_type" field.
+func (cuo *CredentialUpdateOne) ClearAttestationType() *CredentialUpdateOne {
+	cuo.mutation.ClearAttestationType()
+	return cuo
+}
+
+// SetTransports sets the "transports" field.
+func (cuo *CredentialUpdateOne) SetTransports(s []string) *CredentialUpdateOne {
+	cuo.mutation.SetTransports(s)
+	return cuo
+}
+
+// AppendTransports appends s to the "transports" field.
+func (cuo *CredentialUpdateOne) AppendTransports(s []string) *CredentialUpdateOne {
+	cuo.mutation.AppendTransports(s)
+	return cuo
+}
+
+// ClearTransports clears the value of the "transports" field.
+func (cuo *CredentialUpdateOne) ClearTransports() *CredentialUpdateOne {
+	cuo.mutation.ClearTransports()
+	return cuo
+}
+
+// SetAaguid sets the "aaguid" field.
+func (cuo *CredentialUpdateOne) SetAaguid(b []byte) *CredentialUpdateOne {
+	cuo.mutation.SetAaguid(b)
+	return cuo
+}
+
+// ClearAaguid clears the value of the "aaguid" field.
+func (cuo *CredentialUpdateOne) ClearAaguid() *CredentialUpdateOne {
+	cuo.mutation.ClearAaguid()
+	return cuo
+}
+
+// SetSignCount sets the "sign_count" field.
+func (cuo *CredentialUpdateOne) SetSignCount(u uint32) *CredentialUpdateOne {
+	cuo.mutation.ResetSignCount()
+	cuo.mutation.SetSignCount(u)
+	return cuo
+}
+
+// SetNillableSignCount sets the "sign_count" field if the given value is not nil.
+func (cuo *CredentialUpdateOne) SetNillableSignCount(u *uint32) *CredentialUpdateOne {
+	if u != nil {
+		cuo.SetSignCount(*u)
+	}
+	return cuo
+}
+
+// AddSignCount adds u to the "sign_count" field.
+func (cuo *CredentialUpdateOne) AddSignCount(u int32) *CredentialUpdateOne {
+	cuo.mutation.AddSignCount(u)
+	return cuo
+}
+
+// SetBackupEligible sets the "backup_eligible" field.
+func (cuo *CredentialUpdateOne) SetBackupEligible(b bool) *CredentialUpdateOne {
+	cuo.mutation.SetBackupEligible(b)
+	return cuo
+}
+
+// SetNillableBackupEligible sets the "backup_eligible" field if the given value is not nil.
+func (cuo *CredentialUpdateOne) SetNillableBackupEligible(b *bool) *CredentialUpdateOne {
+	if b != nil {
+		cuo.SetBackupEligible(*b)
+	}
+	return cuo
+}
+
+// SetBackupState sets the "backup_state" field.
+func (cuo *CredentialUpdateOne) SetBackupState(b bool) *CredentialUpdateOne {
+	cuo.mutation.SetBackupState(b)
+	return cuo
+}
+
+// SetNillableBackupState sets the "backup_state" field if the given value is not nil.
+func (cuo *CredentialUpdateOne) SetNillableBackupState(b *bool) *CredentialUpdateOne {
+	if b != nil {
+		cuo.SetBackupState(*b)
+	}
+	return cuo
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (cuo *CredentialUpdateOne) SetCreatedAt(t time.Time) *CredentialUpdateOne {
+	cuo.mutation.SetCreatedAt(t)
+	return cuo
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (cuo *CredentialUpdateOne) SetNillableCreatedAt(t *time.Time) *CredentialUpdateOne {
+	if t != nil {
+		cuo.SetCreatedAt(*t)
+	}
+	return cuo
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (cuo *CredentialUpdateOne) SetLastUsedAt(t time.Time) *CredentialUpdateOne {
+	cuo.mutation.SetLastUsedAt(t)
+	return cuo
+}
+
+// SetNillableLastUsedAt sets the "last_used_at" field if the given value is not nil.
+func (cuo *CredentialUpdateOne) SetNillableLastUsedAt(t *time.Time) *CredentialUpdateOne {
+	if t != nil {
+		cuo.SetLastUsedAt(*t)
+	}
+	return cuo
+}
+
+// ClearLastUsedAt clears the value of the "last_used_at" field.
+func (cuo *CredentialUpdateOne) ClearLastUsedAt() *CredentialUpdateOne {
+	cuo.mutation.ClearLastUsedAt()
+	return cuo
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (cuo *CredentialUpdateOne) SetUserID(id int) *CredentialUpdateOne {
+	cuo.mutation.SetUserID(id)
+	return cuo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (cuo *CredentialUpdateOne) SetUser(u *User) *CredentialUpdateOne {
+	return cuo.SetUserID(u.ID)
+}
+
+// Mutation returns the CredentialMutation object of the builder.
+func (cuo *CredentialUpdateOne) Mutation() *CredentialMutation {
+	return cuo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (cuo *CredentialUpdateOne) ClearUser() *CredentialUpdateOne {
+	cuo.mutation.ClearUser()
+	return cuo
+}
+
+// Where appends a list predicates to the CredentialUpdate builder.
+func (cuo *CredentialUpdateOne) Where(ps ...predicate.Credential) *CredentialUpdateOne {
+	cuo.mutation.Where(ps...)
+	return cuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (cuo *CredentialUpdateOne) Select(field string, fields ...string) *CredentialUpdateOne {
+	cuo.fields = append([]string{field}, fields...)
+	return cuo
+}
+
+// Save executes the query and returns the updated Credential entity.
+func (cuo *CredentialUpdateOne) Save(ctx context.Context) (*Credential, error) {
+	return withHooks(ctx, cuo.sqlSave, cuo.mutation, cuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cuo *CredentialUpdateOne) SaveX(ctx context.Context) *Credential {
+	node, err := cuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (cuo *CredentialUpdateOne) Exec(ctx context.Context) error {
+	_, err := cuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cuo *CredentialUpdateOne) ExecX(ctx context.Context) {
+	if err := cuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cuo *CredentialUpdateOne) check() error {
+	if v, ok := cuo.mutation.CredentialID(); ok {
+		if err := credential.CredentialIDValidator(v); err != nil {
+			return &ValidationError{Name: "credential_id", err: fmt.Errorf(`ent: validator failed for field "Credential.credential_id": %w`, err)}
+		}
+	}
+	if v, ok := cuo.mutation.PublicKey(); ok {
+		if err := credential.PublicKeyValidator(v); err != nil {
+			return &ValidationError{Name: "public_key", err: fmt.Errorf(`ent: validator failed for field "Credential.public_key": %w`, err)}
+		}
+	}
+	if v, ok := cuo.mutation.Name(); ok {
+		if err := credential.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Credential.name": %w`, err)}
+		}
+	}
+	if _, ok := cuo.mutation.UserID(); cuo.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Credential.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (cuo *CredentialUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *CredentialUpdateOne {
+	cuo.modifiers = append(cuo.modifiers, modifiers...)
+	return cuo
+}
+
+func (cuo *CredentialUpdateOne) sqlSave(ctx context.Context) (_node *Credential, err error) {
+	if err := cuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(credential.Table, credential.Columns, sqlgraph.NewFieldSpec(credential.FieldID, field.TypeInt))
+	id, ok := cuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Credential.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := cuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, credential.FieldID)
+		for _, f := range fields {
+			if !credential.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != credential.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := cuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cuo.mutation.CredentialID(); ok {
+		_spec.SetField(credential.FieldCredentialID, field.TypeBytes, value)
+	}
+	if value, ok := cuo.mutation.PublicKey(); ok {
+		_spec.SetField(credential.FieldPublicKey, field.TypeBytes, value)
+	}
+	if value, ok := cuo.mutation.Name(); ok {
+		_spec.SetField(credential.FieldName, field.TypeString, value)
+	}
+	if value, ok := cuo.mutation.AttestationType(); ok {
+		_spec.SetField(credential.FieldAttestationType, field.TypeString, value)
+	}
+	if cuo.mutation.AttestationTypeCleared() {
+		_spec.ClearField(credential.FieldAttestationType, field.TypeString)
+	}
+	if value, ok := cuo.mutation.Transports(); ok {
+		_spec.SetField(credential.FieldTransports, field.TypeJSON, value)
+	}
+	if value, ok := cuo.mutation.AppendedTransports(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, credential.FieldTransports, value)
+		})
+	}
+	if cuo.mutation.TransportsCleared() {
+		_spec.ClearField(credential.FieldTransports, field.TypeJSON)
+	}
+	if value, ok := cuo.mutation.Aaguid(); ok {
+		_spec.SetField(credential.FieldAaguid, field.TypeBytes, value)
+	}
+	if cuo.mutation.AaguidCleared() {
+		_spec.ClearField(credential.FieldAaguid, field.TypeBytes)
+	}
+	if value, ok := cuo.mutation.SignCount(); ok {
+		_spec.SetField(credential.FieldSignCount, field.TypeUint32, value)
+	}
+	if value, ok := cuo.mutation.AddedSignCount(); ok {
+		_spec.AddField(credential.FieldSignCount, field.TypeUint32, value)
+	}
+	if value, ok := cuo.mutation.BackupEligible(); ok {
+		_spec.SetField(credential.FieldBackupEligible, field.TypeBool, value)
+	}
+	if value, ok := cuo.mutation.BackupState(); ok {
+		_spec.SetField(credential.FieldBackupState, field.TypeBool, value)
+	}
+	if value, ok := cuo.mutation.CreatedAt(); ok {
+		_spec.SetField(credential.FieldCreatedAt, field.TypeTime, value)
+	}
+	if value, ok := cuo.mutation.LastUsedAt(); ok {
+		_spec.SetField(credential.FieldLastUsedAt, field.TypeTime, value)
+	}
+	if cuo.mutation.LastUsedAtCleared() {
+		_spec.ClearField(credential.FieldLastUsedAt, field.TypeTime)
+	}
+	if cuo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   credential.UserTable,
+			Columns: []string{credential.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := cuo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   credential.UserTable,
+			Columns: []string{credential.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(cuo.modifiers...)
+	_node = &Credential{config: cuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, cuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{credential.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	cuo.mutation.done = true
+	return _node, nil
+}
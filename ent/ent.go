@@ -12,7 +12,19 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/mikestefanello/pagoda/ent/auditevent"
+	"github.com/mikestefanello/pagoda/ent/category"
+	"github.com/mikestefanello/pagoda/ent/comment"
+	"github.com/mikestefanello/pagoda/ent/credential"
+	"github.com/mikestefanello/pagoda/ent/failedmail"
+	"github.com/mikestefanello/pagoda/ent/identity"
+	"github.com/mikestefanello/pagoda/ent/media"
 	"github.com/mikestefanello/pagoda/ent/passwordtoken"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/postrevision"
+	"github.com/mikestefanello/pagoda/ent/recoverycode"
+	"github.com/mikestefanello/pagoda/ent/subscriber"
+	"github.com/mikestefanello/pagoda/ent/tag"
 	"github.com/mikestefanello/pagoda/ent/user"
 )
 
@@ -74,7 +86,19 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
+			auditevent.Table:    auditevent.ValidColumn,
+			category.Table:      category.ValidColumn,
+			comment.Table:       comment.ValidColumn,
+			credential.Table:    credential.ValidColumn,
+			failedmail.Table:    failedmail.ValidColumn,
+			identity.Table:      identity.ValidColumn,
+			media.Table:         media.ValidColumn,
 			passwordtoken.Table: passwordtoken.ValidColumn,
+			post.Table:          post.ValidColumn,
+			postrevision.Table:  postrevision.ValidColumn,
+			recoverycode.Table:  recoverycode.ValidColumn,
+			subscriber.Table:    subscriber.ValidColumn,
+			tag.Table:           tag.ValidColumn,
 			user.Table:          user.ValidColumn,
 		})
 	})
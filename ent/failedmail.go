@@ -0,0 +1,167 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/mikestefanello/pagoda/ent/failedmail"
+	"github.com/mikestefanello/pagoda/pkg/mailer"
+)
+
+// FailedMail is the model entity for the FailedMail schema.
+type FailedMail struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// To holds the value of the "to" field.
+	To string `json:"to,omitempty"`
+	// Subject holds the value of the "subject" field.
+	Subject string `json:"subject,omitempty"`
+	// Message holds the value of the "message" field.
+	Message *mailer.Message `json:"message,omitempty"`
+	// Error holds the value of the "error" field.
+	Error string `json:"error,omitempty"`
+	// Attempts holds the value of the "attempts" field.
+	Attempts int `json:"attempts,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*FailedMail) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case failedmail.FieldMessage:
+			values[i] = new([]byte)
+		case failedmail.FieldID, failedmail.FieldAttempts:
+			values[i] = new(sql.NullInt64)
+		case failedmail.FieldTo, failedmail.FieldSubject, failedmail.FieldError:
+			values[i] = new(sql.NullString)
+		case failedmail.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the FailedMail fields.
+func (fm *FailedMail) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case failedmail.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			fm.ID = int(value.Int64)
+		case failedmail.FieldTo:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field to", values[i])
+			} else if value.Valid {
+				fm.To = value.String
+			}
+		case failedmail.FieldSubject:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field subject", values[i])
+			} else if value.Valid {
+				fm.Subject = value.String
+			}
+		case failedmail.FieldMessage:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field message", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &fm.Message); err != nil {
+					return fmt.Errorf("unmarshal field message: %w", err)
+				}
+			}
+		case failedmail.FieldError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field error", values[i])
+			} else if value.Valid {
+				fm.Error = value.String
+			}
+		case failedmail.FieldAttempts:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field attempts", values[i])
+			} else if value.Valid {
+				fm.Attempts = int(value.Int64)
+			}
+		case failedmail.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				fm.CreatedAt = value.Time
+			}
+		default:
+			fm.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the FailedMail.
+// This includes values selected through modifiers, order, etc.
+func (fm *FailedMail) Value(name string) (ent.Value, error) {
+	return fm.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this FailedMail.
+// Note that you need to call FailedMail.Unwrap() before calling this method if this FailedMail
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (fm *FailedMail) Update() *FailedMailUpdateOne {
+	return NewFailedMailClient(fm.config).UpdateOne(fm)
+}
+
+// Unwrap unwraps the FailedMail entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (fm *FailedMail) Unwrap() *FailedMail {
+	_tx, ok := fm.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: FailedMail is not a transactional entity")
+	}
+	fm.config.driver = _tx.drv
+	return fm
+}
+
+// String implements the fmt.Stringer.
+func (fm *FailedMail) String() string {
+	var builder strings.Builder
+	builder.WriteString("FailedMail(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", fm.ID))
+	builder.WriteString("to=")
+	builder.WriteString(fm.To)
+	builder.WriteString(", ")
+	builder.WriteString("subject=")
+	builder.WriteString(fm.Subject)
+	builder.WriteString(", ")
+	builder.WriteString("message=")
+	builder.WriteString(fmt.Sprintf("%v", fm.Message))
+	builder.WriteString(", ")
+	builder.WriteString("error=")
+	builder.WriteString(fm.Error)
+	builder.WriteString(", ")
+	builder.WriteString("attempts=")
+	builder.WriteString(fmt.Sprintf("%v", fm.Attempts))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(fm.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// FailedMails is a parsable slice of FailedMail.
+type FailedMails []*FailedMail
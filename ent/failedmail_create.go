@@ -0,0 +1,273 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mikestefanello/pagoda/ent/failedmail"
+	"github.com/mikestefanello/pagoda/pkg/mailer"
+)
+
+// FailedMailCreate is the builder for creating a FailedMail entity.
+type FailedMailCreate struct {
+	config
+	mutation *FailedMailMutation
+	hooks    []Hook
+}
+
+// SetTo sets the "to" field.
+func (fmc *FailedMailCreate) SetTo(s string) *FailedMailCreate {
+	fmc.mutation.SetTo(s)
+	return fmc
+}
+
+// SetSubject sets the "subject" field.
+func (fmc *FailedMailCreate) SetSubject(s string) *FailedMailCreate {
+	fmc.mutation.SetSubject(s)
+	return fmc
+}
+
+// SetMessage sets the "message" field.
+func (fmc *FailedMailCreate) SetMessage(m *mailer.Message) *FailedMailCreate {
+	fmc.mutation.SetMessage(m)
+	return fmc
+}
+
+// SetError sets the "error" field.
+func (fmc *FailedMailCreate) SetError(s string) *FailedMailCreate {
+	fmc.mutation.SetError(s)
+	return fmc
+}
+
+// SetAttempts sets the "attempts" field.
+func (fmc *FailedMailCreate) SetAttempts(i int) *FailedMailCreate {
+	fmc.mutation.SetAttempts(i)
+	return fmc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (fmc *FailedMailCreate) SetCreatedAt(t time.Time) *FailedMailCreate {
+	fmc.mutation.SetCreatedAt(t)
+	return fmc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (fmc *FailedMailCreate) SetNillableCreatedAt(t *time.Time) *FailedMailCreate {
+	if t != nil {
+		fmc.SetCreatedAt(*t)
+	}
+	return fmc
+}
+
+// Mutation returns the FailedMailMutation object of the builder.
+func (fmc *FailedMailCreate) Mutation() *FailedMailMutation {
+	return fmc.mutation
+}
+
+// Save creates the FailedMail in the database.
+func (fmc *FailedMailCreate) Save(ctx context.Context) (*FailedMail, error) {
+	fmc.defaults()
+	return withHooks(ctx, fmc.sqlSave, fmc.mutation, fmc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (fmc *FailedMailCreate) SaveX(ctx context.Context) *FailedMail {
+	v, err := fmc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (fmc *FailedMailCreate) Exec(ctx context.Context) error {
+	_, err := fmc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (fmc *FailedMailCreate) ExecX(ctx context.Context) {
+	if err := fmc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (fmc *FailedMailCreate) defaults() {
+	if _, ok := fmc.mutation.CreatedAt(); !ok {
+		v := failedmail.DefaultCreatedAt()
+		fmc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (fmc *FailedMailCreate) check() error {
+	if _, ok := fmc.mutation.To(); !ok {
+		return &ValidationError{Name: "to", err: errors.New(`ent: missing required field "FailedMail.to"`)}
+	}
+	if _, ok := fmc.mutation.Subject(); !ok {
+		return &ValidationError{Name: "subject", err: errors.New(`ent: missing required field "FailedMail.subject"`)}
+	}
+	if _, ok := fmc.mutation.Message(); !ok {
+		return &ValidationError{Name: "message", err: errors.New(`ent: missing required field "FailedMail.message"`)}
+	}
+	if _, ok := fmc.mutation.Error(); !ok {
+		return &ValidationError{Name: "error", err: errors.New(`ent: missing required field "FailedMail.error"`)}
+	}
+	if _, ok := fmc.mutation.Attempts(); !ok {
+		return &ValidationError{Name: "attempts", err: errors.New(`ent: missing required field "FailedMail.attempts"`)}
+	}
+	if v, ok := fmc.mutation.Attempts(); ok {
+		if err := failedmail.AttemptsValidator(v); err != nil {
+			return &ValidationError{Name: "attempts", err: fmt.Errorf(`ent: validator failed for field "FailedMail.attempts": %w`, err)}
+		}
+	}
+	if _, ok := fmc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "FailedMail.created_at"`)}
+	}
+	return nil
+}
+
+func (fmc *FailedMailCreate) sqlSave(ctx context.Context) (*FailedMail, error) {
+	if err := fmc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := fmc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, fmc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	fmc.mutation.id = &_node.ID
+	fmc.mutation.done = true
+	return _node, nil
+}
+
+func (fmc *FailedMailCreate) createSpec() (*FailedMail, *sqlgraph.CreateSpec) {
+	var (
+		_node = &FailedMail{config: fmc.config}
+		_spec = sqlgraph.NewCreateSpec(failedmail.Table, sqlgraph.NewFieldSpec(failedmail.FieldID, field.TypeInt))
+	)
+	if value, ok := fmc.mutation.To(); ok {
+		_spec.SetField(failedmail.FieldTo, field.TypeString, value)
+		_node.To = value
+	}
+	if value, ok := fmc.mutation.Subject(); ok {
+		_spec.SetField(failedmail.FieldSubject, field.TypeString, value)
+		_node.Subject = value
+	}
+	if value, ok := fmc.mutation.Message(); ok {
+		_spec.SetField(failedmail.FieldMessage, field.TypeJSON, value)
+		_node.Message = value
+	}
+	if value, ok := fmc.mutation.Error(); ok {
+		_spec.SetField(failedmail.FieldError, field.TypeString, value)
+		_node.Error = value
+	}
+	if value, ok := fmc.mutation.Attempts(); ok {
+		_spec.SetField(failedmail.FieldAttempts, field.TypeInt, value)
+		_node.Attempts = value
+	}
+	if value, ok := fmc.mutation.CreatedAt(); ok {
+		_spec.SetField(failedmail.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// FailedMailCreateBulk is the builder for creating many FailedMail entities in bulk.
+type FailedMailCreateBulk struct {
+	config
+	err      error
+	builders []*FailedMailCreate
+}
+
+// Save creates the FailedMail entities in the database.
+func (fmcb *FailedMailCreateBulk) Save(ctx context.Context) ([]*FailedMail, error) {
+	if fmcb.err != nil {
+		return nil, fmcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(fmcb.builders))
+	nodes := make([]*FailedMail, len(fmcb.builders))
+	mutators := make([]Mutator, len(fmcb.builders))
+	for i := range fmcb.builders {
+		func(i int, root context.Context) {
+			builder := fmcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*FailedMailMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, fmcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, fmcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, fmcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (fmcb *FailedMailCreateBulk) SaveX(ctx context.Context) []*FailedMail {
+	v, err := fmcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (fmcb *FailedMailCreateBulk) Exec(ctx context.Context) error {
+	_, err := fmcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (fmcb *FailedMailCreateBulk) ExecX(ctx context.Context) {
+	if err := fmcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
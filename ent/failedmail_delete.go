@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mikestefanello/pagoda/ent/failedmail"
+	"github.com/mikestefanello/pagoda/ent/predicate"
+)
+
+// FailedMailDelete is the builder for deleting a FailedMail entity.
+type FailedMailDelete struct {
+	config
+	hooks    []Hook
+	mutation *FailedMailMutation
+}
+
+// Where appends a list predicates to the FailedMailDelete builder.
+func (fmd *FailedMailDelete) Where(ps ...predicate.FailedMail) *FailedMailDelete {
+	fmd.mutation.Where(ps...)
+	return fmd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (fmd *FailedMailDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, fmd.sqlExec, fmd.mutation, fmd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (fmd *FailedMailDelete) ExecX(ctx context.Context) int {
+	n, err := fmd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (fmd *FailedMailDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(failedmail.Table, sqlgraph.NewFieldSpec(failedmail.FieldID, field.TypeInt))
+	if ps := fmd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, fmd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	fmd.mutation.done = true
+	return affected, err
+}
+
+// FailedMailDeleteOne is the builder for deleting a single FailedMail entity.
+type FailedMailDeleteOne struct {
+	fmd *FailedMailDelete
+}
+
+// Where appends a list predicates to the FailedMailDelete builder.
+func (fmdo *FailedMailDeleteOne) Where(ps ...predicate.FailedMail) *FailedMailDeleteOne {
+	fmdo.fmd.mutation.Where(ps...)
+	return fmdo
+}
+
+// Exec executes the deletion query.
+func (fmdo *FailedMailDeleteOne) Exec(ctx context.Context) error {
+	n, err := fmdo.fmd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{failedmail.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (fmdo *FailedMailDeleteOne) ExecX(ctx context.Context) {
+	if err := fmdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
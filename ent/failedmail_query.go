@@ -0,0 +1,548 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mikestefanello/pagoda/ent/failedmail"
+	"github.com/mikestefanello/pagoda/ent/predicate"
+)
+
+// FailedMailQuery is the builder for querying FailedMail entities.
+type FailedMailQuery struct {
+	config
+	ctx        *QueryContext
+	order      []failedmail.OrderOption
+	inters     []Interceptor
+	predicates []predicate.FailedMail
+	modifiers  []func(*sql.Selector)
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the FailedMailQuery builder.
+func (fmq *FailedMailQuery) Where(ps ...predicate.FailedMail) *FailedMailQuery {
+	fmq.predicates = append(fmq.predicates, ps...)
+	return fmq
+}
+
+// Limit the number of records to be returned by this query.
+func (fmq *FailedMailQuery) Limit(limit int) *FailedMailQuery {
+	fmq.ctx.Limit = &limit
+	return fmq
+}
+
+// Offset to start from.
+func (fmq *FailedMailQuery) Offset(offset int) *FailedMailQuery {
+	fmq.ctx.Offset = &offset
+	return fmq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (fmq *FailedMailQuery) Unique(unique bool) *FailedMailQuery {
+	fmq.ctx.Unique = &unique
+	return fmq
+}
+
+// Order specifies how the records should be ordered.
+func (fmq *FailedMailQuery) Order(o ...failedmail.OrderOption) *FailedMailQuery {
+	fmq.order = append(fmq.order, o...)
+	return fmq
+}
+
+// First returns the first FailedMail entity from the query.
+// Returns a *NotFoundError when no FailedMail was found.
+func (fmq *FailedMailQuery) First(ctx context.Context) (*FailedMail, error) {
+	nodes, err := fmq.Limit(1).All(setContextOp(ctx, fmq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{failedmail.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (fmq *FailedMailQuery) FirstX(ctx context.Context) *FailedMail {
+	node, err := fmq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first FailedMail ID from the query.
+// Returns a *NotFoundError when no FailedMail ID was found.
+func (fmq *FailedMailQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = fmq.Limit(1).IDs(setContextOp(ctx, fmq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{failedmail.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (fmq *FailedMailQuery) FirstIDX(ctx context.Context) int {
+	id, err := fmq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single FailedMail entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one FailedMail entity is found.
+// Returns a *NotFoundError when no FailedMail entities are found.
+func (fmq *FailedMailQuery) Only(ctx context.Context) (*FailedMail, error) {
+	nodes, err := fmq.Limit(2).All(setContextOp(ctx, fmq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{failedmail.Label}
+	default:
+		return nil, &NotSingularError{failedmail.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (fmq *FailedMailQuery) OnlyX(ctx context.Context) *FailedMail {
+	node, err := fmq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only FailedMail ID in the query.
+// Returns a *NotSingularError when more than one FailedMail ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (fmq *FailedMailQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = fmq.Limit(2).IDs(setContextOp(ctx, fmq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{failedmail.Label}
+	default:
+		err = &NotSingularError{failedmail.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (fmq *FailedMailQuery) OnlyIDX(ctx context.Context) int {
+	id, err := fmq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of FailedMails.
+func (fmq *FailedMailQuery) All(ctx context.Context) ([]*FailedMail, error) {
+	ctx = setContextOp(ctx, fmq.ctx, "All")
+	if err := fmq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*FailedMail, *FailedMailQuery]()
+	return withInterceptors[[]*FailedMail](ctx, fmq, qr, fmq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (fmq *FailedMailQuery) AllX(ctx context.Context) []*FailedMail {
+	nodes, err := fmq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of FailedMail IDs.
+func (fmq *FailedMailQuery) IDs(ctx context.Context) (ids []int, err error) {
+	if fmq.ctx.Unique == nil && fmq.path != nil {
+		fmq.Unique(true)
+	}
+	ctx = setContextOp(ctx, fmq.ctx, "IDs")
+	if err = fmq.Select(failedmail.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (fmq *FailedMailQuery) IDsX(ctx context.Context) []int {
+	ids, err := fmq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (fmq *FailedMailQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, fmq.ctx, "Count")
+	if err := fmq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, fmq, querierCount[*FailedMailQuery](), fmq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (fmq *FailedMailQuery) CountX(ctx context.Context) int {
+	count, err := fmq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (fmq *FailedMailQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, fmq.ctx, "Exist")
+	switch _, err := fmq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (fmq *FailedMailQuery) ExistX(ctx context.Context) bool {
+	exist, err := fmq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the FailedMailQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (fmq *FailedMailQuery) Clone() *FailedMailQuery {
+	if fmq == nil {
+		return nil
+	}
+	return &FailedMailQuery{
+		config:     fmq.config,
+		ctx:        fmq.ctx.Clone(),
+		order:      append([]failedmail.OrderOption{}, fmq.order...),
+		inters:     append([]Interceptor{}, fmq.inters...),
+		predicates: append([]predicate.FailedMail{}, fmq.predicates...),
+		// clone intermediate query.
+		sql:  fmq.sql.Clone(),
+		path: fmq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		To string `json:"to,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.FailedMail.Query().
+//		GroupBy(failedmail.FieldTo).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (fmq *FailedMailQuery) GroupBy(field string, fields ...string) *FailedMailGroupBy {
+	fmq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &FailedMailGroupBy{build: fmq}
+	grbuild.flds = &fmq.ctx.Fields
+	grbuild.label = failedmail.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		To string `json:"to,omitempty"`
+//	}
+//
+//	client.FailedMail.Query().
+//		Select(failedmail.FieldTo).
+//		Scan(ctx, &v)
+func (fmq *FailedMailQuery) Select(fields ...string) *FailedMailSelect {
+	fmq.ctx.Fields = append(fmq.ctx.Fields, fields...)
+	sbuild := &FailedMailSelect{FailedMailQuery: fmq}
+	sbuild.label = failedmail.Label
+	sbuild.flds, sbuild.scan = &fmq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a FailedMailSelect configured with the given aggregations.
+func (fmq *FailedMailQuery) Aggregate(fns ...AggregateFunc) *FailedMailSelect {
+	return fmq.Select().Aggregate(fns...)
+}
+
+func (fmq *FailedMailQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range fmq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, fmq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range fmq.ctx.Fields {
+		if !failedmail.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if fmq.path != nil {
+		prev, err := fmq.path(ctx)
+		if err != nil {
+			return err
+		}
+		fmq.sql = prev
+	}
+	return nil
+}
+
+func (fmq *FailedMailQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*FailedMail, error) {
+	var (
+		nodes = []*FailedMail{}
+		_spec = fmq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*FailedMail).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &FailedMail{config: fmq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(fmq.modifiers) > 0 {
+		_spec.Modifiers = fmq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, fmq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (fmq *FailedMailQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := fmq.querySpec()
+	if len(fmq.modifiers) > 0 {
+		_spec.Modifiers = fmq.modifiers
+	}
+	_spec.Node.Columns = fmq.ctx.Fields
+	if len(fmq.ctx.Fields) > 0 {
+		_spec.Unique = fmq.ctx.Unique != nil && *fmq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, fmq.driver, _spec)
+}
+
+func (fmq *FailedMailQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(failedmail.Table, failedmail.Columns, sqlgraph.NewFieldSpec(failedmail.FieldID, field.TypeInt))
+	_spec.From = fmq.sql
+	if unique := fmq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if fmq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := fmq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, failedmail.FieldID)
+		for i := range fields {
+			if fields[i] != failedmail.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := fmq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := fmq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := fmq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := fmq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (fmq *FailedMailQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(fmq.driver.Dialect())
+	t1 := builder.Table(failedmail.Table)
+	columns := fmq.ctx.Fields
+	if len(columns) == 0 {
+		columns = failedmail.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if fmq.sql != nil {
+		selector = fmq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if fmq.ctx.Unique != nil && *fmq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, m := range fmq.modifiers {
+		m(selector)
+	}
+	for _, p := range fmq.predicates {
+		p(selector)
+	}
+	for _, p := range fmq.order {
+		p(selector)
+	}
+	if offset := fmq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := fmq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// Modify adds a query modifier for attaching custom logic to queries.
+func (fmq *FailedMailQuery) Modify(modifiers ...func(s *sql.Selector)) *FailedMailSelect {
+	fmq.modifiers = append(fmq.modifiers, modifiers...)
+	return fmq.Select()
+}
+
+// FailedMailGroupBy is the group-by builder for FailedMail entities.
+type FailedMailGroupBy struct {
+	selector
+	build *FailedMailQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (fmgb *FailedMailGroupBy) Aggregate(fns ...AggregateFunc) *FailedMailGroupBy {
+	fmgb.fns = append(fmgb.fns, fns...)
+	return fmgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (fmgb *FailedMailGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, fmgb.build.ctx, "GroupBy")
+	if err := fmgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*FailedMailQuery, *FailedMailGroupBy](ctx, fmgb.build, fmgb, fmgb.build.inters, v)
+}
+
+func (fmgb *FailedMailGroupBy) sqlScan(ctx context.Context, root *FailedMailQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(fmgb.fns))
+	for _, fn := range fmgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*fmgb.flds)+len(fmgb.fns))
+		for _, f := range *fmgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*fmgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := fmgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// FailedMailSelect is the builder for selecting fields of FailedMail entities.
+type FailedMailSelect struct {
+	*FailedMailQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (fms *FailedMailSelect) Aggregate(fns ...AggregateFunc) *FailedMailSelect {
+	fms.fns = append(fms.fns, fns...)
+	return fms
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (fms *FailedMailSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, fms.ctx, "Select")
+	if err := fms.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*FailedMailQuery, *FailedMailSelect](ctx, fms.FailedMailQuery, fms, fms.inters, v)
+}
+
+func (fms *FailedMailSelect) sqlScan(ctx context.Context, root *FailedMailQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(fms.fns))
+	for _, fn := range fms.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*fms.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := fms.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// Modify adds a query modifier for attaching custom logic to queries.
+func (fms *FailedMailSelect) Modify(modifiers ...func(s *sql.Selector)) *FailedMailSelect {
+	fms.modifiers = append(fms.modifiers, modifiers...)
+	return fms
+}
@@ -0,0 +1,191 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mikestefanello/pagoda/ent/failedmail"
+	"github.com/mikestefanello/pagoda/ent/predicate"
+)
+
+// FailedMailUpdate is the builder for updating FailedMail entities.
+type FailedMailUpdate struct {
+	config
+	hooks     []Hook
+	mutation  *FailedMailMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// Where appends a list predicates to the FailedMailUpdate builder.
+func (fmu *FailedMailUpdate) Where(ps ...predicate.FailedMail) *FailedMailUpdate {
+	fmu.mutation.Where(ps...)
+	return fmu
+}
+
+// Mutation returns the FailedMailMutation object of the builder.
+func (fmu *FailedMailUpdate) Mutation() *FailedMailMutation {
+	return fmu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (fmu *FailedMailUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, fmu.sqlSave, fmu.mutation, fmu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (fmu *FailedMailUpdate) SaveX(ctx context.Context) int {
+	affected, err := fmu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (fmu *FailedMailUpdate) Exec(ctx context.Context) error {
+	_, err := fmu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (fmu *FailedMailUpdate) ExecX(ctx context.Context) {
+	if err := fmu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (fmu *FailedMailUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *FailedMailUpdate {
+	fmu.modifiers = append(fmu.modifiers, modifiers...)
+	return fmu
+}
+
+func (fmu *FailedMailUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(failedmail.Table, failedmail.Columns, sqlgraph.NewFieldSpec(failedmail.FieldID, field.TypeInt))
+	if ps := fmu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	_spec.AddModifiers(fmu.modifiers...)
+	if n, err = sqlgraph.UpdateNodes(ctx, fmu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{failedmail.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	fmu.mutation.done = true
+	return n, nil
+}
+
+// FailedMailUpdateOne is the builder for updating a single FailedMail entity.
+type FailedMailUpdateOne struct {
+	config
+	fields    []string
+	hooks     []Hook
+	mutation  *FailedMailMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// Mutation returns the FailedMailMutation object of the builder.
+func (fmuo *FailedMailUpdateOne) Mutation() *FailedMailMutation {
+	return fmuo.mutation
+}
+
+// Where appends a list predicates to the FailedMailUpdate builder.
+func (fmuo *FailedMailUpdateOne) Where(ps ...predicate.FailedMail) *FailedMailUpdateOne {
+	fmuo.mutation.Where(ps...)
+	return fmuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (fmuo *FailedMailUpdateOne) Select(field string, fields ...string) *FailedMailUpdateOne {
+	fmuo.fields = append([]string{field}, fields...)
+	return fmuo
+}
+
+// Save executes the query and returns the updated FailedMail entity.
+func (fmuo *FailedMailUpdateOne) Save(ctx context.Context) (*FailedMail, error) {
+	return withHooks(ctx, fmuo.sqlSave, fmuo.mutation, fmuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (fmuo *FailedMailUpdateOne) SaveX(ctx context.Context) *FailedMail {
+	node, err := fmuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (fmuo *FailedMailUpdateOne) Exec(ctx context.Context) error {
+	_, err := fmuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (fmuo *FailedMailUpdateOne) ExecX(ctx context.Context) {
+	if err := fmuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (fmuo *FailedMailUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *FailedMailUpdateOne {
+	fmuo.modifiers = append(fmuo.modifiers, modifiers...)
+	return fmuo
+}
+
+func (fmuo *FailedMailUpdateOne) sqlSave(ctx context.Context) (_node *FailedMail, err error) {
+	_spec := sqlgraph.NewUpdateSpec(failedmail.Table, failedmail.Columns, sqlgraph.NewFieldSpec(failedmail.FieldID, field.TypeInt))
+	id, ok := fmuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "FailedMail.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := fmuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, failedmail.FieldID)
+		for _, f := range fields {
+			if !failedmail.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != failedmail.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := fmuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	_spec.AddModifiers(fmuo.modifiers...)
+	_node = &FailedMail{config: fmuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, fmuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{failedmail.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	fmuo.mutation.done = true
+	return _node, nil
+}
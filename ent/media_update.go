@@ -0,0 +1,747 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/mikestefanello/pagoda/ent/media"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/predicate"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/imaging"
+)
+
+// MediaUpdate is the builder for updating Media entities.
+type MediaUpdate struct {
+	config
+	hooks     []Hook
+	mutation  *MediaMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// Where appends a list predicates to the MediaUpdate builder.
+func (mu *MediaUpdate) Where(ps ...predicate.Media) *MediaUpdate {
+	mu.mutation.Where(ps...)
+	return mu
+}
+
+// SetFilename sets the "filename" field.
+func (mu *MediaUpdate) SetFilename(s string) *MediaUpdate {
+	mu.mutation.SetFilename(s)
+	return mu
+}
+
+// SetNillableFilename sets the "filename" field if the given value is not nil.
+func (mu *MediaUpdate) SetNillableFilename(s *string) *MediaUpdate {
+	if s != nil {
+		mu.SetFilename(*s)
+	}
+	return mu
+}
+
+// SetSize sets the "size" field.
+func (mu *MediaUpdate) SetSize(i int64) *MediaUpdate {
+	mu.mutation.ResetSize()
+	mu.mutation.SetSize(i)
+	return mu
+}
+
+// SetNillableSize sets the "size" field if the given value is not nil.
+func (mu *MediaUpdate) SetNillableSize(i *int64) *MediaUpdate {
+	if i != nil {
+		mu.SetSize(*i)
+	}
+	return mu
+}
+
+// AddSize adds i to the "size" field.
+func (mu *MediaUpdate) AddSize(i int64) *MediaUpdate {
+	mu.mutation.AddSize(i)
+	return mu
+}
+
+// SetWidth sets the "width" field.
+func (mu *MediaUpdate) SetWidth(i int) *MediaUpdate {
+	mu.mutation.ResetWidth()
+	mu.mutation.SetWidth(i)
+	return mu
+}
+
+// SetNillableWidth sets the "width" field if the given value is not nil.
+func (mu *MediaUpdate) SetNillableWidth(i *int) *MediaUpdate {
+	if i != nil {
+		mu.SetWidth(*i)
+	}
+	return mu
+}
+
+// AddWidth adds i to the "width" field.
+func (mu *MediaUpdate) AddWidth(i int) *MediaUpdate {
+	mu.mutation.AddWidth(i)
+	return mu
+}
+
+// ClearWidth clears the value of the "width" field.
+func (mu *MediaUpdate) ClearWidth() *MediaUpdate {
+	mu.mutation.ClearWidth()
+	return mu
+}
+
+// SetHeight sets the "height" field.
+func (mu *MediaUpdate) SetHeight(i int) *MediaUpdate {
+	mu.mutation.ResetHeight()
+	mu.mutation.SetHeight(i)
+	return mu
+}
+
+// SetNillableHeight sets the "height" field if the given value is not nil.
+func (mu *MediaUpdate) SetNillableHeight(i *int) *MediaUpdate {
+	if i != nil {
+		mu.SetHeight(*i)
+	}
+	return mu
+}
+
+// AddHeight adds i to the "height" field.
+func (mu *MediaUpdate) AddHeight(i int) *MediaUpdate {
+	mu.mutation.AddHeight(i)
+	return mu
+}
+
+// ClearHeight clears the value of the "height" field.
+func (mu *MediaUpdate) ClearHeight() *MediaUpdate {
+	mu.mutation.ClearHeight()
+	return mu
+}
+
+// SetVariants sets the "variants" field.
+func (mu *MediaUpdate) SetVariants(i []imaging.Variant) *MediaUpdate {
+	mu.mutation.SetVariants(i)
+	return mu
+}
+
+// AppendVariants appends i to the "variants" field.
+func (mu *MediaUpdate) AppendVariants(i []imaging.Variant) *MediaUpdate {
+	mu.mutation.AppendVariants(i)
+	return mu
+}
+
+// ClearVariants clears the value of the "variants" field.
+func (mu *MediaUpdate) ClearVariants() *MediaUpdate {
+	mu.mutation.ClearVariants()
+	return mu
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (mu *MediaUpdate) SetOwnerID(id int) *MediaUpdate {
+	mu.mutation.SetOwnerID(id)
+	return mu
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (mu *MediaUpdate) SetOwner(u *User) *MediaUpdate {
+	return mu.SetOwnerID(u.ID)
+}
+
+// SetPostID sets the "post" edge to the Post entity by ID.
+func (mu *MediaUpdate) SetPostID(id int) *MediaUpdate {
+	mu.mutation.SetPostID(id)
+	return mu
+}
+
+// SetNillablePostID sets the "post" edge to the Post entity by ID if the given value is not nil.
+func (mu *MediaUpdate) SetNillablePostID(id *int) *MediaUpdate {
+	if id != nil {
+		mu = mu.SetPostID(*id)
+	}
+	return mu
+}
+
+// SetPost sets the "post" edge to the Post entity.
+func (mu *MediaUpdate) SetPost(p *Post) *MediaUpdate {
+	return mu.SetPostID(p.ID)
+}
+
+// Mutation returns the MediaMutation object of the builder.
+func (mu *MediaUpdate) Mutation() *MediaMutation {
+	return mu.mutation
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (mu *MediaUpdate) ClearOwner() *MediaUpdate {
+	mu.mutation.ClearOwner()
+	return mu
+}
+
+// ClearPost clears the "post" edge to the Post entity.
+func (mu *MediaUpdate) ClearPost() *MediaUpdate {
+	mu.mutation.ClearPost()
+	return mu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (mu *MediaUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, mu.sqlSave, mu.mutation, mu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (mu *MediaUpdate) SaveX(ctx context.Context) int {
+	affected, err := mu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (mu *MediaUpdate) Exec(ctx context.Context) error {
+	_, err := mu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (mu *MediaUpdate) ExecX(ctx context.Context) {
+	if err := mu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (mu *MediaUpdate) check() error {
+	if v, ok := mu.mutation.Filename(); ok {
+		if err := media.FilenameValidator(v); err != nil {
+			return &ValidationError{Name: "filename", err: fmt.Errorf(`ent: validator failed for field "Media.filename": %w`, err)}
+		}
+	}
+	if v, ok := mu.mutation.Size(); ok {
+		if err := media.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "Media.size": %w`, err)}
+		}
+	}
+	if v, ok := mu.mutation.Width(); ok {
+		if err := media.WidthValidator(v); err != nil {
+			return &ValidationError{Name: "width", err: fmt.Errorf(`ent: validator failed for field "Media.width": %w`, err)}
+		}
+	}
+	if v, ok := mu.mutation.Height(); ok {
+		if err := media.HeightValidator(v); err != nil {
+			return &ValidationError{Name: "height", err: fmt.Errorf(`ent: validator failed for field "Media.height": %w`, err)}
+		}
+	}
+	if _, ok := mu.mutation.OwnerID(); mu.mutation.OwnerCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Media.owner"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (mu *MediaUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *MediaUpdate {
+	mu.modifiers = append(mu.modifiers, modifiers...)
+	return mu
+}
+
+func (mu *MediaUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := mu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(media.Table, media.Columns, sqlgraph.NewFieldSpec(media.FieldID, field.TypeInt))
+	if ps := mu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := mu.mutation.Filename(); ok {
+		_spec.SetField(media.FieldFilename, field.TypeString, value)
+	}
+	if value, ok := mu.mutation.Size(); ok {
+		_spec.SetField(media.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := mu.mutation.AddedSize(); ok {
+		_spec.AddField(media.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := mu.mutation.Width(); ok {
+		_spec.SetField(media.FieldWidth, field.TypeInt, value)
+	}
+	if value, ok := mu.mutation.AddedWidth(); ok {
+		_spec.AddField(media.FieldWidth, field.TypeInt, value)
+	}
+	if mu.mutation.WidthCleared() {
+		_spec.ClearField(media.FieldWidth, field.TypeInt)
+	}
+	if value, ok := mu.mutation.Height(); ok {
+		_spec.SetField(media.FieldHeight, field.TypeInt, value)
+	}
+	if value, ok := mu.mutation.AddedHeight(); ok {
+		_spec.AddField(media.FieldHeight, field.TypeInt, value)
+	}
+	if mu.mutation.HeightCleared() {
+		_spec.ClearField(media.FieldHeight, field.TypeInt)
+	}
+	if value, ok := mu.mutation.Variants(); ok {
+		_spec.SetField(media.FieldVariants, field.TypeJSON, value)
+	}
+	if value, ok := mu.mutation.AppendedVariants(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, media.FieldVariants, value)
+		})
+	}
+	if mu.mutation.VariantsCleared() {
+		_spec.ClearField(media.FieldVariants, field.TypeJSON)
+	}
+	if mu.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   media.OwnerTable,
+			Columns: []string{media.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := mu.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   media.OwnerTable,
+			Columns: []string{media.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if mu.mutation.PostCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   media.PostTable,
+			Columns: []string{media.PostColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(post.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := mu.mutation.PostIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   media.PostTable,
+			Columns: []string{media.PostColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(post.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(mu.modifiers...)
+	if n, err = sqlgraph.UpdateNodes(ctx, mu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{media.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	mu.mutation.done = true
+	return n, nil
+}
+
+// MediaUpdateOne is the builder for updating a single Media entity.
+type MediaUpdateOne struct {
+	config
+	fields    []string
+	hooks     []Hook
+	mutation  *MediaMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// SetFilename sets the "filename" field.
+func (muo *MediaUpdateOne) SetFilename(s string) *MediaUpdateOne {
+	muo.mutation.SetFilename(s)
+	return muo
+}
+
+// SetNillableFilename sets the "filename" field if the given value is not nil.
+func (muo *MediaUpdateOne) SetNillableFilename(s *string) *MediaUpdateOne {
+	if s != nil {
+		muo.SetFilename(*s)
+	}
+	return muo
+}
+
+// SetSize sets the "size" field.
+func (muo *MediaUpdateOne) SetSize(i int64) *MediaUpdateOne {
+	muo.mutation.ResetSize()
+	muo.mutation.SetSize(i)
+	return muo
+}
+
+// SetNillableSize sets the "size" field if the given value is not nil.
+func (muo *MediaUpdateOne) SetNillableSize(i *int64) *MediaUpdateOne {
+	if i != nil {
+		muo.SetSize(*i)
+	}
+	return muo
+}
+
+// AddSize adds i to the "size" field.
+func (muo *MediaUpdateOne) AddSize(i int64) *MediaUpdateOne {
+	muo.mutation.AddSize(i)
+	return muo
+}
+
+// SetWidth sets the "width" field.
+func (muo *MediaUpdateOne) SetWidth(i int) *MediaUpdateOne {
+	muo.mutation.ResetWidth()
+	muo.mutation.SetWidth(i)
+	return muo
+}
+
+// SetNillableWidth sets the "width" field if the given value is not nil.
+func (muo *MediaUpdateOne) SetNillableWidth(i *int) *MediaUpdateOne {
+	if i != nil {
+		muo.SetWidth(*i)
+	}
+	return muo
+}
+
+// AddWidth adds i to the "width" field.
+func (muo *MediaUpdateOne) AddWidth(i int) *MediaUpdateOne {
+	muo.mutation.AddWidth(i)
+	return muo
+}
+
+// ClearWidth clears the value of the "width" field.
+func (muo *MediaUpdateOne) ClearWidth() *MediaUpdateOne {
+	muo.mutation.ClearWidth()
+	return muo
+}
+
+// SetHeight sets the "height" field.
+func (muo *MediaUpdateOne) SetHeight(i int) *MediaUpdateOne {
+	muo.mutation.ResetHeight()
+	muo.mutation.SetHeight(i)
+	return muo
+}
+
+// SetNillableHeight sets the "height" field if the given value is not nil.
+func (muo *MediaUpdateOne) SetNillableHeight(i *int) *MediaUpdateOne {
+	if i != nil {
+		muo.SetHeight(*i)
+	}
+	return muo
+}
+
+// AddHeight adds i to the "height" field.
+func (muo *MediaUpdateOne) AddHeight(i int) *MediaUpdateOne {
+	muo.mutation.AddHeight(i)
+	return muo
+}
+
+// ClearHeight clears the value of the "height" field.
+func (muo *MediaUpdateOne) ClearHeight() *MediaUpdateOne {
+	muo.mutation.ClearHeight()
+	return muo
+}
+
+// SetVariants sets the "variants" field.
+func (muo *MediaUpdateOne) SetVariants(i []imaging.Variant) *MediaUpdateOne {
+	muo.mutation.SetVariants(i)
+	return muo
+}
+
+// AppendVariants appends i to the "variants" field.
+func (muo *MediaUpdateOne) AppendVariants(i []imaging.Variant) *MediaUpdateOne {
+	muo.mutation.AppendVariants(i)
+	return muo
+}
+
+// ClearVariants clears the value of the "variants" field.
+func (muo *MediaUpdateOne) ClearVariants() *MediaUpdateOne {
+	muo.mutation.ClearVariants()
+	return muo
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (muo *MediaUpdateOne) SetOwnerID(id int) *MediaUpdateOne {
+	muo.mutation.SetOwnerID(id)
+	return muo
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (muo *MediaUpdateOne) SetOwner(u *User) *MediaUpdateOne {
+	return muo.SetOwnerID(u.ID)
+}
+
+// SetPostID sets the "post" edge to the Post entity by ID.
+func (muo *MediaUpdateOne) SetPostID(id int) *MediaUpdateOne {
+	muo.mutation.SetPostID(id)
+	return muo
+}
+
+// SetNillablePostID sets the "post" edge to the Post entity by ID if the given value is not nil.
+func (muo *MediaUpdateOne) SetNillablePostID(id *int) *MediaUpdateOne {
+	if id != nil {
+		muo = muo.SetPostID(*id)
+	}
+	return muo
+}
+
+// SetPost sets the "post" edge to the Post entity.
+func (muo *MediaUpdateOne) SetPost(p *Post) *MediaUpdateOne {
+	return muo.SetPostID(p.ID)
+}
+
+// Mutation returns the MediaMutation object of the builder.
+func (muo *MediaUpdateOne) Mutation() *MediaMutation {
+	return muo.mutation
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (muo *MediaUpdateOne) ClearOwner() *MediaUpdateOne {
+	muo.mutation.ClearOwner()
+	return muo
+}
+
+// ClearPost clears the "post" edge to the Post entity.
+func (muo *MediaUpdateOne) ClearPost() *MediaUpdateOne {
+	muo.mutation.ClearPost()
+	return muo
+}
+
+// Where appends a list predicates to the MediaUpdate builder.
+func (muo *MediaUpdateOne) Where(ps ...predicate.Media) *MediaUpdateOne {
+	muo.mutation.Where(ps...)
+	return muo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (muo *MediaUpdateOne) Select(field string, fields ...string) *MediaUpdateOne {
+	muo.fields = append([]string{field}, fields...)
+	return muo
+}
+
+// Save executes the query and returns the updated Media entity.
+func (muo *MediaUpdateOne) Save(ctx context.Context) (*Media, error) {
+	return withHooks(ctx, muo.sqlSave, muo.mutation, muo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (muo *MediaUpdateOne) SaveX(ctx context.Context) *Media {
+	node, err := muo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (muo *MediaUpdateOne) Exec(ctx context.Context) error {
+	_, err := muo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (muo *MediaUpdateOne) ExecX(ctx context.Context) {
+	if err := muo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (muo *MediaUpdateOne) check() error {
+	if v, ok := muo.mutation.Filename(); ok {
+		if err := media.FilenameValidator(v); err != nil {
+			return &ValidationError{Name: "filename", err: fmt.Errorf(`ent: validator failed for field "Media.filename": %w`, err)}
+		}
+	}
+	if v, ok := muo.mutation.Size(); ok {
+		if err := media.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "Media.size": %w`, err)}
+		}
+	}
+	if v, ok := muo.mutation.Width(); ok {
+		if err := media.WidthValidator(v); err != nil {
+			return &ValidationError{Name: "width", err: fmt.Errorf(`ent: validator failed for field "Media.width": %w`, err)}
+		}
+	}
+	if v, ok := muo.mutation.Height(); ok {
+		if err := media.HeightValidator(v); err != nil {
+			return &ValidationError{Name: "height", err: fmt.Errorf(`ent: validator failed for field "Media.height": %w`, err)}
+		}
+	}
+	if _, ok := muo.mutation.OwnerID(); muo.mutation.OwnerCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Media.owner"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (muo *MediaUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *MediaUpdateOne {
+	muo.modifiers = append(muo.modifiers, modifiers...)
+	return muo
+}
+
+func (muo *MediaUpdateOne) sqlSave(ctx context.Context) (_node *Media, err error) {
+	if err := muo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(media.Table, media.Columns, sqlgraph.NewFieldSpec(media.FieldID, field.TypeInt))
+	id, ok := muo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Media.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := muo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, media.FieldID)
+		for _, f := range fields {
+			if !media.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != media.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := muo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := muo.mutation.Filename(); ok {
+		_spec.SetField(media.FieldFilename, field.TypeString, value)
+	}
+	if value, ok := muo.mutation.Size(); ok {
+		_spec.SetField(media.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := muo.mutation.AddedSize(); ok {
+		_spec.AddField(media.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := muo.mutation.Width(); ok {
+		_spec.SetField(media.FieldWidth, field.TypeInt, value)
+	}
+	if value, ok := muo.mutation.AddedWidth(); ok {
+		_spec.AddField(media.FieldWidth, field.TypeInt, value)
+	}
+	if muo.mutation.WidthCleared() {
+		_spec.ClearField(media.FieldWidth, field.TypeInt)
+	}
+	if value, ok := muo.mutation.Height(); ok {
+		_spec.SetField(media.FieldHeight, field.TypeInt, value)
+	}
+	if value, ok := muo.mutation.AddedHeight(); ok {
+		_spec.AddField(media.FieldHeight, field.TypeInt, value)
+	}
+	if muo.mutation.HeightCleared() {
+		_spec.ClearField(media.FieldHeight, field.TypeInt)
+	}
+	if value, ok := muo.mutation.Variants(); ok {
+		_spec.SetField(media.FieldVariants, field.TypeJSON, value)
+	}
+	if value, ok := muo.mutation.AppendedVariants(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, media.FieldVariants, value)
+		})
+	}
+	if muo.mutation.VariantsCleared() {
+		_spec.ClearField(media.FieldVariants, field.TypeJSON)
+	}
+	if muo.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   media.OwnerTable,
+			Columns: []string{media.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := muo.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   media.OwnerTable,
+			Columns: []string{media.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if muo.mutation.PostCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   media.PostTable,
+			Columns: []string{media.PostColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(post.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := muo.mutation.PostIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   media.PostTable,
+			Columns: []string{media.PostColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(post.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(muo.modifiers...)
+	_node = &Media{config: muo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, muo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{media.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	muo.mutation.done = true
+	return _node, nil
+}
@@ -24,6 +24,7 @@ type PasswordTokenQuery struct {
 	predicates []predicate.PasswordToken
 	withUser   *UserQuery
 	withFKs    bool
+	modifiers  []func(*sql.Selector)
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -390,6 +391,9 @@ func (ptq *PasswordTokenQuery) sqlAll(ctx context.Context, hooks ...queryHook) (
 		node.Edges.loadedTypes = loadedTypes
 		return node.assignValues(columns, values)
 	}
+	if len(ptq.modifiers) > 0 {
+		_spec.Modifiers = ptq.modifiers
+	}
 	for i := range hooks {
 		hooks[i](ctx, _spec)
 	}
@@ -443,6 +447,9 @@ func (ptq *PasswordTokenQuery) loadUser(ctx context.Context, query *UserQuery, n
 
 func (ptq *PasswordTokenQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := ptq.querySpec()
+	if len(ptq.modifiers) > 0 {
+		_spec.Modifiers = ptq.modifiers
+	}
 	_spec.Node.Columns = ptq.ctx.Fields
 	if len(ptq.ctx.Fields) > 0 {
 		_spec.Unique = ptq.ctx.Unique != nil && *ptq.ctx.Unique
@@ -505,6 +512,9 @@ func (ptq *PasswordTokenQuery) sqlQuery(ctx context.Context) *sql.Selector {
 	if ptq.ctx.Unique != nil && *ptq.ctx.Unique {
 		selector.Distinct()
 	}
+	for _, m := range ptq.modifiers {
+		m(selector)
+	}
 	for _, p := range ptq.predicates {
 		p(selector)
 	}
@@ -522,6 +532,12 @@ func (ptq *PasswordTokenQuery) sqlQuery(ctx context.Context) *sql.Selector {
 	return selector
 }
 
+// Modify adds a query modifier for attaching custom logic to queries.
+func (ptq *PasswordTokenQuery) Modify(modifiers ...func(s *sql.Selector)) *PasswordTokenSelect {
+	ptq.modifiers = append(ptq.modifiers, modifiers...)
+	return ptq.Select()
+}
+
 // PasswordTokenGroupBy is the group-by builder for PasswordToken entities.
 type PasswordTokenGroupBy struct {
 	selector
@@ -611,3 +627,9 @@ func (pts *PasswordTokenSelect) sqlScan(ctx context.Context, root *PasswordToken
 	defer rows.Close()
 	return sql.ScanSlice(rows, v)
 }
+
+// Modify adds a query modifier for attaching custom logic to queries.
+func (pts *PasswordTokenSelect) Modify(modifiers ...func(s *sql.Selector)) *PasswordTokenSelect {
+	pts.modifiers = append(pts.modifiers, modifiers...)
+	return pts
+}
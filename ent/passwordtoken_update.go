@@ -19,8 +19,9 @@ import (
 // PasswordTokenUpdate is the builder for updating PasswordToken entities.
 type PasswordTokenUpdate struct {
 	config
-	hooks    []Hook
-	mutation *PasswordTokenMutation
+	hooks     []Hook
+	mutation  *PasswordTokenMutation
+	modifiers []func(*sql.UpdateBuilder)
 }
 
 // Where appends a list predicates to the PasswordTokenUpdate builder.
@@ -119,6 +120,12 @@ func (ptu *PasswordTokenUpdate) check() error {
 	return nil
 }
 
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (ptu *PasswordTokenUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *PasswordTokenUpdate {
+	ptu.modifiers = append(ptu.modifiers, modifiers...)
+	return ptu
+}
+
 func (ptu *PasswordTokenUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if err := ptu.check(); err != nil {
 		return n, err
@@ -166,6 +173,7 @@ func (ptu *PasswordTokenUpdate) sqlSave(ctx context.Context) (n int, err error)
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	_spec.AddModifiers(ptu.modifiers...)
 	if n, err = sqlgraph.UpdateNodes(ctx, ptu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{passwordtoken.Label}
@@ -181,9 +189,10 @@ func (ptu *PasswordTokenUpdate) sqlSave(ctx context.Context) (n int, err error)
 // PasswordTokenUpdateOne is the builder for updating a single PasswordToken entity.
 type PasswordTokenUpdateOne struct {
 	config
-	fields   []string
-	hooks    []Hook
-	mutation *PasswordTokenMutation
+	fields    []string
+	hooks     []Hook
+	mutation  *PasswordTokenMutation
+	modifiers []func(*sql.UpdateBuilder)
 }
 
 // SetHash sets the "hash" field.
@@ -289,6 +298,12 @@ func (ptuo *PasswordTokenUpdateOne) check() error {
 	return nil
 }
 
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (ptuo *PasswordTokenUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *PasswordTokenUpdateOne {
+	ptuo.modifiers = append(ptuo.modifiers, modifiers...)
+	return ptuo
+}
+
 func (ptuo *PasswordTokenUpdateOne) sqlSave(ctx context.Context) (_node *PasswordToken, err error) {
 	if err := ptuo.check(); err != nil {
 		return _node, err
@@ -353,6 +368,7 @@ func (ptuo *PasswordTokenUpdateOne) sqlSave(ctx context.Context) (_node *Passwor
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	_spec.AddModifiers(ptuo.modifiers...)
 	_node = &PasswordToken{config: ptuo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -3,17 +3,160 @@
 package runtime
 
 import (
+	"context"
 	"time"
 
+	"github.com/mikestefanello/pagoda/ent/auditevent"
+	"github.com/mikestefanello/pagoda/ent/category"
+	"github.com/mikestefanello/pagoda/ent/comment"
+	"github.com/mikestefanello/pagoda/ent/credential"
+	"github.com/mikestefanello/pagoda/ent/failedmail"
+	"github.com/mikestefanello/pagoda/ent/identity"
+	"github.com/mikestefanello/pagoda/ent/media"
 	"github.com/mikestefanello/pagoda/ent/passwordtoken"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/postrevision"
+	"github.com/mikestefanello/pagoda/ent/recoverycode"
 	"github.com/mikestefanello/pagoda/ent/schema"
+	"github.com/mikestefanello/pagoda/ent/subscriber"
+	"github.com/mikestefanello/pagoda/ent/tag"
 	"github.com/mikestefanello/pagoda/ent/user"
+
+	"entgo.io/ent"
+	"entgo.io/ent/privacy"
 )
 
 // The init function reads all schema descriptors with runtime code
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	auditeventHooks := schema.AuditEvent{}.Hooks()
+	auditevent.Hooks[0] = auditeventHooks[0]
+	auditeventFields := schema.AuditEvent{}.Fields()
+	_ = auditeventFields
+	// auditeventDescAction is the schema descriptor for action field.
+	auditeventDescAction := auditeventFields[0].Descriptor()
+	// auditevent.ActionValidator is a validator for the "action" field. It is called by the builders before save.
+	auditevent.ActionValidator = auditeventDescAction.Validators[0].(func(string) error)
+	// auditeventDescCreatedAt is the schema descriptor for created_at field.
+	auditeventDescCreatedAt := auditeventFields[7].Descriptor()
+	// auditevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditevent.DefaultCreatedAt = auditeventDescCreatedAt.Default.(func() time.Time)
+	categoryHooks := schema.Category{}.Hooks()
+	category.Hooks[0] = categoryHooks[0]
+	categoryFields := schema.Category{}.Fields()
+	_ = categoryFields
+	// categoryDescName is the schema descriptor for name field.
+	categoryDescName := categoryFields[0].Descriptor()
+	// category.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	category.NameValidator = categoryDescName.Validators[0].(func(string) error)
+	// categoryDescSlug is the schema descriptor for slug field.
+	categoryDescSlug := categoryFields[1].Descriptor()
+	// category.SlugValidator is a validator for the "slug" field. It is called by the builders before save.
+	category.SlugValidator = categoryDescSlug.Validators[0].(func(string) error)
+	comment.Policy = privacy.NewPolicies(schema.Comment{})
+	comment.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := comment.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	commentFields := schema.Comment{}.Fields()
+	_ = commentFields
+	// commentDescBody is the schema descriptor for body field.
+	commentDescBody := commentFields[0].Descriptor()
+	// comment.BodyValidator is a validator for the "body" field. It is called by the builders before save.
+	comment.BodyValidator = commentDescBody.Validators[0].(func(string) error)
+	// commentDescCreatedAt is the schema descriptor for created_at field.
+	commentDescCreatedAt := commentFields[5].Descriptor()
+	// comment.DefaultCreatedAt holds the default value on creation for the created_at field.
+	comment.DefaultCreatedAt = commentDescCreatedAt.Default.(func() time.Time)
+	credentialFields := schema.Credential{}.Fields()
+	_ = credentialFields
+	// credentialDescCredentialID is the schema descriptor for credential_id field.
+	credentialDescCredentialID := credentialFields[0].Descriptor()
+	// credential.CredentialIDValidator is a validator for the "credential_id" field. It is called by the builders before save.
+	credential.CredentialIDValidator = credentialDescCredentialID.Validators[0].(func([]byte) error)
+	// credentialDescPublicKey is the schema descriptor for public_key field.
+	credentialDescPublicKey := credentialFields[1].Descriptor()
+	// credential.PublicKeyValidator is a validator for the "public_key" field. It is called by the builders before save.
+	credential.PublicKeyValidator = credentialDescPublicKey.Validators[0].(func([]byte) error)
+	// credentialDescName is the schema descriptor for name field.
+	credentialDescName := credentialFields[2].Descriptor()
+	// credential.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	credential.NameValidator = credentialDescName.Validators[0].(func(string) error)
+	// credentialDescSignCount is the schema descriptor for sign_count field.
+	credentialDescSignCount := credentialFields[6].Descriptor()
+	// credential.DefaultSignCount holds the default value on creation for the sign_count field.
+	credential.DefaultSignCount = credentialDescSignCount.Default.(uint32)
+	// credentialDescBackupEligible is the schema descriptor for backup_eligible field.
+	credentialDescBackupEligible := credentialFields[7].Descriptor()
+	// credential.DefaultBackupEligible holds the default value on creation for the backup_eligible field.
+	credential.DefaultBackupEligible = credentialDescBackupEligible.Default.(bool)
+	// credentialDescBackupState is the schema descriptor for backup_state field.
+	credentialDescBackupState := credentialFields[8].Descriptor()
+	// credential.DefaultBackupState holds the default value on creation for the backup_state field.
+	credential.DefaultBackupState = credentialDescBackupState.Default.(bool)
+	// credentialDescCreatedAt is the schema descriptor for created_at field.
+	credentialDescCreatedAt := credentialFields[9].Descriptor()
+	// credential.DefaultCreatedAt holds the default value on creation for the created_at field.
+	credential.DefaultCreatedAt = credentialDescCreatedAt.Default.(func() time.Time)
+	failedmailFields := schema.FailedMail{}.Fields()
+	_ = failedmailFields
+	// failedmailDescAttempts is the schema descriptor for attempts field.
+	failedmailDescAttempts := failedmailFields[4].Descriptor()
+	// failedmail.AttemptsValidator is a validator for the "attempts" field. It is called by the builders before save.
+	failedmail.AttemptsValidator = failedmailDescAttempts.Validators[0].(func(int) error)
+	// failedmailDescCreatedAt is the schema descriptor for created_at field.
+	failedmailDescCreatedAt := failedmailFields[5].Descriptor()
+	// failedmail.DefaultCreatedAt holds the default value on creation for the created_at field.
+	failedmail.DefaultCreatedAt = failedmailDescCreatedAt.Default.(func() time.Time)
+	identityFields := schema.Identity{}.Fields()
+	_ = identityFields
+	// identityDescProvider is the schema descriptor for provider field.
+	identityDescProvider := identityFields[0].Descriptor()
+	// identity.ProviderValidator is a validator for the "provider" field. It is called by the builders before save.
+	identity.ProviderValidator = identityDescProvider.Validators[0].(func(string) error)
+	// identityDescSubject is the schema descriptor for subject field.
+	identityDescSubject := identityFields[1].Descriptor()
+	// identity.SubjectValidator is a validator for the "subject" field. It is called by the builders before save.
+	identity.SubjectValidator = identityDescSubject.Validators[0].(func(string) error)
+	// identityDescCreatedAt is the schema descriptor for created_at field.
+	identityDescCreatedAt := identityFields[3].Descriptor()
+	// identity.DefaultCreatedAt holds the default value on creation for the created_at field.
+	identity.DefaultCreatedAt = identityDescCreatedAt.Default.(func() time.Time)
+	mediaFields := schema.Media{}.Fields()
+	_ = mediaFields
+	// mediaDescKey is the schema descriptor for key field.
+	mediaDescKey := mediaFields[0].Descriptor()
+	// media.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	media.KeyValidator = mediaDescKey.Validators[0].(func(string) error)
+	// mediaDescFilename is the schema descriptor for filename field.
+	mediaDescFilename := mediaFields[1].Descriptor()
+	// media.FilenameValidator is a validator for the "filename" field. It is called by the builders before save.
+	media.FilenameValidator = mediaDescFilename.Validators[0].(func(string) error)
+	// mediaDescContentType is the schema descriptor for content_type field.
+	mediaDescContentType := mediaFields[2].Descriptor()
+	// media.ContentTypeValidator is a validator for the "content_type" field. It is called by the builders before save.
+	media.ContentTypeValidator = mediaDescContentType.Validators[0].(func(string) error)
+	// mediaDescSize is the schema descriptor for size field.
+	mediaDescSize := mediaFields[3].Descriptor()
+	// media.SizeValidator is a validator for the "size" field. It is called by the builders before save.
+	media.SizeValidator = mediaDescSize.Validators[0].(func(int64) error)
+	// mediaDescWidth is the schema descriptor for width field.
+	mediaDescWidth := mediaFields[4].Descriptor()
+	// media.WidthValidator is a validator for the "width" field. It is called by the builders before save.
+	media.WidthValidator = mediaDescWidth.Validators[0].(func(int) error)
+	// mediaDescHeight is the schema descriptor for height field.
+	mediaDescHeight := mediaFields[5].Descriptor()
+	// media.HeightValidator is a validator for the "height" field. It is called by the builders before save.
+	media.HeightValidator = mediaDescHeight.Validators[0].(func(int) error)
+	// mediaDescCreatedAt is the schema descriptor for created_at field.
+	mediaDescCreatedAt := mediaFields[7].Descriptor()
+	// media.DefaultCreatedAt holds the default value on creation for the created_at field.
+	media.DefaultCreatedAt = mediaDescCreatedAt.Default.(func() time.Time)
 	passwordtokenFields := schema.PasswordToken{}.Fields()
 	_ = passwordtokenFields
 	// passwordtokenDescHash is the schema descriptor for hash field.
@@ -24,8 +167,91 @@ func init() {
 	passwordtokenDescCreatedAt := passwordtokenFields[1].Descriptor()
 	// passwordtoken.DefaultCreatedAt holds the default value on creation for the created_at field.
 	passwordtoken.DefaultCreatedAt = passwordtokenDescCreatedAt.Default.(func() time.Time)
+	post.Policy = privacy.NewPolicies(schema.Post{})
+	post.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := post.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	postHooks := schema.Post{}.Hooks()
+
+	post.Hooks[1] = postHooks[0]
+
+	post.Hooks[2] = postHooks[1]
+
+	post.Hooks[3] = postHooks[2]
+
+	post.Hooks[4] = postHooks[3]
+	postFields := schema.Post{}.Fields()
+	_ = postFields
+	// postDescTitle is the schema descriptor for title field.
+	postDescTitle := postFields[0].Descriptor()
+	// post.TitleValidator is a validator for the "title" field. It is called by the builders before save.
+	post.TitleValidator = postDescTitle.Validators[0].(func(string) error)
+	// postDescSlug is the schema descriptor for slug field.
+	postDescSlug := postFields[1].Descriptor()
+	// post.SlugValidator is a validator for the "slug" field. It is called by the builders before save.
+	post.SlugValidator = postDescSlug.Validators[0].(func(string) error)
+	// postDescBody is the schema descriptor for body field.
+	postDescBody := postFields[2].Descriptor()
+	// post.BodyValidator is a validator for the "body" field. It is called by the builders before save.
+	post.BodyValidator = postDescBody.Validators[0].(func(string) error)
+	// postDescCreatedAt is the schema descriptor for created_at field.
+	postDescCreatedAt := postFields[8].Descriptor()
+	// post.DefaultCreatedAt holds the default value on creation for the created_at field.
+	post.DefaultCreatedAt = postDescCreatedAt.Default.(func() time.Time)
+	// postDescUpdatedAt is the schema descriptor for updated_at field.
+	postDescUpdatedAt := postFields[9].Descriptor()
+	// post.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	post.DefaultUpdatedAt = postDescUpdatedAt.Default.(func() time.Time)
+	// post.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	post.UpdateDefaultUpdatedAt = postDescUpdatedAt.UpdateDefault.(func() time.Time)
+	postrevisionFields := schema.PostRevision{}.Fields()
+	_ = postrevisionFields
+	// postrevisionDescCreatedAt is the schema descriptor for created_at field.
+	postrevisionDescCreatedAt := postrevisionFields[4].Descriptor()
+	// postrevision.DefaultCreatedAt holds the default value on creation for the created_at field.
+	postrevision.DefaultCreatedAt = postrevisionDescCreatedAt.Default.(func() time.Time)
+	recoverycodeFields := schema.RecoveryCode{}.Fields()
+	_ = recoverycodeFields
+	// recoverycodeDescHash is the schema descriptor for hash field.
+	recoverycodeDescHash := recoverycodeFields[0].Descriptor()
+	// recoverycode.HashValidator is a validator for the "hash" field. It is called by the builders before save.
+	recoverycode.HashValidator = recoverycodeDescHash.Validators[0].(func(string) error)
+	// recoverycodeDescCreatedAt is the schema descriptor for created_at field.
+	recoverycodeDescCreatedAt := recoverycodeFields[1].Descriptor()
+	// recoverycode.DefaultCreatedAt holds the default value on creation for the created_at field.
+	recoverycode.DefaultCreatedAt = recoverycodeDescCreatedAt.Default.(func() time.Time)
+	subscriberHooks := schema.Subscriber{}.Hooks()
+	subscriber.Hooks[0] = subscriberHooks[0]
+	subscriberFields := schema.Subscriber{}.Fields()
+	_ = subscriberFields
+	// subscriberDescEmail is the schema descriptor for email field.
+	subscriberDescEmail := subscriberFields[0].Descriptor()
+	// subscriber.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	subscriber.EmailValidator = subscriberDescEmail.Validators[0].(func(string) error)
+	// subscriberDescCreatedAt is the schema descriptor for created_at field.
+	subscriberDescCreatedAt := subscriberFields[3].Descriptor()
+	// subscriber.DefaultCreatedAt holds the default value on creation for the created_at field.
+	subscriber.DefaultCreatedAt = subscriberDescCreatedAt.Default.(func() time.Time)
+	tagHooks := schema.Tag{}.Hooks()
+	tag.Hooks[0] = tagHooks[0]
+	tagFields := schema.Tag{}.Fields()
+	_ = tagFields
+	// tagDescName is the schema descriptor for name field.
+	tagDescName := tagFields[0].Descriptor()
+	// tag.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	tag.NameValidator = tagDescName.Validators[0].(func(string) error)
+	// tagDescSlug is the schema descriptor for slug field.
+	tagDescSlug := tagFields[1].Descriptor()
+	// tag.SlugValidator is a validator for the "slug" field. It is called by the builders before save.
+	tag.SlugValidator = tagDescSlug.Validators[0].(func(string) error)
 	userHooks := schema.User{}.Hooks()
 	user.Hooks[0] = userHooks[0]
+	user.Hooks[1] = userHooks[1]
 	userFields := schema.User{}.Fields()
 	_ = userFields
 	// userDescName is the schema descriptor for name field.
@@ -40,12 +266,16 @@ func init() {
 	userDescPassword := userFields[2].Descriptor()
 	// user.PasswordValidator is a validator for the "password" field. It is called by the builders before save.
 	user.PasswordValidator = userDescPassword.Validators[0].(func(string) error)
+	// userDescPasswordSet is the schema descriptor for password_set field.
+	userDescPasswordSet := userFields[3].Descriptor()
+	// user.DefaultPasswordSet holds the default value on creation for the password_set field.
+	user.DefaultPasswordSet = userDescPasswordSet.Default.(bool)
 	// userDescVerified is the schema descriptor for verified field.
-	userDescVerified := userFields[3].Descriptor()
+	userDescVerified := userFields[4].Descriptor()
 	// user.DefaultVerified holds the default value on creation for the verified field.
 	user.DefaultVerified = userDescVerified.Default.(bool)
 	// userDescCreatedAt is the schema descriptor for created_at field.
-	userDescCreatedAt := userFields[4].Descriptor()
+	userDescCreatedAt := userFields[9].Descriptor()
 	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
 	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
 }
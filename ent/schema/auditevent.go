@@ -0,0 +1,75 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent/hook"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// AuditEvent holds the schema definition for the AuditEvent entity.
+// Audit events record security and content events, such as logins and posts being published, along with who
+// performed them and the request they were performed in. Events are append-only and cannot be changed or deleted.
+type AuditEvent struct {
+	ent.Schema
+}
+
+// Fields of the AuditEvent.
+func (AuditEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("action").
+			NotEmpty().
+			Immutable(),
+		field.String("subject_type").
+			Optional().
+			Immutable(),
+		field.Int("subject_id").
+			Optional().
+			Nillable().
+			Immutable(),
+		field.Text("details").
+			Optional().
+			Immutable(),
+		field.String("request_id").
+			Optional().
+			Immutable(),
+		field.String("ip").
+			Optional().
+			Immutable(),
+		field.String("user_agent").
+			Optional().
+			Immutable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the AuditEvent.
+func (AuditEvent) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("user", User.Type).
+			Unique().
+			Immutable(),
+	}
+}
+
+// Indexes of the AuditEvent.
+func (AuditEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("created_at"),
+		index.Fields("action", "created_at"),
+	}
+}
+
+// Hooks of the AuditEvent.
+func (AuditEvent) Hooks() []ent.Hook {
+	return []ent.Hook{
+		// The audit log is append-only
+		hook.Reject(ent.OpUpdate | ent.OpUpdateOne | ent.OpDelete | ent.OpDeleteOne),
+	}
+}
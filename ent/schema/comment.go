@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent/privacy"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Comment holds the schema definition for the Comment entity.
+type Comment struct {
+	ent.Schema
+}
+
+// Fields of the Comment.
+func (Comment) Fields() []ent.Field {
+	return []ent.Field{
+		field.Text("body").
+			NotEmpty(),
+		field.String("guest_name").
+			Optional(),
+		field.String("guest_email").
+			Optional(),
+		field.Enum("status").
+			Values("pending", "approved", "spam").
+			Default("pending"),
+		field.Int("parent_id").
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Comment.
+func (Comment) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("post", Post.Type).
+			Ref("comments").
+			Required().
+			Unique(),
+		edge.From("author", User.Type).
+			Ref("comments").
+			Unique(),
+		edge.To("replies", Comment.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)).
+			From("parent").
+			Field("parent_id").
+			Unique(),
+	}
+}
+
+// Policy of the Comment.
+func (Comment) Policy() ent.Policy {
+	return privacy.Policy{
+		Mutation: privacy.MutationPolicy{
+			permission.CommentMutationRule(),
+		},
+	}
+}
@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Credential holds the schema definition for the Credential entity, which is a WebAuthn passkey.
+type Credential struct {
+	ent.Schema
+}
+
+// Fields of the Credential.
+func (Credential) Fields() []ent.Field {
+	return []ent.Field{
+		field.Bytes("credential_id").
+			NotEmpty().
+			Unique(),
+		field.Bytes("public_key").
+			Sensitive().
+			NotEmpty(),
+		field.String("name").
+			NotEmpty(),
+		field.String("attestation_type").
+			Optional(),
+		field.Strings("transports").
+			Optional(),
+		field.Bytes("aaguid").
+			Optional(),
+		field.Uint32("sign_count").
+			Default(0),
+		field.Bool("backup_eligible").
+			Default(false),
+		field.Bool("backup_state").
+			Default(false),
+		field.Time("created_at").
+			Default(time.Now),
+		field.Time("last_used_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the Credential.
+func (Credential) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("user", User.Type).
+			Required().
+			Unique(),
+	}
+}
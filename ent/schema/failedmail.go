@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/mikestefanello/pagoda/pkg/mailer"
+)
+
+// FailedMail holds the schema definition for the FailedMail entity.
+// Email queued via SendAsync which could not be delivered is stored so it can be inspected and retried.
+type FailedMail struct {
+	ent.Schema
+}
+
+// Fields of the FailedMail.
+func (FailedMail) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("to").
+			Immutable(),
+		field.String("subject").
+			Immutable(),
+		field.JSON("message", &mailer.Message{}).
+			Immutable(),
+		field.Text("error").
+			Immutable(),
+		field.Int("attempts").
+			NonNegative().
+			Immutable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/mikestefanello/pagoda/pkg/imaging"
+)
+
+// Media holds the schema definition for the Media entity.
+type Media struct {
+	ent.Schema
+}
+
+// Fields of the Media.
+func (Media) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("key").
+			NotEmpty().
+			Unique().
+			Immutable(),
+		field.String("filename").
+			NotEmpty(),
+		field.String("content_type").
+			NotEmpty().
+			Immutable(),
+		field.Int64("size").
+			NonNegative(),
+		field.Int("width").
+			Optional().
+			Nillable().
+			Positive(),
+		field.Int("height").
+			Optional().
+			Nillable().
+			Positive(),
+		field.JSON("variants", []imaging.Variant{}).
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Media.
+func (Media) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("owner", User.Type).
+			Ref("media").
+			Required().
+			Unique(),
+		edge.From("post", Post.Type).
+			Ref("media").
+			Unique(),
+	}
+}
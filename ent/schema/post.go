@@ -0,0 +1,132 @@
+package schema
+
+import (
+	"context"
+	"time"
+
+	ge "github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/hook"
+	"github.com/mikestefanello/pagoda/ent/privacy"
+	"github.com/mikestefanello/pagoda/pkg/audit"
+	"github.com/mikestefanello/pagoda/pkg/markdown"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Post holds the schema definition for the Post entity.
+type Post struct {
+	ent.Schema
+}
+
+// Fields of the Post.
+func (Post) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("title").
+			NotEmpty(),
+		slugField(),
+		field.Text("body").
+			NotEmpty(),
+		field.Text("body_text").
+			Optional().
+			Comment("The body without its Markdown syntax, which search headlines are built from"),
+		field.String("excerpt").
+			Optional(),
+		field.Enum("status").
+			Values("draft", "in_review", "scheduled", "published", "archived").
+			Default("draft"),
+		field.Time("published_at").
+			Optional().
+			Nillable(),
+		field.Time("publish_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Post.
+func (Post) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("author", User.Type).
+			Ref("posts").
+			Required().
+			Unique(),
+		edge.To("tags", Tag.Type),
+		edge.To("categories", Category.Type),
+		edge.To("comments", Comment.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("revisions", PostRevision.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("media", Media.Type),
+	}
+}
+
+// Hooks of the Post.
+func (Post) Hooks() []ent.Hook {
+	return []ent.Hook{
+		slugHook("title"),
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return hook.PostFunc(func(ctx context.Context, m *ge.PostMutation) (ent.Value, error) {
+					if body, ok := m.Body(); ok {
+						m.SetBodyText(markdown.PlainText(body))
+					}
+					return next.Mutate(ctx, m)
+				})
+			},
+			ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+		),
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return hook.PostFunc(func(ctx context.Context, m *ge.PostMutation) (ent.Value, error) {
+					v, err := next.Mutate(ctx, m)
+					if err != nil {
+						return v, err
+					}
+
+					// Only changes to the content of the post are recorded as a revision. The revision is created
+					// with the client of the mutation so, if the post is saved within a transaction, which it
+					// should be, the post is never saved without its revision.
+					_, title := m.Title()
+					_, body := m.Body()
+					_, excerpt := m.Excerpt()
+					if !title && !body && !excerpt && !m.ExcerptCleared() {
+						return v, nil
+					}
+
+					p := v.(*ge.Post)
+					err = m.Client().PostRevision.
+						Create().
+						SetPostID(p.ID).
+						SetTitle(p.Title).
+						SetExcerpt(p.Excerpt).
+						SetBody(p.Body).
+						Exec(ctx)
+
+					return v, err
+				})
+			},
+			// Limit the hook only for these operations.
+			ent.OpCreate|ent.OpUpdateOne,
+		),
+		audit.PostHook(),
+	}
+}
+
+// Policy of the Post.
+func (Post) Policy() ent.Policy {
+	return privacy.Policy{
+		Mutation: privacy.MutationPolicy{
+			permission.PostMutationRule(),
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// PostRevision holds the schema definition for the PostRevision entity.
+type PostRevision struct {
+	ent.Schema
+}
+
+// Fields of the PostRevision.
+func (PostRevision) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("post_id").
+			Immutable(),
+		field.String("title").
+			Immutable(),
+		field.String("excerpt").
+			Optional().
+			Immutable(),
+		field.Text("body").
+			Immutable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the PostRevision.
+func (PostRevision) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("post", Post.Type).
+			Ref("revisions").
+			Field("post_id").
+			Required().
+			Unique().
+			Immutable(),
+	}
+}
@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/mikestefanello/pagoda/ent/hook"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// slugInvalidChars matches runs of characters which are not permitted in a slug
+var slugInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugField returns the field definition for a unique slug
+func slugField() ent.Field {
+	return field.String("slug").
+		NotEmpty().
+		Unique()
+}
+
+// slugHook normalizes the slug field of an entity, or derives one from a given source field when an entity
+// is created without a slug
+func slugHook(source string) ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+				v, exists := m.Field("slug")
+				if !exists && m.Op().Is(ent.OpCreate) {
+					v, exists = m.Field(source)
+				}
+
+				if s, ok := v.(string); exists && ok {
+					if err := m.SetField("slug", Slugify(s)); err != nil {
+						return nil, err
+					}
+				}
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		// Limit the hook only for these operations.
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+	)
+}
+
+// Slugify converts a string in to a URL-safe slug
+func Slugify(s string) string {
+	s = slugInvalidChars.ReplaceAllString(strings.ToLower(s), "-")
+	return strings.Trim(s, "-")
+}
@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	ge "github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/hook"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// Subscriber holds the schema definition for the Subscriber entity.
+// Subscribers receive the newsletter digest once they have confirmed their email address.
+type Subscriber struct {
+	ent.Schema
+}
+
+// Fields of the Subscriber.
+func (Subscriber) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("email").
+			NotEmpty().
+			Unique(),
+		field.Time("confirmed_at").
+			Optional().
+			Nillable(),
+		field.Time("digest_sent_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Hooks of the Subscriber.
+func (Subscriber) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return hook.SubscriberFunc(func(ctx context.Context, m *ge.SubscriberMutation) (ent.Value, error) {
+					if v, exists := m.Email(); exists {
+						m.SetEmail(strings.ToLower(v))
+					}
+					return next.Mutate(ctx, m)
+				})
+			},
+			// Limit the hook only for these operations.
+			ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+		),
+	}
+}
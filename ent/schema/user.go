@@ -7,6 +7,7 @@ import (
 
 	ge "github.com/mikestefanello/pagoda/ent"
 	"github.com/mikestefanello/pagoda/ent/hook"
+	"github.com/mikestefanello/pagoda/pkg/audit"
 
 	"entgo.io/ent"
 	"entgo.io/ent/schema/edge"
@@ -29,8 +30,23 @@ func (User) Fields() []ent.Field {
 		field.String("password").
 			Sensitive().
 			NotEmpty(),
+		field.Bool("password_set").
+			Default(true),
 		field.Bool("verified").
 			Default(false),
+		field.Enum("role").
+			Values("admin", "editor", "author", "contributor", "reader").
+			Default("reader"),
+		field.Time("banned_at").
+			Optional().
+			Nillable(),
+		field.String("totp_secret").
+			Sensitive().
+			Optional().
+			Nillable(),
+		field.Int64("totp_last_step").
+			Optional().
+			Nillable(),
 		field.Time("created_at").
 			Default(time.Now).
 			Immutable(),
@@ -42,6 +58,17 @@ func (User) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.From("owner", PasswordToken.Type).
 			Ref("user"),
+		edge.From("credentials", Credential.Type).
+			Ref("user"),
+		edge.From("identities", Identity.Type).
+			Ref("user"),
+		edge.From("recovery_codes", RecoveryCode.Type).
+			Ref("user"),
+		edge.From("audit_events", AuditEvent.Type).
+			Ref("user"),
+		edge.To("posts", Post.Type),
+		edge.To("comments", Comment.Type),
+		edge.To("media", Media.Type),
 	}
 }
 
@@ -60,5 +87,6 @@ func (User) Hooks() []ent.Hook {
 			// Limit the hook only for these operations.
 			ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
 		),
+		audit.UserHook(),
 	}
 }
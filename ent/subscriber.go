@@ -0,0 +1,145 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/mikestefanello/pagoda/ent/subscriber"
+)
+
+// Subscriber is the model entity for the Subscriber schema.
+type Subscriber struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Email holds the value of the "email" field.
+	Email string `json:"email,omitempty"`
+	// ConfirmedAt holds the value of the "confirmed_at" field.
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+	// DigestSentAt holds the value of the "digest_sent_at" field.
+	DigestSentAt *time.Time `json:"digest_sent_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Subscriber) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case subscriber.FieldID:
+			values[i] = new(sql.NullInt64)
+		case subscriber.FieldEmail:
+			values[i] = new(sql.NullString)
+		case subscriber.FieldConfirmedAt, subscriber.FieldDigestSentAt, subscriber.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Subscriber fields.
+func (s *Subscriber) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case subscriber.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			s.ID = int(value.Int64)
+		case subscriber.FieldEmail:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field email", values[i])
+			} else if value.Valid {
+				s.Email = value.String
+			}
+		case subscriber.FieldConfirmedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field confirmed_at", values[i])
+			} else if value.Valid {
+				s.ConfirmedAt = new(time.Time)
+				*s.ConfirmedAt = value.Time
+			}
+		case subscriber.FieldDigestSentAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field digest_sent_at", values[i])
+			} else if value.Valid {
+				s.DigestSentAt = new(time.Time)
+				*s.DigestSentAt = value.Time
+			}
+		case subscriber.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				s.CreatedAt = value.Time
+			}
+		default:
+			s.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Subscriber.
+// This includes values selected through modifiers, order, etc.
+func (s *Subscriber) Value(name string) (ent.Value, error) {
+	return s.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Subscriber.
+// Note that you need to call Subscriber.Unwrap() before calling this method if this Subscriber
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (s *Subscriber) Update() *SubscriberUpdateOne {
+	return NewSubscriberClient(s.config).UpdateOne(s)
+}
+
+// Unwrap unwraps the Subscriber entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (s *Subscriber) Unwrap() *Subscriber {
+	_tx, ok := s.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Subscriber is not a transactional entity")
+	}
+	s.config.driver = _tx.drv
+	return s
+}
+
+// String implements the fmt.Stringer.
+func (s *Subscriber) String() string {
+	var builder strings.Builder
+	builder.WriteString("Subscriber(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", s.ID))
+	builder.WriteString("email=")
+	builder.WriteString(s.Email)
+	builder.WriteString(", ")
+	if v := s.ConfirmedAt; v != nil {
+		builder.WriteString("confirmed_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := s.DigestSentAt; v != nil {
+		builder.WriteString("digest_sent_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(s.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Subscribers is a parsable slice of Subscriber.
+type Subscribers []*Subscriber
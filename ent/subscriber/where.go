@@ -0,0 +1,295 @@
+// Code generated by ent, DO NOT EDIT.
+
+package subscriber
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/mikestefanello/pagoda/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldLTE(FieldID, id))
+}
+
+// Email applies equality check predicate on the "email" field. It's identical to EmailEQ.
+func Email(v string) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldEQ(FieldEmail, v))
+}
+
+// ConfirmedAt applies equality check predicate on the "confirmed_at" field. It's identical to ConfirmedAtEQ.
+func ConfirmedAt(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldEQ(FieldConfirmedAt, v))
+}
+
+// DigestSentAt applies equality check predicate on the "digest_sent_at" field. It's identical to DigestSentAtEQ.
+func DigestSentAt(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldEQ(FieldDigestSentAt, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// EmailEQ applies the EQ predicate on the "email" field.
+func EmailEQ(v string) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldEQ(FieldEmail, v))
+}
+
+// EmailNEQ applies the NEQ predicate on the "email" field.
+func EmailNEQ(v string) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldNEQ(FieldEmail, v))
+}
+
+// EmailIn applies the In predicate on the "email" field.
+func EmailIn(vs ...string) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldIn(FieldEmail, vs...))
+}
+
+// EmailNotIn applies the NotIn predicate on the "email" field.
+func EmailNotIn(vs ...string) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldNotIn(FieldEmail, vs...))
+}
+
+// EmailGT applies the GT predicate on the "email" field.
+func EmailGT(v string) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldGT(FieldEmail, v))
+}
+
+// EmailGTE applies the GTE predicate on the "email" field.
+func EmailGTE(v string) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldGTE(FieldEmail, v))
+}
+
+// EmailLT applies the LT predicate on the "email" field.
+func EmailLT(v string) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldLT(FieldEmail, v))
+}
+
+// EmailLTE applies the LTE predicate on the "email" field.
+func EmailLTE(v string) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldLTE(FieldEmail, v))
+}
+
+// EmailContains applies the Contains predicate on the "email" field.
+func EmailContains(v string) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldContains(FieldEmail, v))
+}
+
+// EmailHasPrefix applies the HasPrefix predicate on the "email" field.
+func EmailHasPrefix(v string) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldHasPrefix(FieldEmail, v))
+}
+
+// EmailHasSuffix applies the HasSuffix predicate on the "email" field.
+func EmailHasSuffix(v string) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldHasSuffix(FieldEmail, v))
+}
+
+// EmailEqualFold applies the EqualFold predicate on the "email" field.
+func EmailEqualFold(v string) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldEqualFold(FieldEmail, v))
+}
+
+// EmailContainsFold applies the ContainsFold predicate on the "email" field.
+func EmailContainsFold(v string) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldContainsFold(FieldEmail, v))
+}
+
+// ConfirmedAtEQ applies the EQ predicate on the "confirmed_at" field.
+func ConfirmedAtEQ(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldEQ(FieldConfirmedAt, v))
+}
+
+// ConfirmedAtNEQ applies the NEQ predicate on the "confirmed_at" field.
+func ConfirmedAtNEQ(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldNEQ(FieldConfirmedAt, v))
+}
+
+// ConfirmedAtIn applies the In predicate on the "confirmed_at" field.
+func ConfirmedAtIn(vs ...time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldIn(FieldConfirmedAt, vs...))
+}
+
+// ConfirmedAtNotIn applies the NotIn predicate on the "confirmed_at" field.
+func ConfirmedAtNotIn(vs ...time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldNotIn(FieldConfirmedAt, vs...))
+}
+
+// ConfirmedAtGT applies the GT predicate on the "confirmed_at" field.
+func ConfirmedAtGT(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldGT(FieldConfirmedAt, v))
+}
+
+// ConfirmedAtGTE applies the GTE predicate on the "confirmed_at" field.
+func ConfirmedAtGTE(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldGTE(FieldConfirmedAt, v))
+}
+
+// ConfirmedAtLT applies the LT predicate on the "confirmed_at" field.
+func ConfirmedAtLT(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldLT(FieldConfirmedAt, v))
+}
+
+// ConfirmedAtLTE applies the LTE predicate on the "confirmed_at" field.
+func ConfirmedAtLTE(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldLTE(FieldConfirmedAt, v))
+}
+
+// ConfirmedAtIsNil applies the IsNil predicate on the "confirmed_at" field.
+func ConfirmedAtIsNil() predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldIsNull(FieldConfirmedAt))
+}
+
+// ConfirmedAtNotNil applies the NotNil predicate on the "confirmed_at" field.
+func ConfirmedAtNotNil() predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldNotNull(FieldConfirmedAt))
+}
+
+// DigestSentAtEQ applies the EQ predicate on the "digest_sent_at" field.
+func DigestSentAtEQ(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldEQ(FieldDigestSentAt, v))
+}
+
+// DigestSentAtNEQ applies the NEQ predicate on the "digest_sent_at" field.
+func DigestSentAtNEQ(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldNEQ(FieldDigestSentAt, v))
+}
+
+// DigestSentAtIn applies the In predicate on the "digest_sent_at" field.
+func DigestSentAtIn(vs ...time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldIn(FieldDigestSentAt, vs...))
+}
+
+// DigestSentAtNotIn applies the NotIn predicate on the "digest_sent_at" field.
+func DigestSentAtNotIn(vs ...time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldNotIn(FieldDigestSentAt, vs...))
+}
+
+// DigestSentAtGT applies the GT predicate on the "digest_sent_at" field.
+func DigestSentAtGT(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldGT(FieldDigestSentAt, v))
+}
+
+// DigestSentAtGTE applies the GTE predicate on the "digest_sent_at" field.
+func DigestSentAtGTE(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldGTE(FieldDigestSentAt, v))
+}
+
+// DigestSentAtLT applies the LT predicate on the "digest_sent_at" field.
+func DigestSentAtLT(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldLT(FieldDigestSentAt, v))
+}
+
+// DigestSentAtLTE applies the LTE predicate on the "digest_sent_at" field.
+func DigestSentAtLTE(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldLTE(FieldDigestSentAt, v))
+}
+
+// DigestSentAtIsNil applies the IsNil predicate on the "digest_sent_at" field.
+func DigestSentAtIsNil() predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldIsNull(FieldDigestSentAt))
+}
+
+// DigestSentAtNotNil applies the NotNil predicate on the "digest_sent_at" field.
+func DigestSentAtNotNil() predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldNotNull(FieldDigestSentAt))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.Subscriber {
+	return predicate.Subscriber(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Subscriber) predicate.Subscriber {
+	return predicate.Subscriber(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Subscriber) predicate.Subscriber {
+	return predicate.Subscriber(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Subscriber) predicate.Subscriber {
+	return predicate.Subscriber(sql.NotPredicates(p))
+}
@@ -0,0 +1,262 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/mikestefanello/pagoda/ent/subscriber"
+)
+
+// SubscriberCreate is the builder for creating a Subscriber entity.
+type SubscriberCreate struct {
+	config
+	mutation *SubscriberMutation
+	hooks    []Hook
+}
+
+// SetEmail sets the "email" field.
+func (sc *SubscriberCreate) SetEmail(s string) *SubscriberCreate {
+	sc.mutation.SetEmail(s)
+	return sc
+}
+
+// SetConfirmedAt sets the "confirmed_at" field.
+func (sc *SubscriberCreate) SetConfirmedAt(t time.Time) *SubscriberCreate {
+	sc.mutation.SetConfirmedAt(t)
+	return sc
+}
+
+// SetNillableConfirmedAt sets the "confirmed_at" field if the given value is not nil.
+func (sc *SubscriberCreate) SetNillableConfirmedAt(t *time.Time) *SubscriberCreate {
+	if t != nil {
+		sc.SetConfirmedAt(*t)
+	}
+	return sc
+}
+
+// SetDigestSentAt sets the "digest_sent_at" field.
+func (sc *SubscriberCreate) SetDigestSentAt(t time.Time) *SubscriberCreate {
+	sc.mutation.SetDigestSentAt(t)
+	return sc
+}
+
+// SetNillableDigestSentAt sets the "digest_sent_at" field if the given value is not nil.
+func (sc *SubscriberCreate) SetNillableDigestSentAt(t *time.Time) *SubscriberCreate {
+	if t != nil {
+		sc.SetDigestSentAt(*t)
+	}
+	return sc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (sc *SubscriberCreate) SetCreatedAt(t time.Time) *SubscriberCreate {
+	sc.mutation.SetCreatedAt(t)
+	return sc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (sc *SubscriberCreate) SetNillableCreatedAt(t *time.Time) *SubscriberCreate {
+	if t != nil {
+		sc.SetCreatedAt(*t)
+	}
+	return sc
+}
+
+// Mutation returns the SubscriberMutation object of the builder.
+func (sc *SubscriberCreate) Mutation() *SubscriberMutation {
+	return sc.mutation
+}
+
+// Save creates the Subscriber in the database.
+func (sc *SubscriberCreate) Save(ctx context.Context) (*Subscriber, error) {
+	if err := sc.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, sc.sqlSave, sc.mutation, sc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (sc *SubscriberCreate) SaveX(ctx context.Context) *Subscriber {
+	v, err := sc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (sc *SubscriberCreate) Exec(ctx context.Context) error {
+	_, err := sc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sc *SubscriberCreate) ExecX(ctx context.Context) {
+	if err := sc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (sc *SubscriberCreate) defaults() error {
+	if _, ok := sc.mutation.CreatedAt(); !ok {
+		if subscriber.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized subscriber.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
+		v := subscriber.DefaultCreatedAt()
+		sc.mutation.SetCreatedAt(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (sc *SubscriberCreate) check() error {
+	if _, ok := sc.mutation.Email(); !ok {
+		return &ValidationError{Name: "email", err: errors.New(`ent: missing required field "Subscriber.email"`)}
+	}
+	if v, ok := sc.mutation.Email(); ok {
+		if err := subscriber.EmailValidator(v); err != nil {
+			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "Subscriber.email": %w`, err)}
+		}
+	}
+	if _, ok := sc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Subscriber.created_at"`)}
+	}
+	return nil
+}
+
+func (sc *SubscriberCreate) sqlSave(ctx context.Context) (*Subscriber, error) {
+	if err := sc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := sc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, sc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	sc.mutation.id = &_node.ID
+	sc.mutation.done = true
+	return _node, nil
+}
+
+func (sc *SubscriberCreate) createSpec() (*Subscriber, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Subscriber{config: sc.config}
+		_spec = sqlgraph.NewCreateSpec(subscriber.Table, sqlgraph.NewFieldSpec(subscriber.FieldID, field.TypeInt))
+	)
+	if value, ok := sc.mutation.Email(); ok {
+		_spec.SetField(subscriber.FieldEmail, field.TypeString, value)
+		_node.Email = value
+	}
+	if value, ok := sc.mutation.ConfirmedAt(); ok {
+		_spec.SetField(subscriber.FieldConfirmedAt, field.TypeTime, value)
+		_node.ConfirmedAt = &value
+	}
+	if value, ok := sc.mutation.DigestSentAt(); ok {
+		_spec.SetField(subscriber.FieldDigestSentAt, field.TypeTime, value)
+		_node.DigestSentAt = &value
+	}
+	if value, ok := sc.mutation.CreatedAt(); ok {
+		_spec.SetField(subscriber.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// SubscriberCreateBulk is the builder for creating many Subscriber entities in bulk.
+type SubscriberCreateBulk struct {
+	config
+	err      error
+	builders []*SubscriberCreate
+}
+
+// Save creates the Subscriber entities in the database.
+func (scb *SubscriberCreateBulk) Save(ctx context.Context) ([]*Subscriber, error) {
+	if scb.err != nil {
+		return nil, scb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(scb.builders))
+	nodes := make([]*Subscriber, len(scb.builders))
+	mutators := make([]Mutator, len(scb.builders))
+	for i := range scb.builders {
+		func(i int, root context.Context) {
+			builder := scb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*SubscriberMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, scb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, scb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, scb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (scb *SubscriberCreateBulk) SaveX(ctx context.Context) []*Subscriber {
+	v, err := scb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (scb *SubscriberCreateBulk) Exec(ctx context.Context) error {
+	_, err := scb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (scb *SubscriberCreateBulk) ExecX(ctx context.Context) {
+	if err := scb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
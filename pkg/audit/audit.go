@@ -0,0 +1,155 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+)
+
+// Action is a type of event recorded in the audit log
+type Action string
+
+const (
+	// Login is recorded when a user logs in
+	Login Action = "login"
+
+	// LoginFailed is recorded when an incorrect password or two-factor code is entered while logging in
+	LoginFailed Action = "login_failed"
+
+	// AccountLocked is recorded when an account is locked after too many failed logins
+	AccountLocked Action = "account_locked"
+
+	// AccountUnlocked is recorded when a locked account is unlocked from the link emailed to the user
+	AccountUnlocked Action = "account_unlocked"
+
+	// Logout is recorded when a user logs out
+	Logout Action = "logout"
+
+	// PasswordChanged is recorded when the password of a user is changed, such as when it is reset
+	PasswordChanged Action = "password_changed"
+
+	// RoleChanged is recorded when the role of a user is changed
+	RoleChanged Action = "role_changed"
+
+	// UserUpdated is recorded when an admin updates the name, email address or verification of a user, while changes
+	// to their role are recorded as RoleChanged
+	UserUpdated Action = "user_updated"
+
+	// UserBanned is recorded when a user is banned
+	UserBanned Action = "user_banned"
+
+	// UserUnbanned is recorded when a user is no longer banned
+	UserUnbanned Action = "user_unbanned"
+
+	// PostPublished is recorded when a post is published, including when a scheduled post is published
+	PostPublished Action = "post_published"
+
+	// PostDeleted is recorded when a post is deleted
+	PostDeleted Action = "post_deleted"
+
+	// CommentsModerated is recorded when an admin moderates many comments at once
+	CommentsModerated Action = "comments_moderated"
+
+	// CacheFlushed is recorded when an admin flushes the cache
+	CacheFlushed Action = "cache_flushed"
+
+	// MailRetried is recorded when an admin retries a failed email
+	MailRetried Action = "mail_retried"
+
+	// MailDeleted is recorded when an admin deletes a failed email
+	MailDeleted Action = "mail_deleted"
+)
+
+// Actions stores all actions which are recorded, in the order they are presented when filtering the audit log
+var Actions = []Action{
+	Login,
+	LoginFailed,
+	AccountLocked,
+	AccountUnlocked,
+	Logout,
+	PasswordChanged,
+	RoleChanged,
+	UserUpdated,
+	UserBanned,
+	UserUnbanned,
+	PostPublished,
+	PostDeleted,
+	CommentsModerated,
+	CacheFlushed,
+	MailRetried,
+	MailDeleted,
+}
+
+// Request describes the HTTP request events are recorded within
+type Request struct {
+	// ID stores the ID of the request, which is included in all logs for the request
+	ID string
+
+	// IP stores the IP address of the client
+	IP string
+
+	// UserAgent stores the user agent of the client
+	UserAgent string
+}
+
+// Event is an event to record in the audit log
+type Event struct {
+	// Action stores the action performed
+	Action Action
+
+	// UserID stores the ID of the user who performed the action. If not provided, the viewer stored in the context,
+	// if one, is used.
+	UserID int
+
+	// SubjectType stores the type of entity the action was performed on, if one
+	SubjectType string
+
+	// SubjectID stores the ID of the entity the action was performed on, if one
+	SubjectID int
+
+	// Details stores a description of the action
+	Details string
+}
+
+// requestKey is the context key which stores the request events are recorded within
+type requestKey struct{}
+
+// NewContext returns a copy of a given context which stores the request events are recorded within
+func NewContext(ctx context.Context, r Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, r)
+}
+
+// FromContext returns the request stored in a given context, if one
+func FromContext(ctx context.Context) Request {
+	r, _ := ctx.Value(requestKey{}).(Request)
+	return r
+}
+
+// Record records an event in the audit log, along with the request stored in the context
+func Record(ctx context.Context, orm *ent.Client, e Event) error {
+	r := FromContext(ctx)
+
+	create := orm.AuditEvent.
+		Create().
+		SetAction(string(e.Action)).
+		SetDetails(e.Details).
+		SetRequestID(r.ID).
+		SetIP(r.IP).
+		SetUserAgent(r.UserAgent)
+
+	switch v := permission.FromContext(ctx); {
+	case e.UserID != 0:
+		create.SetUserID(e.UserID)
+	case v != nil:
+		create.SetUserID(v.ID)
+	}
+
+	if e.SubjectType != "" {
+		create.
+			SetSubjectType(e.SubjectType).
+			SetSubjectID(e.SubjectID)
+	}
+
+	return create.Exec(ctx)
+}
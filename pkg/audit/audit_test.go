@@ -0,0 +1,99 @@
+package audit_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/auditevent"
+	"github.com/mikestefanello/pagoda/pkg/audit"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+	"github.com/mikestefanello/pagoda/pkg/services"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var c *services.Container
+
+func TestMain(m *testing.M) {
+	// Set the environment to test
+	config.SwitchEnvironment(config.EnvTest)
+
+	// Create a new container
+	c = services.NewContainer()
+
+	// Run tests
+	exitVal := m.Run()
+
+	// Shutdown the container
+	if err := c.Shutdown(); err != nil {
+		panic(err)
+	}
+
+	os.Exit(exitVal)
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, audit.Request{}, audit.FromContext(ctx))
+
+	r := audit.Request{ID: "abc", IP: "1.2.3.4", UserAgent: "test"}
+	assert.Equal(t, r, audit.FromContext(audit.NewContext(ctx, r)))
+}
+
+func TestRecord(t *testing.T) {
+	u, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	r := audit.Request{ID: "abc", IP: "1.2.3.4", UserAgent: "test"}
+	ctx := audit.NewContext(permission.NewContext(context.Background(), u), r)
+
+	// The viewer is recorded as the user, if one is not provided
+	err = audit.Record(ctx, c.ORM, audit.Event{
+		Action:      audit.CacheFlushed,
+		SubjectType: "Test",
+		SubjectID:   123,
+		Details:     "Details",
+	})
+	require.NoError(t, err)
+
+	e := lastEvent(t)
+	assert.Equal(t, string(audit.CacheFlushed), e.Action)
+	assert.Equal(t, "Test", e.SubjectType)
+	require.NotNil(t, e.SubjectID)
+	assert.Equal(t, 123, *e.SubjectID)
+	assert.Equal(t, "Details", e.Details)
+	assert.Equal(t, r.ID, e.RequestID)
+	assert.Equal(t, r.IP, e.IP)
+	assert.Equal(t, r.UserAgent, e.UserAgent)
+	require.NotNil(t, e.Edges.User)
+	assert.Equal(t, u.ID, e.Edges.User.ID)
+
+	// Events can be recorded without a user or subject
+	err = audit.Record(context.Background(), c.ORM, audit.Event{Action: audit.LoginFailed})
+	require.NoError(t, err)
+
+	e = lastEvent(t)
+	assert.Equal(t, string(audit.LoginFailed), e.Action)
+	assert.Nil(t, e.SubjectID)
+	assert.Nil(t, e.Edges.User)
+
+	// The audit log is append-only
+	assert.Error(t, e.Update().Exec(context.Background()))
+	assert.Error(t, c.ORM.AuditEvent.DeleteOne(e).Exec(context.Background()))
+}
+
+// lastEvent returns the audit event which was most recently recorded
+func lastEvent(t *testing.T) *ent.AuditEvent {
+	e, err := c.ORM.AuditEvent.
+		Query().
+		WithUser().
+		Order(ent.Desc(auditevent.FieldID)).
+		First(context.Background())
+	require.NoError(t, err)
+	return e
+}
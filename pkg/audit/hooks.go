@@ -0,0 +1,151 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/hook"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/user"
+)
+
+// UserHook records changes to the role, password and ban status of a user
+func UserHook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.UserFunc(func(ctx context.Context, m *ent.UserMutation) (ent.Value, error) {
+				id, _ := m.ID()
+				events := make([]Event, 0)
+
+				if role, ok := m.Role(); ok {
+					old, err := m.OldRole(ctx)
+					if err != nil {
+						return nil, err
+					}
+					if old != role {
+						events = append(events, Event{
+							Action:      RoleChanged,
+							SubjectType: user.Label,
+							SubjectID:   id,
+							Details:     fmt.Sprintf("%s to %s", old, role),
+						})
+					}
+				}
+
+				// Passwords are only changed by the users themselves, who may not be logged in when resetting it
+				if _, ok := m.Password(); ok {
+					events = append(events, Event{
+						Action:      PasswordChanged,
+						UserID:      id,
+						SubjectType: user.Label,
+						SubjectID:   id,
+					})
+				}
+
+				if _, ok := m.BannedAt(); ok {
+					events = append(events, Event{
+						Action:      UserBanned,
+						SubjectType: user.Label,
+						SubjectID:   id,
+					})
+				}
+
+				if m.BannedAtCleared() {
+					events = append(events, Event{
+						Action:      UserUnbanned,
+						SubjectType: user.Label,
+						SubjectID:   id,
+					})
+				}
+
+				v, err := next.Mutate(ctx, m)
+				if err != nil {
+					return v, err
+				}
+
+				return v, record(ctx, m.Client(), events)
+			})
+		},
+		ent.OpUpdateOne,
+	)
+}
+
+// PostHook records posts being published and deleted
+func PostHook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.PostFunc(func(ctx context.Context, m *ent.PostMutation) (ent.Value, error) {
+				events := make([]Event, 0)
+
+				if m.Op().Is(ent.OpCreate) {
+					v, err := next.Mutate(ctx, m)
+					if err != nil {
+						return v, err
+					}
+
+					if p, ok := v.(*ent.Post); ok && p.Status == post.StatusPublished {
+						events = append(events, postEvent(PostPublished, p))
+					}
+					return v, record(ctx, m.Client(), events)
+				}
+
+				// Load the posts which are about to change, since they cannot be loaded once deleted
+				query := m.Client().Post.Query()
+				if status, ok := m.Status(); ok && status == post.StatusPublished {
+					query.Where(post.StatusNEQ(post.StatusPublished))
+				} else if !m.Op().Is(ent.OpDelete | ent.OpDeleteOne) {
+					return next.Mutate(ctx, m)
+				}
+
+				ids, err := m.IDs(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				posts, err := query.
+					Where(post.IDIn(ids...)).
+					All(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				v, err := next.Mutate(ctx, m)
+				if err != nil {
+					return v, err
+				}
+
+				action := PostPublished
+				if m.Op().Is(ent.OpDelete | ent.OpDeleteOne) {
+					action = PostDeleted
+				}
+				for _, p := range posts {
+					events = append(events, postEvent(action, p))
+				}
+
+				return v, record(ctx, m.Client(), events)
+			})
+		},
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne,
+	)
+}
+
+// postEvent returns an event for a given action performed on a given post
+func postEvent(action Action, p *ent.Post) Event {
+	return Event{
+		Action:      action,
+		SubjectType: post.Label,
+		SubjectID:   p.ID,
+		Details:     p.Title,
+	}
+}
+
+// record records many events in the audit log
+func record(ctx context.Context, orm *ent.Client, events []Event) error {
+	for _, e := range events {
+		if err := Record(ctx, orm, e); err != nil {
+			return fmt.Errorf("unable to record audit event: %w", err)
+		}
+	}
+	return nil
+}
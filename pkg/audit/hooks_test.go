@@ -0,0 +1,106 @@
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/ent/auditevent"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/audit"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserHook(t *testing.T) {
+	u, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+	ctx := permission.SystemContext(context.Background())
+
+	err = u.Update().SetRole(user.RoleEditor).Exec(ctx)
+	require.NoError(t, err)
+	e := lastEvent(t)
+	assert.Equal(t, string(audit.RoleChanged), e.Action)
+	assert.Equal(t, "reader to editor", e.Details)
+	require.NotNil(t, e.SubjectID)
+	assert.Equal(t, u.ID, *e.SubjectID)
+
+	// Setting the same role is not a change
+	count := countEvents(t, user.Label, u.ID)
+	err = c.ORM.User.UpdateOneID(u.ID).SetRole(user.RoleEditor).Exec(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, count, countEvents(t, user.Label, u.ID))
+
+	// Password changes are attributed to the user themselves
+	err = u.Update().SetPassword("changed").Exec(ctx)
+	require.NoError(t, err)
+	e = lastEvent(t)
+	assert.Equal(t, string(audit.PasswordChanged), e.Action)
+	require.NotNil(t, e.Edges.User)
+	assert.Equal(t, u.ID, e.Edges.User.ID)
+
+	err = u.Update().SetBannedAt(u.CreatedAt).Exec(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, string(audit.UserBanned), lastEvent(t).Action)
+
+	err = u.Update().ClearBannedAt().Exec(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, string(audit.UserUnbanned), lastEvent(t).Action)
+}
+
+func TestPostHook(t *testing.T) {
+	u, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+	ctx := permission.SystemContext(context.Background())
+
+	// Publishing
+	p, err := c.ORM.Post.
+		Create().
+		SetTitle(fmt.Sprintf("Audit %d", rand.Int())).
+		SetBody("Body").
+		SetStatus(post.StatusDraft).
+		SetAuthorID(u.ID).
+		Save(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, countEvents(t, post.Label, p.ID))
+
+	err = p.Update().SetStatus(post.StatusPublished).Exec(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, countEvents(t, post.Label, p.ID))
+	e := lastEvent(t)
+	assert.Equal(t, string(audit.PostPublished), e.Action)
+	assert.Equal(t, post.Label, e.SubjectType)
+	assert.Equal(t, p.Title, e.Details)
+
+	// Updating a post which is already published does not publish it again
+	err = p.Update().SetStatus(post.StatusPublished).SetTitle("Updated").Exec(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, countEvents(t, post.Label, p.ID))
+
+	// Deleting
+	err = c.ORM.Post.DeleteOne(p).Exec(ctx)
+	require.NoError(t, err)
+	e = lastEvent(t)
+	assert.Equal(t, string(audit.PostDeleted), e.Action)
+	require.NotNil(t, e.SubjectID)
+	assert.Equal(t, p.ID, *e.SubjectID)
+	assert.Equal(t, "Updated", e.Details)
+}
+
+// countEvents returns the amount of audit events recorded for a given subject
+func countEvents(t *testing.T, subjectType string, subjectID int) int {
+	count, err := c.ORM.AuditEvent.
+		Query().
+		Where(
+			auditevent.SubjectType(subjectType),
+			auditevent.SubjectID(subjectID),
+		).
+		Count(context.Background())
+	require.NoError(t, err)
+	return count
+}
@@ -17,6 +17,18 @@ const (
 
 	// PasswordTokenKey is the key value used to store a password token in context
 	PasswordTokenKey = "password_token"
+
+	// PostKey is the key value used to store a post in context
+	PostKey = "post"
+
+	// CommentKey is the key value used to store a comment in context
+	CommentKey = "comment"
+
+	// MediaKey is the key value used to store media in context
+	MediaKey = "media"
+
+	// FailedMailKey is the key value used to store failed mail in context
+	FailedMailKey = "failed_mail"
 )
 
 // IsCanceledError determines if an error is due to a context cancelation
@@ -2,12 +2,15 @@ package controller
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net/http"
 
+	"github.com/mikestefanello/pagoda/ent/privacy"
 	"github.com/mikestefanello/pagoda/pkg/context"
 	"github.com/mikestefanello/pagoda/pkg/htmx"
 	"github.com/mikestefanello/pagoda/pkg/middleware"
+	"github.com/mikestefanello/pagoda/pkg/paths"
 	"github.com/mikestefanello/pagoda/pkg/services"
 
 	"github.com/labstack/echo/v4"
@@ -95,6 +98,10 @@ func (c *Controller) RenderPage(ctx echo.Context, page Page) error {
 		ctx.Response().Header().Set(k, v)
 	}
 
+	// The markup differs for HTMX partial requests, such as search-as-you-type, so browsers and
+	// proxies must not serve one in place of the other
+	ctx.Response().Header().Add(echo.HeaderVary, htmx.HeaderRequest)
+
 	// Apply the HTMX response, if one
 	if page.HTMX.Response != nil {
 		page.HTMX.Response.Apply(ctx)
@@ -112,6 +119,11 @@ func (c *Controller) cachePage(ctx echo.Context, page Page, html *bytes.Buffer)
 		return
 	}
 
+	// Partial HTMX renders share the URL of the full page so they cannot be cached
+	if page.HTMX.Request.Enabled && !page.HTMX.Request.Boosted {
+		return
+	}
+
 	// If no expiration time was provided, default to the configuration value
 	if page.Cache.Expiration == 0 {
 		page.Cache.Expiration = c.Container.Config.Cache.Expiration.Page
@@ -165,7 +177,18 @@ func (c *Controller) Redirect(ctx echo.Context, route string, routeParams ...any
 	}
 }
 
+// AbsoluteURL returns the absolute URL of a given path on the public URL of the application stored in
+// configuration. This must be used rather than the host of the request, which is controlled by the client,
+// to build links that are emailed or cached.
+func (c *Controller) AbsoluteURL(path string) string {
+	return paths.Absolute(c.Container.Config.App.URL, path)
+}
+
 // Fail is a helper to fail a request by returning a 500 error and logging the error
+// Errors from entity privacy policies denying the user return a 403 error instead
 func (c *Controller) Fail(err error, log string) error {
+	if errors.Is(err, privacy.Deny) {
+		return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("%s: %v", log, err))
+	}
 	return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("%s: %v", log, err))
 }
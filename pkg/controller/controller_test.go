@@ -56,6 +56,11 @@ func TestController_Redirect(t *testing.T) {
 	assert.Equal(t, http.StatusFound, ctx.Response().Status)
 }
 
+func TestController_AbsoluteURL(t *testing.T) {
+	ctr := NewController(c)
+	assert.Equal(t, c.Config.App.URL+"/a/b", ctr.AbsoluteURL("/a/b"))
+}
+
 func TestController_RenderPage(t *testing.T) {
 	setup := func() (echo.Context, *httptest.ResponseRecorder, Controller, Page) {
 		ctx, rec := tests.NewContext(c.Web, "/test/TestController_RenderPage")
@@ -90,6 +95,7 @@ func TestController_RenderPage(t *testing.T) {
 		for k, v := range p.Headers {
 			assert.Equal(t, v, ctx.Response().Header().Get(k))
 		}
+		assert.Equal(t, htmx.HeaderRequest, ctx.Response().Header().Get(echo.HeaderVary))
 
 		// Check the template cache
 		parsed, err := c.TemplateRenderer.Load("page", string(p.Name))
@@ -165,6 +171,7 @@ func TestController_RenderPage(t *testing.T) {
 		cp, ok := res.(*middleware.CachedPage)
 		require.True(t, ok)
 		assert.Equal(t, p.URL, cp.URL)
+		p.Headers[echo.HeaderVary] = htmx.HeaderRequest
 		assert.Equal(t, p.Headers, cp.Headers)
 		assert.Equal(t, p.StatusCode, cp.StatusCode)
 		assert.Equal(t, rec.Body.Bytes(), cp.HTML)
@@ -185,4 +192,22 @@ func TestController_RenderPage(t *testing.T) {
 			Fetch(context.Background())
 		assert.Error(t, err)
 	})
+
+	t.Run("htmx partial not cached", func(t *testing.T) {
+		ctx, _, ctr, p := setup()
+		p.URL = "/test/TestController_RenderPage/htmx"
+		ctx.Request().URL.Path = p.URL
+		p.Cache.Enabled = true
+		p.HTMX.Request.Enabled = true
+		err := ctr.RenderPage(ctx, p)
+		require.NoError(t, err)
+
+		_, err = c.Cache.
+			Get().
+			Group(middleware.CachedPageGroup).
+			Key(p.URL).
+			Type(new(middleware.CachedPage)).
+			Fetch(context.Background())
+		assert.Error(t, err)
+	})
 }
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"github.com/mikestefanello/pagoda/ent"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// SortQueryKey stores the query key used to indicate the field to sort by
+	SortQueryKey = "sort"
+
+	// DirectionQueryKey stores the query key used to indicate the direction to sort in
+	DirectionQueryKey = "dir"
+)
+
+// Sorter provides a mechanism to allow a user to sort results by one of a given set of fields via query
+// parameters
+type Sorter struct {
+	// Field stores the field the results are sorted by
+	Field string
+
+	// Desc stores if the results are sorted in descending order
+	Desc bool
+}
+
+// NewSorter creates a new Sorter which sorts by a given default field and direction unless the query parameters
+// request one of the given fields
+func NewSorter(ctx echo.Context, fields []string, defaultField string, defaultDesc bool) Sorter {
+	s := Sorter{
+		Field: defaultField,
+		Desc:  defaultDesc,
+	}
+
+	field := ctx.QueryParam(SortQueryKey)
+	for _, f := range fields {
+		if f == field {
+			s.Field = field
+			s.Desc = ctx.QueryParam(DirectionQueryKey) == "desc"
+			break
+		}
+	}
+
+	return s
+}
+
+// Direction returns the direction the results are sorted in, either asc or desc
+func (s Sorter) Direction() string {
+	if s.Desc {
+		return "desc"
+	}
+	return "asc"
+}
+
+// Order returns an order option which sorts a query by the field, in the direction, of the sorter
+func (s Sorter) Order() func(*sql.Selector) {
+	if s.Desc {
+		return ent.Desc(s.Field)
+	}
+	return ent.Asc(s.Field)
+}
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSorter(t *testing.T) {
+	fields := []string{"name", "email"}
+
+	ctx, _ := tests.NewContext(c.Web, "/")
+	s := NewSorter(ctx, fields, "created_at", true)
+	assert.Equal(t, "created_at", s.Field)
+	assert.True(t, s.Desc)
+	assert.Equal(t, "desc", s.Direction())
+
+	ctx, _ = tests.NewContext(c.Web, "/abc?sort=name&dir=asc")
+	s = NewSorter(ctx, fields, "created_at", true)
+	assert.Equal(t, "name", s.Field)
+	assert.False(t, s.Desc)
+	assert.Equal(t, "asc", s.Direction())
+
+	ctx, _ = tests.NewContext(c.Web, "/abc?sort=email&dir=desc")
+	s = NewSorter(ctx, fields, "created_at", false)
+	assert.Equal(t, "email", s.Field)
+	assert.True(t, s.Desc)
+
+	// Fields which are not allowed are ignored
+	ctx, _ = tests.NewContext(c.Web, "/abc?sort=password&dir=asc")
+	s = NewSorter(ctx, fields, "created_at", true)
+	assert.Equal(t, "created_at", s.Field)
+	assert.True(t, s.Desc)
+}
@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/bytes"
+)
+
+// sniffLength stores the amount of bytes used to detect the content type of an uploaded file
+const sniffLength = 512
+
+// FileField describes a file field within a multipart form along with the files it accepts
+type FileField struct {
+	// Name stores the name of the form struct field that validation errors are set on
+	Name string
+
+	// Key stores the key of the file within the multipart form
+	Key string
+
+	// Required indicates if a file must be uploaded
+	Required bool
+
+	// MaxSize stores the maximum size of the file, in bytes
+	MaxSize int64
+
+	// AllowedTypes stores the content types the file can have, as detected from its contents
+	AllowedTypes []string
+}
+
+// UploadedFile is a file uploaded via a multipart form that passed validation
+type UploadedFile struct {
+	// Header stores the header of the file within the multipart form
+	Header *multipart.FileHeader
+
+	// ContentType stores the content type detected from the contents of the file
+	ContentType string
+}
+
+// Open opens the uploaded file
+func (u *UploadedFile) Open() (multipart.File, error) {
+	return u.Header.Open()
+}
+
+// ProcessFile processes a file uploaded as part of a submission for a multipart form, which FormSubmission.Process
+// does not handle. Validation errors are set on the submission using the name of the field and nil is returned
+// if there is no valid file. The content type provided by the client is not trusted and is instead detected
+// from the contents of the file.
+func (f *FormSubmission) ProcessFile(ctx echo.Context, field FileField) (*UploadedFile, error) {
+	f.IsSubmitted = true
+
+	fh, err := ctx.FormFile(field.Key)
+	switch {
+	case errors.Is(err, http.ErrMissingFile), err == nil && fh.Size == 0:
+		if field.Required {
+			f.SetFieldError(field.Name, "This field is required.")
+		}
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if field.MaxSize > 0 && fh.Size > field.MaxSize {
+		f.SetFieldError(field.Name, fmt.Sprintf("The file cannot be larger than %s.", bytes.Format(field.MaxSize)))
+		return nil, nil
+	}
+
+	contentType, err := detectContentType(fh)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(field.AllowedTypes) > 0 && !isAllowedType(contentType, field.AllowedTypes) {
+		f.SetFieldError(field.Name, "This type of file is not allowed.")
+		return nil, nil
+	}
+
+	return &UploadedFile{
+		Header:      fh,
+		ContentType: contentType,
+	}, nil
+}
+
+// detectContentType detects the content type of an uploaded file from the start of its contents
+func detectContentType(fh *multipart.FileHeader) (string, error) {
+	file, err := fh.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	buf := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(buf[:n]))
+	if err != nil {
+		return "", err
+	}
+	return mediaType, nil
+}
+
+// isAllowedType determines if a content type is within a list of allowed types
+func isAllowedType(contentType string, allowed []string) bool {
+	for _, a := range allowed {
+		if strings.EqualFold(a, contentType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormSubmission_ProcessFile(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	field := FileField{
+		Name:         "File",
+		Key:          "file",
+		Required:     true,
+		MaxSize:      100,
+		AllowedTypes: []string{"image/png"},
+	}
+
+	process := func(filename string, content []byte) (*UploadedFile, FormSubmission) {
+		var sub FormSubmission
+		ctx, _ := tests.NewUploadContext(c.Web, "/", "file", filename, content)
+		file, err := sub.ProcessFile(ctx, field)
+		require.NoError(t, err)
+		return file, sub
+	}
+
+	// The content type is detected from the contents rather than the filename
+	file, sub := process("image.txt", png)
+	require.NotNil(t, file)
+	assert.False(t, sub.HasErrors())
+	assert.Equal(t, "image/png", file.ContentType)
+	assert.Equal(t, "image.txt", file.Header.Filename)
+
+	file, sub = process("image.png", []byte("plain text"))
+	assert.Nil(t, file)
+	assert.True(t, sub.FieldHasErrors("File"))
+
+	file, sub = process("image.png", append(png, bytes.Repeat([]byte{0}, 100)...))
+	assert.Nil(t, file)
+	assert.True(t, sub.FieldHasErrors("File"))
+
+	file, sub = process("image.png", nil)
+	assert.Nil(t, file)
+	assert.Equal(t, []string{"This field is required."}, sub.GetFieldErrors("File"))
+}
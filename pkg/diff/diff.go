@@ -0,0 +1,212 @@
+package diff
+
+import (
+	"strings"
+)
+
+// maxLines is the maximum amount of lines, between both texts, which are compared by Lines
+const maxLines = 10000
+
+// Op is the operation which transforms a line of the old text in to the new text
+type Op int
+
+const (
+	// Equal indicates the line is in both the old and the new text
+	Equal Op = iota
+
+	// Insert indicates the line is only in the new text
+	Insert
+
+	// Delete indicates the line is only in the old text
+	Delete
+)
+
+// Line is a single line of a diff
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// String returns the name of the operation
+func (o Op) String() string {
+	switch o {
+	case Insert:
+		return "insert"
+	case Delete:
+		return "delete"
+	default:
+		return "equal"
+	}
+}
+
+// Lines compares two texts line by line and returns the lines of both, in order, marked with the operation
+// that transforms the old text in to the new text.
+// Myers' algorithm is used to find the shortest edit script, so the amount of inserted and deleted lines is
+// minimal, while only using memory linear in the length of the texts. The time taken grows with the length of
+// the texts multiplied by the amount of changes, so if there are more than maxLines lines between both texts,
+// they are not compared and every old line is deleted and every new line is inserted instead.
+func Lines(oldText, newText string) []Line {
+	a, b := split(oldText), split(newText)
+	lines := make([]Line, 0, len(a)+len(b))
+
+	if len(a)+len(b) > maxLines {
+		for _, text := range a {
+			lines = append(lines, Line{Op: Delete, Text: text})
+		}
+		for _, text := range b {
+			lines = append(lines, Line{Op: Insert, Text: text})
+		}
+		return lines
+	}
+
+	return diff(a, b, lines)
+}
+
+// Changed determines if any of the lines were inserted or deleted
+func Changed(lines []Line) bool {
+	for _, line := range lines {
+		if line.Op != Equal {
+			return true
+		}
+	}
+	return false
+}
+
+// diff appends the diff of two slices of lines to a given slice of lines
+func diff(a, b []string, lines []Line) []Line {
+	// Lines shared at the start and end of both slices can be skipped when finding the edit script
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	for _, text := range a[:prefix] {
+		lines = append(lines, Line{Op: Equal, Text: text})
+	}
+
+	shared := a[len(a)-suffix:]
+	a, b = a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	switch {
+	case len(a) == 0:
+		for _, text := range b {
+			lines = append(lines, Line{Op: Insert, Text: text})
+		}
+	case len(b) == 0:
+		for _, text := range a {
+			lines = append(lines, Line{Op: Delete, Text: text})
+		}
+	default:
+		// Split both slices where the middle of the edit script crosses them and diff each half
+		x, y := bisect(a, b)
+		lines = diff(a[:x], b[:y], lines)
+		lines = diff(a[x:], b[y:], lines)
+	}
+
+	for _, text := range shared {
+		lines = append(lines, Line{Op: Equal, Text: text})
+	}
+
+	return lines
+}
+
+// bisect finds the point at which the shortest edit script of two slices of lines, which must not be empty nor
+// share their first or last line, can be split in to two edit scripts of about half the length. The script is
+// searched for from both the start and the end of the slices at once, until the two searches overlap.
+// If the slices have nothing in common, the end of the old slice and the start of the new slice are returned so
+// the old lines are deleted before the new lines are inserted.
+func bisect(a, b []string) (int, int) {
+	n, m := len(a), len(b)
+	maxD := (n + m + 1) / 2
+	offset := maxD
+
+	// forward[offset+k] and reverse[offset+k] store the furthest x reached on diagonal k, from the start and
+	// from the end respectively, or -1 if it has not been reached
+	forward := make([]int, 2*maxD+2)
+	reverse := make([]int, 2*maxD+2)
+	for i := range forward {
+		forward[i] = -1
+		reverse[i] = -1
+	}
+	forward[offset+1] = 0
+	reverse[offset+1] = 0
+
+	// The searches can only overlap on the forward search if the difference in length is odd, otherwise on the
+	// reverse search
+	delta := n - m
+	odd := delta%2 != 0
+
+	// The diagonals which have run off the edges and no longer need to be searched
+	fStart, fEnd, rStart, rEnd := 0, 0, 0, 0
+
+	for d := 0; d < maxD; d++ {
+		for k := -d + fStart; k <= d-fEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+
+			switch {
+			case x > n:
+				fEnd += 2
+			case y > m:
+				fStart += 2
+			case odd:
+				if r := offset + delta - k; r >= 0 && r < len(reverse) && reverse[r] != -1 && x >= n-reverse[r] {
+					return x, y
+				}
+			}
+		}
+
+		for k := -d + rStart; k <= d-rEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && reverse[offset+k-1] < reverse[offset+k+1]) {
+				x = reverse[offset+k+1]
+			} else {
+				x = reverse[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[n-x-1] == b[m-y-1] {
+				x++
+				y++
+			}
+			reverse[offset+k] = x
+
+			switch {
+			case x > n:
+				rEnd += 2
+			case y > m:
+				rStart += 2
+			case !odd:
+				if f := offset + delta - k; f >= 0 && f < len(forward) && forward[f] != -1 {
+					fx := forward[f]
+					if fx >= n-x {
+						return fx, offset + fx - f
+					}
+				}
+			}
+		}
+	}
+
+	return n, 0
+}
+
+// split splits text in to lines
+func split(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
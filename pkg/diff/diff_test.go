@@ -0,0 +1,86 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	lines := Lines("a\nb\nc\nd\ne", "a\nc\nd\nx\ne\nf")
+	expected := []Line{
+		{Op: Equal, Text: "a"},
+		{Op: Delete, Text: "b"},
+		{Op: Equal, Text: "c"},
+		{Op: Equal, Text: "d"},
+		{Op: Insert, Text: "x"},
+		{Op: Equal, Text: "e"},
+		{Op: Insert, Text: "f"},
+	}
+	assert.Equal(t, expected, lines)
+	assert.True(t, Changed(lines))
+}
+
+func TestLines_Equal(t *testing.T) {
+	lines := Lines("a\r\nb\n", "a\nb")
+	assert.Equal(t, []Line{{Op: Equal, Text: "a"}, {Op: Equal, Text: "b"}}, lines)
+	assert.False(t, Changed(lines))
+}
+
+func TestLines_Empty(t *testing.T) {
+	assert.Empty(t, Lines("", ""))
+	assert.Equal(t, []Line{{Op: Insert, Text: "a"}}, Lines("", "a"))
+	assert.Equal(t, []Line{{Op: Delete, Text: "a"}}, Lines("a", ""))
+}
+
+func TestLines_Replaced(t *testing.T) {
+	// Lines with nothing in common are deleted before they are inserted
+	lines := Lines("a\nb\nc", "x\ny")
+	expected := []Line{
+		{Op: Delete, Text: "a"},
+		{Op: Delete, Text: "b"},
+		{Op: Delete, Text: "c"},
+		{Op: Insert, Text: "x"},
+		{Op: Insert, Text: "y"},
+	}
+	assert.Equal(t, expected, lines)
+}
+
+func TestLines_Long(t *testing.T) {
+	count := func(lines []Line, op Op) int {
+		n := 0
+		for _, line := range lines {
+			if line.Op == op {
+				n++
+			}
+		}
+		return n
+	}
+
+	text := func(lines, step int) string {
+		s := make([]string, lines)
+		for i := range s {
+			s[i] = strconv.Itoa(i * step)
+		}
+		return strings.Join(s, "\n")
+	}
+
+	// Long texts are diffed without allocating memory for every pair of lines
+	lines := Lines(text(maxLines/2, 1), text(maxLines/2, 2))
+	assert.Equal(t, maxLines/4, count(lines, Insert))
+	assert.Equal(t, maxLines/4, count(lines, Delete))
+	assert.Equal(t, maxLines/4, count(lines, Equal))
+
+	// Texts which are too long are not compared
+	lines = Lines(text(maxLines, 1), text(1, 1))
+	assert.Equal(t, maxLines, count(lines, Delete))
+	assert.Equal(t, 1, count(lines, Insert))
+}
+
+func TestOp_String(t *testing.T) {
+	assert.Equal(t, "equal", Equal.String())
+	assert.Equal(t, "insert", Insert.String())
+	assert.Equal(t, "delete", Delete.String())
+}
@@ -4,17 +4,39 @@ import (
 	"fmt"
 	"html/template"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/pkg/markdown"
+	"github.com/mikestefanello/pagoda/pkg/permission"
 
 	"github.com/Masterminds/sprig"
 	"github.com/labstack/gommon/random"
 )
 
+// ImageSource is a resized variant of an image which can be used by the browser in place of the original
+type ImageSource struct {
+	// URL stores the URL of the variant
+	URL string
+
+	// ContentType stores the content type of the variant
+	ContentType string
+
+	// Width stores the width of the variant, in pixels
+	Width int
+
+	// Height stores the height of the variant, in pixels
+	Height int
+}
+
 var (
 	// CacheBuster stores a random string used as a cache buster for static files.
 	CacheBuster = random.String(10)
+
+	// markdownRenderer stores the renderer used by the Markdown function
+	markdownRenderer = markdown.NewRenderer()
 )
 
 // GetFuncMap provides a template function map
@@ -26,9 +48,12 @@ func GetFuncMap() template.FuncMap {
 	// Expand this as you add more functions to this package
 	// Avoid using a name already in use by sprig
 	f := template.FuncMap{
+		"can":      Can,
 		"hasField": HasField,
 		"file":     File,
 		"link":     Link,
+		"markdown": Markdown,
+		"srcset":   Srcset,
 	}
 
 	for k, v := range f {
@@ -50,6 +75,11 @@ func HasField(v any, name string) bool {
 	return rv.FieldByName(name).IsValid()
 }
 
+// Can determines if the role of a given user grants a given permission
+func Can(u *ent.User, p string) bool {
+	return permission.Has(u, permission.Permission(p))
+}
+
 // File appends a cache buster to a given filepath so it can remain cached until the app is restarted
 func File(filepath string) string {
 	return fmt.Sprintf("/%s/%s?v=%s", config.StaticPrefix, filepath, CacheBuster)
@@ -64,3 +94,68 @@ func Link(url, text, currentPath string, classes ...string) template.HTML {
 	html := fmt.Sprintf(`<a class="%s" href="%s">%s</a>`, strings.Join(classes, " "), url, text)
 	return template.HTML(html)
 }
+
+// Markdown renders Markdown in to sanitized HTML.
+// Rendering is not cached, so use the Markdown service for larger content such as post bodies.
+func Markdown(source string) template.HTML {
+	doc, err := markdownRenderer.Render(source)
+	if err != nil {
+		return ""
+	}
+	return doc.HTML
+}
+
+// Srcset outputs HTML for a responsive image which lets the browser choose the most appropriate source based on
+// the sizes attribute, which describes how wide the image will be displayed, and the screen. The original is
+// used when the browser does not support srcset or when there are no sources. WebP sources are preferred
+// over others when the browser supports them.
+func Srcset(src, alt, sizes string, sources []ImageSource) template.HTML {
+	var webp, fallback []ImageSource
+	for _, s := range sources {
+		if s.ContentType == "image/webp" {
+			webp = append(webp, s)
+		} else {
+			fallback = append(fallback, s)
+		}
+	}
+
+	candidates := func(sources []ImageSource) string {
+		sort.Slice(sources, func(i, j int) bool {
+			return sources[i].Width < sources[j].Width
+		})
+		list := make([]string, 0, len(sources))
+		for _, s := range sources {
+			list = append(list, fmt.Sprintf("%s %dw", s.URL, s.Width))
+		}
+		return template.HTMLEscapeString(strings.Join(list, ", "))
+	}
+
+	img := fmt.Sprintf(`<img src="%s" alt="%s"`, template.HTMLEscapeString(src), template.HTMLEscapeString(alt))
+	if len(fallback) > 0 {
+		img += fmt.Sprintf(` srcset="%s" sizes="%s"`, candidates(fallback), template.HTMLEscapeString(sizes))
+	}
+
+	// The dimensions of the widest source provide the aspect ratio which prevents the layout from shifting
+	// as the image loads
+	if len(sources) > 0 {
+		widest := sources[0]
+		for _, s := range sources {
+			if s.Width > widest.Width {
+				widest = s
+			}
+		}
+		img += fmt.Sprintf(` width="%d" height="%d"`, widest.Width, widest.Height)
+	}
+	img += ` loading="lazy">`
+
+	if len(webp) == 0 {
+		return template.HTML(img)
+	}
+
+	return template.HTML(fmt.Sprintf(
+		`<picture><source type="image/webp" srcset="%s" sizes="%s">%s</picture>`,
+		candidates(webp),
+		template.HTMLEscapeString(sizes),
+		img,
+	))
+}
@@ -5,10 +5,20 @@ import (
 	"testing"
 
 	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/user"
 
 	"github.com/stretchr/testify/assert"
 )
 
+func TestCan(t *testing.T) {
+	assert.False(t, Can(nil, "create_post"))
+	assert.False(t, Can(&ent.User{Role: user.RoleReader}, "create_post"))
+	assert.True(t, Can(&ent.User{Role: user.RoleContributor}, "create_post"))
+	assert.False(t, Can(&ent.User{Role: user.RoleEditor}, "manage_site"))
+	assert.True(t, Can(&ent.User{Role: user.RoleAdmin}, "manage_site"))
+}
+
 func TestHasField(t *testing.T) {
 	type example struct {
 		name string
@@ -37,3 +47,40 @@ func TestFile(t *testing.T) {
 	expected := fmt.Sprintf("/%s/test.png?v=%s", config.StaticPrefix, CacheBuster)
 	assert.Equal(t, expected, file)
 }
+
+func TestMarkdown(t *testing.T) {
+	html := string(Markdown("# Title\n\n*text*\n\n<script>alert(1)</script>"))
+	assert.Contains(t, html, `<h1 id="title">Title</h1>`)
+	assert.Contains(t, html, "<em>text</em>")
+	assert.NotContains(t, html, "<script>")
+}
+
+func TestSrcset(t *testing.T) {
+	html := string(Srcset("/a.png", `"alt"`, "64px", nil))
+	assert.Equal(t, `<img src="/a.png" alt="&#34;alt&#34;" loading="lazy">`, html)
+
+	sources := []ImageSource{
+		{URL: "/a_medium.jpg", ContentType: "image/jpeg", Width: 768, Height: 384},
+		{URL: "/a_thumbnail.jpg", ContentType: "image/jpeg", Width: 150, Height: 75},
+	}
+	html = string(Srcset("/a.png", "alt", "(max-width: 768px) 100vw, 768px", sources))
+	expected := `<img src="/a.png" alt="alt" srcset="/a_thumbnail.jpg 150w, /a_medium.jpg 768w" ` +
+		`sizes="(max-width: 768px) 100vw, 768px" width="768" height="384" loading="lazy">`
+	assert.Equal(t, expected, html)
+
+	// Images with transparency have PNG sources
+	sources = []ImageSource{
+		{URL: "/a_thumbnail.png", ContentType: "image/png", Width: 150, Height: 75},
+	}
+	html = string(Srcset("/a.png", "alt", "64px", sources))
+	expected = `<img src="/a.png" alt="alt" srcset="/a_thumbnail.png 150w" sizes="64px" width="150" height="75" loading="lazy">`
+	assert.Equal(t, expected, html)
+
+	// WebP sources are preferred by browsers which support them
+	sources = append(sources, ImageSource{URL: "/a_thumbnail.webp", ContentType: "image/webp", Width: 150, Height: 75})
+	html = string(Srcset("/a.png", "alt", "64px", sources))
+	expected = `<picture><source type="image/webp" srcset="/a_thumbnail.webp 150w" sizes="64px">` +
+		`<img src="/a.png" alt="alt" srcset="/a_thumbnail.png 150w" sizes="64px" ` +
+		`width="150" height="75" loading="lazy"></picture>`
+	assert.Equal(t, expected, html)
+}
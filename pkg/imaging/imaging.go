@@ -0,0 +1,198 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	// Register the decoders of the supported formats
+	_ "image/gif"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// MaxPixels is the maximum amount of pixels an image can have in order to be decoded which guards against
+// images which are small in size but expand to consume large amounts of memory
+const MaxPixels = 50_000_000
+
+// JPEGQuality is the quality JPEG variants are encoded with
+const JPEGQuality = 85
+
+var (
+	// ErrTooLarge is returned when an image exceeds MaxPixels
+	ErrTooLarge = errors.New("image exceeds the maximum amount of pixels")
+
+	// ErrWebPUnsupported is returned by EncodeWebP when no WebP encoder has been registered
+	ErrWebPUnsupported = errors.New("no WebP encoder has been registered")
+)
+
+// webpEncoder stores the encoder registered by RegisterWebPEncoder, if any
+var webpEncoder func(io.Writer, image.Image) error
+
+type (
+	// Size is a named width images are resized to
+	Size struct {
+		// Name stores the name of the size
+		Name string
+
+		// Width stores the maximum width of the size, in pixels
+		Width int
+	}
+
+	// Variant is a resized and encoded copy of an image
+	Variant struct {
+		// Name stores the name of the Size the variant was generated for
+		Name string `json:"name"`
+
+		// Key stores the storage key of the variant
+		Key string `json:"key"`
+
+		// ContentType stores the content type of the variant
+		ContentType string `json:"content_type"`
+
+		// Width stores the width of the variant, in pixels
+		Width int `json:"width"`
+
+		// Height stores the height of the variant, in pixels
+		Height int `json:"height"`
+
+		// Size stores the size of the variant, in bytes
+		Size int64 `json:"size"`
+	}
+)
+
+// Sizes contains the sizes variants are generated for, ordered from smallest to largest
+var Sizes = []Size{
+	{Name: "thumbnail", Width: 150},
+	{Name: "medium", Width: 768},
+	{Name: "large", Width: 1600},
+}
+
+// IsSupported returns true if images of a given content type can be decoded
+func IsSupported(contentType string) bool {
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif", "image/webp":
+		return true
+	default:
+		return false
+	}
+}
+
+// Decode decodes an image and applies the orientation stored in its metadata, if any, so that the image
+// is upright
+func Decode(data []byte) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return nil, ErrTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return Orient(img, Orientation(data)), nil
+}
+
+// Reencode decodes an image and encodes it again, which removes all of its metadata, such as EXIF which can
+// include the location a photo was taken at, since the encoders do not write any. JPEGs which are rotated
+// via their metadata are encoded upright. Formats other than JPEG, including WebP which is only encoded by the
+// worker, are converted to PNG, which is why the content type of the result is returned. GIFs cannot store EXIF and are returned as-is so animations are kept.
+func Reencode(data []byte, contentType string) ([]byte, string, error) {
+	if contentType == "image/gif" {
+		return data, contentType, nil
+	}
+
+	img, err := Decode(data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		err = EncodeJPEG(&buf, img)
+	} else {
+		contentType = "image/png"
+		err = EncodePNG(&buf, img)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), contentType, nil
+}
+
+// Resize scales an image down to a given width while maintaining its aspect ratio.
+// Images are never scaled up so a copy of the image is returned if it is not wider than the width.
+func Resize(img image.Image, width int) image.Image {
+	b := img.Bounds()
+	if b.Dx() <= width {
+		width = b.Dx()
+	}
+	height := max(1, (b.Dy()*width+b.Dx()/2)/b.Dx())
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+// EncodeJPEG writes an image to w in the JPEG format.
+// Since JPEG does not support transparency, transparent areas are filled with white.
+func EncodeJPEG(w io.Writer, img image.Image) error {
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Over)
+	return jpeg.Encode(w, dst, &jpeg.Options{Quality: JPEGQuality})
+}
+
+// EncodePNG writes an image to w in the PNG format, which keeps transparency
+func EncodePNG(w io.Writer, img image.Image) error {
+	enc := png.Encoder{CompressionLevel: png.BestCompression}
+	return enc.Encode(w, img)
+}
+
+// RegisterWebPEncoder registers the encoder used by EncodeWebP. The standard library cannot encode WebP and
+// the encoder requires cgo, so it is registered by importing pkg/imaging/webp only in binaries which need it.
+func RegisterWebPEncoder(encode func(io.Writer, image.Image) error) {
+	webpEncoder = encode
+}
+
+// CanEncodeWebP returns true if a WebP encoder has been registered
+func CanEncodeWebP() bool {
+	return webpEncoder != nil
+}
+
+// EncodeWebP writes an image to w in the WebP format using the registered encoder, returning
+// ErrWebPUnsupported if there is none
+func EncodeWebP(w io.Writer, img image.Image) error {
+	if webpEncoder == nil {
+		return ErrWebPUnsupported
+	}
+	return webpEncoder(w, img)
+}
+
+// IsOpaque returns true if an image has no transparent pixels and can therefore be encoded as a JPEG
+func IsOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a != 0xffff {
+				return false
+			}
+		}
+	}
+	return true
+}
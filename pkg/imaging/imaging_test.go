@@ -0,0 +1,200 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testImage creates an image with a gradient, noise and a partially transparent area
+func testImage(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	r := rand.New(rand.NewSource(1))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.NRGBA{
+				R: uint8(x * 255 / width),
+				G: uint8(y * 255 / height),
+				B: uint8(r.Intn(256)),
+				A: 0xff,
+			}
+			if x < width/4 && y < height/4 {
+				c.A = uint8(r.Intn(256))
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// jpegWithOrientation creates a JPEG containing an EXIF segment with a given orientation
+func jpegWithOrientation(t *testing.T, img image.Image, orientation uint16) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, EncodeJPEG(&buf, img))
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	tiff = binary.BigEndian.AppendUint16(tiff, exifTagOrientation)
+	tiff = append(tiff, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00)
+	app1 := append([]byte("Exif\x00\x00"), tiff...)
+
+	out := []byte{0xff, 0xd8, 0xff, jpegMarkerAPP1}
+	out = binary.BigEndian.AppendUint16(out, uint16(len(app1)+2))
+	out = append(out, app1...)
+	return append(out, buf.Bytes()[2:]...)
+}
+
+func TestResize(t *testing.T) {
+	img := testImage(400, 300)
+
+	resized := Resize(img, 150)
+	assert.Equal(t, 150, resized.Bounds().Dx())
+	assert.Equal(t, 113, resized.Bounds().Dy())
+
+	// Images are not scaled up
+	resized = Resize(img, 1600)
+	assert.Equal(t, 400, resized.Bounds().Dx())
+	assert.Equal(t, 300, resized.Bounds().Dy())
+}
+
+func TestOrient(t *testing.T) {
+	// A 3x2 image where each pixel has a unique red value
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(y*3 + x), A: 0xff})
+		}
+	}
+
+	tests := map[int][][]uint8{
+		1: {{0, 1, 2}, {3, 4, 5}},
+		2: {{2, 1, 0}, {5, 4, 3}},
+		3: {{5, 4, 3}, {2, 1, 0}},
+		4: {{3, 4, 5}, {0, 1, 2}},
+		5: {{0, 3}, {1, 4}, {2, 5}},
+		6: {{3, 0}, {4, 1}, {5, 2}},
+		7: {{5, 2}, {4, 1}, {3, 0}},
+		8: {{2, 5}, {1, 4}, {0, 3}},
+	}
+
+	for orientation, expected := range tests {
+		oriented := Orient(img, orientation)
+		require.Equal(t, len(expected[0]), oriented.Bounds().Dx(), orientation)
+		require.Equal(t, len(expected), oriented.Bounds().Dy(), orientation)
+		for y, row := range expected {
+			for x, r := range row {
+				c := color.NRGBAModel.Convert(oriented.At(x, y)).(color.NRGBA)
+				assert.Equal(t, r, c.R, "orientation %d at %d,%d", orientation, x, y)
+			}
+		}
+	}
+}
+
+func TestOrientation(t *testing.T) {
+	img := testImage(8, 4)
+	assert.Equal(t, 6, Orientation(jpegWithOrientation(t, img, 6)))
+	assert.Equal(t, 1, Orientation(jpegWithOrientation(t, img, 9)))
+
+	var buf bytes.Buffer
+	require.NoError(t, EncodeJPEG(&buf, img))
+	assert.Equal(t, 1, Orientation(buf.Bytes()))
+	assert.Equal(t, 1, Orientation([]byte("not an image")))
+}
+
+func TestDecode(t *testing.T) {
+	decoded, err := Decode(jpegWithOrientation(t, testImage(8, 4), 6))
+	require.NoError(t, err)
+	assert.Equal(t, 4, decoded.Bounds().Dx())
+	assert.Equal(t, 8, decoded.Bounds().Dy())
+
+	_, err = Decode([]byte("not an image"))
+	assert.Error(t, err)
+}
+
+func TestReencode(t *testing.T) {
+	t.Run("jpeg", func(t *testing.T) {
+		data := jpegWithOrientation(t, testImage(8, 4), 6)
+		reencoded, contentType, err := Reencode(data, "image/jpeg")
+		require.NoError(t, err)
+		assert.Equal(t, "image/jpeg", contentType)
+		assert.NotContains(t, string(reencoded), "Exif")
+		assert.Equal(t, 1, Orientation(reencoded))
+
+		// The image is upright without its orientation
+		img, err := Decode(reencoded)
+		require.NoError(t, err)
+		assert.Equal(t, 4, img.Bounds().Dx())
+		assert.Equal(t, 8, img.Bounds().Dy())
+
+		_, _, err = Reencode(data[:10], "image/jpeg")
+		assert.Error(t, err)
+	})
+
+	t.Run("png", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, testImage(8, 4)))
+		data := buf.Bytes()
+
+		// Insert a text chunk after the header chunk
+		text := []byte("tEXtComment\x00hello")
+		chunk := binary.BigEndian.AppendUint32(nil, uint32(len(text)-4))
+		chunk = append(chunk, text...)
+		chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(text))
+		withText := append(append(append([]byte{}, data[:33]...), chunk...), data[33:]...)
+
+		reencoded, contentType, err := Reencode(withText, "image/png")
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", contentType)
+		assert.NotContains(t, string(reencoded), "hello")
+
+		// Transparency is kept
+		img, err := Decode(reencoded)
+		require.NoError(t, err)
+		assert.False(t, IsOpaque(img))
+	})
+
+	t.Run("webp", func(t *testing.T) {
+		// A lossless 4x2 WebP
+		data := []byte("RIFF<\x00\x00\x00WEBPVP8L/\x00\x00\x00/\x03@\x00\x00\xb9\x1eD\xf4?\n\x81@\x12\xdaf\xfb\x1b" +
+			"\x10\x04\x90lvj\x99\x86B\xa6\x01\x91E&e\xd5Q\xc0an\x8b0x l]/V\x01\x00")
+		reencoded, contentType, err := Reencode(data, "image/webp")
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", contentType)
+
+		img, err := png.Decode(bytes.NewReader(reencoded))
+		require.NoError(t, err)
+		assert.Equal(t, 4, img.Bounds().Dx())
+		assert.Equal(t, 2, img.Bounds().Dy())
+	})
+
+	t.Run("gif", func(t *testing.T) {
+		data := []byte("GIF89a")
+		reencoded, contentType, err := Reencode(data, "image/gif")
+		require.NoError(t, err)
+		assert.Equal(t, "image/gif", contentType)
+		assert.Equal(t, data, reencoded)
+	})
+}
+
+func TestEncodeWebP(t *testing.T) {
+	var buf bytes.Buffer
+	assert.ErrorIs(t, EncodeWebP(&buf, testImage(4, 2)), ErrWebPUnsupported)
+}
+
+func TestIsOpaque(t *testing.T) {
+	assert.False(t, IsOpaque(testImage(8, 4)))
+	assert.True(t, IsOpaque(testImage(3, 3)))
+
+	// Images without an Opaque method are checked pixel by pixel
+	assert.True(t, IsOpaque(struct{ image.Image }{testImage(3, 3)}))
+	assert.False(t, IsOpaque(struct{ image.Image }{testImage(8, 4)}))
+}
@@ -0,0 +1,6 @@
+// Package webp registers a WebP encoder, which wraps libwebp, with the imaging package so that imaging.EncodeWebP
+// can be used. Since the encoder requires cgo, it is only registered when cgo is enabled and the package should
+// only be imported, for its side effects, by binaries which encode WebP, such as the worker:
+//
+//	import _ "github.com/mikestefanello/pagoda/pkg/imaging/webp"
+package webp
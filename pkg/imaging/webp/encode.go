@@ -0,0 +1,32 @@
+//go:build cgo
+
+package webp
+
+import (
+	"image"
+	"io"
+
+	"github.com/mikestefanello/pagoda/pkg/imaging"
+
+	"github.com/chai2010/webp"
+	"golang.org/x/image/draw"
+)
+
+// Quality is the quality images are encoded with
+const Quality = 80
+
+func init() {
+	imaging.RegisterWebPEncoder(Encode)
+}
+
+// Encode writes an image to w in the lossy WebP format, which keeps transparency and is usually much smaller
+// than JPEG or PNG
+func Encode(w io.Writer, img image.Image) error {
+	// The encoder expects colors which are not premultiplied by alpha, but only accepts them as an image.RGBA
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+	return webp.Encode(w, &image.RGBA{Pix: dst.Pix, Stride: dst.Stride, Rect: dst.Rect}, &webp.Options{
+		Quality: Quality,
+	})
+}
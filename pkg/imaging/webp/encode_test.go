@@ -0,0 +1,45 @@
+//go:build cgo
+
+package webp
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/pkg/imaging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	// The encoder is registered with the imaging package
+	require.True(t, imaging.CanEncodeWebP())
+
+	// An opaque image with a transparent corner
+	img := image.NewNRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			c := color.NRGBA{R: uint8(x * 6), G: uint8(y * 12), B: 0x80, A: 0xff}
+			if x < 10 && y < 5 {
+				c.A = 0
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, imaging.EncodeWebP(&buf, img))
+	assert.Equal(t, "WEBP", string(buf.Bytes()[8:12]))
+
+	// Transparency is kept
+	decoded, err := imaging.Decode(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, 40, decoded.Bounds().Dx())
+	assert.Equal(t, 20, decoded.Bounds().Dy())
+	assert.False(t, imaging.IsOpaque(decoded))
+	_, _, _, a := decoded.At(30, 15).RGBA()
+	assert.Equal(t, uint32(0xffff), a)
+}
@@ -0,0 +1,396 @@
+package mailer
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+type (
+	// cssRule is a rule parsed from a stylesheet
+	cssRule struct {
+		selectors    []string
+		declarations []cssDeclaration
+	}
+
+	// cssDeclaration is a single property and value within a rule
+	cssDeclaration struct {
+		property string
+		value    string
+	}
+
+	// cssSelector is a selector which can be matched against elements
+	cssSelector struct {
+		// compounds stores the compound selectors, from left to right
+		compounds []cssCompound
+
+		// child stores, for each compound after the first, if it must be a child, rather than any descendant,
+		// of the element matched by the previous compound
+		child []bool
+
+		// specificity stores the amount of ids, classes and types within the selector
+		specificity [3]int
+	}
+
+	// cssCompound is a selector which matches a single element, such as a.button
+	cssCompound struct {
+		tag     string
+		id      string
+		classes []string
+	}
+
+	// cssMatch is a rule which matched an element
+	cssMatch struct {
+		specificity  [3]int
+		order        int
+		declarations []cssDeclaration
+	}
+)
+
+var (
+	// cssComments matches comments within a stylesheet
+	cssComments = regexp.MustCompile(`(?s)/\*.*?\*/`)
+
+	// cssCompoundPattern matches the supported compound selectors, capturing the type and the ids and classes
+	cssCompoundPattern = regexp.MustCompile(`^(\*|[a-zA-Z][a-zA-Z0-9-]*)?((?:[.#][a-zA-Z_-][a-zA-Z0-9_-]*)*)$`)
+
+	// cssIDsAndClasses matches each id and class within a compound selector
+	cssIDsAndClasses = regexp.MustCompile(`[.#][^.#]+`)
+)
+
+// InlineCSS moves the rules within the <style> elements of an HTML document into the style attributes of the
+// elements which they match, since many mail clients ignore or strip stylesheets.
+// Type, class and id selectors, combined with descendant and child combinators, are supported. Rules which
+// use anything else, such as pseudo-classes, along with at-rules, such as @media queries, remain in the
+// stylesheet. Declarations within existing style attributes take precedence over those from the stylesheet.
+func InlineCSS(document string) (string, error) {
+	doc, err := html.Parse(strings.NewReader(document))
+	if err != nil {
+		return "", err
+	}
+
+	// Extract the rules from each stylesheet, keeping only what cannot be inlined
+	var rules []cssRule
+	var styles []*html.Node
+	walk(doc, func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Style {
+			styles = append(styles, n)
+		}
+	})
+
+	for _, style := range styles {
+		var css strings.Builder
+		for c := style.FirstChild; c != nil; c = c.NextSibling {
+			css.WriteString(c.Data)
+		}
+
+		parsed, remaining := parseCSS(css.String())
+		rules = append(rules, parsed...)
+
+		for style.FirstChild != nil {
+			style.RemoveChild(style.FirstChild)
+		}
+		if remaining == "" {
+			style.Parent.RemoveChild(style)
+		} else {
+			style.AppendChild(&html.Node{Type: html.TextNode, Data: remaining})
+		}
+	}
+
+	if len(rules) == 0 {
+		return document, nil
+	}
+
+	// Compile the selectors once, ordered as they appeared
+	type compiled struct {
+		selector     *cssSelector
+		order        int
+		declarations []cssDeclaration
+	}
+	var selectors []compiled
+	for _, rule := range rules {
+		for _, s := range rule.selectors {
+			selectors = append(selectors, compiled{
+				selector:     parseSelector(s),
+				order:        len(selectors),
+				declarations: rule.declarations,
+			})
+		}
+	}
+
+	walk(doc, func(n *html.Node) {
+		if n.Type != html.ElementNode {
+			return
+		}
+
+		var matches []cssMatch
+		for _, s := range selectors {
+			if s.selector.matches(n) {
+				matches = append(matches, cssMatch{
+					specificity:  s.selector.specificity,
+					order:        s.order,
+					declarations: s.declarations,
+				})
+			}
+		}
+		if len(matches) == 0 {
+			return
+		}
+
+		sort.SliceStable(matches, func(i, j int) bool {
+			a, b := matches[i].specificity, matches[j].specificity
+			for k := range a {
+				if a[k] != b[k] {
+					return a[k] < b[k]
+				}
+			}
+			return matches[i].order < matches[j].order
+		})
+
+		// Apply the declarations in order of precedence, followed by those already on the element
+		var declarations []cssDeclaration
+		for _, m := range matches {
+			declarations = append(declarations, m.declarations...)
+		}
+		for i, attr := range n.Attr {
+			if attr.Key == "style" {
+				declarations = append(declarations, parseDeclarations(attr.Val)...)
+				n.Attr = append(n.Attr[:i], n.Attr[i+1:]...)
+				break
+			}
+		}
+
+		n.Attr = append(n.Attr, html.Attribute{Key: "style", Val: formatDeclarations(declarations)})
+	})
+
+	buf := new(bytes.Buffer)
+	if err = html.Render(buf, doc); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// parseCSS parses a stylesheet and returns the rules which can be inlined, along with the remainder of the
+// stylesheet which cannot be
+func parseCSS(css string) ([]cssRule, string) {
+	var rules []cssRule
+	var remaining strings.Builder
+
+	css = cssComments.ReplaceAllString(css, "")
+	for {
+		css = strings.TrimSpace(css)
+		open := strings.IndexByte(css, '{')
+		if open == -1 {
+			break
+		}
+		prelude := strings.TrimSpace(css[:open])
+
+		// Find the matching closing brace, since at-rules contain nested blocks
+		depth, end := 0, -1
+		for i := open; i < len(css) && end == -1; i++ {
+			switch css[i] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+				if depth == 0 {
+					end = i
+				}
+			}
+		}
+		if end == -1 {
+			break
+		}
+		block := css[open+1 : end]
+		css = css[end+1:]
+
+		if strings.HasPrefix(prelude, "@") {
+			remaining.WriteString(prelude + " {" + block + "}\n")
+			continue
+		}
+
+		var inline, other []string
+		for _, s := range strings.Split(prelude, ",") {
+			s = strings.TrimSpace(s)
+			if parseSelector(s) != nil {
+				inline = append(inline, s)
+			} else if s != "" {
+				other = append(other, s)
+			}
+		}
+
+		if len(inline) > 0 {
+			rules = append(rules, cssRule{
+				selectors:    inline,
+				declarations: parseDeclarations(block),
+			})
+		}
+		if len(other) > 0 {
+			remaining.WriteString(strings.Join(other, ", ") + " {" + strings.TrimSpace(block) + "}\n")
+		}
+	}
+
+	return rules, strings.TrimSpace(remaining.String())
+}
+
+// parseDeclarations parses a list of declarations, such as those within a rule or style attribute
+func parseDeclarations(s string) []cssDeclaration {
+	var declarations []cssDeclaration
+	for _, d := range strings.Split(s, ";") {
+		property, value, ok := strings.Cut(d, ":")
+		property, value = strings.ToLower(strings.TrimSpace(property)), strings.TrimSpace(value)
+		if ok && property != "" && value != "" {
+			declarations = append(declarations, cssDeclaration{property: property, value: value})
+		}
+	}
+	return declarations
+}
+
+// formatDeclarations formats declarations as a style attribute, where a later declaration of a property
+// replaces an earlier one
+func formatDeclarations(declarations []cssDeclaration) string {
+	values := make(map[string]string, len(declarations))
+	var properties []string
+	for _, d := range declarations {
+		if _, ok := values[d.property]; !ok {
+			properties = append(properties, d.property)
+		}
+		values[d.property] = d.value
+	}
+
+	parts := make([]string, len(properties))
+	for i, p := range properties {
+		parts[i] = p + ": " + values[p]
+	}
+	return strings.Join(parts, "; ")
+}
+
+// parseSelector parses a selector, returning nil if it is not supported
+func parseSelector(s string) *cssSelector {
+	fields := strings.Fields(strings.ReplaceAll(s, ">", " > "))
+	if len(fields) == 0 {
+		return nil
+	}
+
+	sel := &cssSelector{}
+	child := false
+	for i, f := range fields {
+		if f == ">" {
+			if i == 0 || child {
+				return nil
+			}
+			child = true
+			continue
+		}
+
+		m := cssCompoundPattern.FindStringSubmatch(f)
+		if m == nil {
+			return nil
+		}
+
+		c := cssCompound{}
+		if m[1] != "*" {
+			c.tag = strings.ToLower(m[1])
+		}
+		if c.tag != "" {
+			sel.specificity[2]++
+		}
+		for _, part := range cssIDsAndClasses.FindAllString(m[2], -1) {
+			if part[0] == '#' {
+				c.id = part[1:]
+				sel.specificity[0]++
+			} else {
+				c.classes = append(c.classes, part[1:])
+				sel.specificity[1]++
+			}
+		}
+
+		if len(sel.compounds) > 0 {
+			sel.child = append(sel.child, child)
+		}
+		sel.compounds = append(sel.compounds, c)
+		child = false
+	}
+
+	if child {
+		return nil
+	}
+	return sel
+}
+
+// matches determines if the selector matches an element
+func (s *cssSelector) matches(n *html.Node) bool {
+	return s.matchFrom(n, len(s.compounds)-1)
+}
+
+// matchFrom determines if the compounds of the selector, up to and including the given index, match an
+// element and its ancestors
+func (s *cssSelector) matchFrom(n *html.Node, i int) bool {
+	if !s.compounds[i].matches(n) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+
+	if s.child[i-1] {
+		return n.Parent != nil && s.matchFrom(n.Parent, i-1)
+	}
+	for p := n.Parent; p != nil; p = p.Parent {
+		if s.matchFrom(p, i-1) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches determines if the compound selector matches an element
+func (c cssCompound) matches(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	if c.tag != "" && c.tag != n.Data {
+		return false
+	}
+
+	var id, class string
+	for _, attr := range n.Attr {
+		switch attr.Key {
+		case "id":
+			id = attr.Val
+		case "class":
+			class = attr.Val
+		}
+	}
+
+	if c.id != "" && c.id != id {
+		return false
+	}
+
+	classes := strings.Fields(class)
+	for _, want := range c.classes {
+		found := false
+		for _, have := range classes {
+			if have == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// walk calls a function for a node and all of its descendants
+func walk(n *html.Node, fn func(*html.Node)) {
+	fn(n)
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walk(c, fn)
+	}
+}
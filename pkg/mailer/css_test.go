@@ -0,0 +1,77 @@
+package mailer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInlineCSS(t *testing.T) {
+	document := `<!DOCTYPE html>
+<html>
+<head>
+<style>
+/* Base */
+body { margin: 0; color: #333; }
+p, li { font-size: 16px; }
+.content p { color: #111; }
+#footer { color: #999 }
+a.button { background: #00d1b2; color: #fff; }
+a:hover { color: red; }
+@media (max-width: 600px) { .content { padding: 0; } }
+</style>
+</head>
+<body>
+<div class="content"><p>One</p><p style="color: blue">Two</p></div>
+<ul><li>Item</li></ul>
+<p id="footer">Footer</p>
+<a class="button primary" href="#">Go</a>
+<a href="#">Link</a>
+</body>
+</html>`
+
+	out, err := InlineCSS(document)
+	require.NoError(t, err)
+
+	assert.Contains(t, out, `<body style="margin: 0; color: #333">`)
+	assert.Contains(t, out, `<p style="font-size: 16px; color: #111">One</p>`)
+	assert.Contains(t, out, `<p style="font-size: 16px; color: blue">Two</p>`)
+	assert.Contains(t, out, `<li style="font-size: 16px">Item</li>`)
+	assert.Contains(t, out, `<p id="footer" style="font-size: 16px; color: #999">Footer</p>`)
+	assert.Contains(t, out, `<a class="button primary" href="#" style="background: #00d1b2; color: #fff">Go</a>`)
+	assert.Contains(t, out, `<a href="#">Link</a>`)
+
+	// Rules which cannot be inlined remain in the stylesheet
+	assert.Contains(t, out, "a:hover {color: red;}")
+	assert.Contains(t, out, "@media (max-width: 600px)")
+	assert.NotContains(t, out, "font-size: 16px; }")
+	assert.NotContains(t, out, "Base")
+
+	// The stylesheet is removed when everything was inlined
+	out, err = InlineCSS(`<html><head><style>p { color: red; }</style></head><body><p>A</p></body></html>`)
+	require.NoError(t, err)
+	assert.False(t, strings.Contains(out, "<style"))
+	assert.Contains(t, out, `<p style="color: red">A</p>`)
+}
+
+func TestParseSelector(t *testing.T) {
+	tests := map[string][3]int{
+		"p":             {0, 0, 1},
+		"*":             {0, 0, 0},
+		".a.b":          {0, 2, 0},
+		"#x":            {1, 0, 0},
+		"div.a > p#b":   {1, 1, 2},
+		"table td.cell": {0, 1, 2},
+	}
+	for selector, specificity := range tests {
+		s := parseSelector(selector)
+		require.NotNil(t, s, selector)
+		assert.Equal(t, specificity, s.specificity, selector)
+	}
+
+	for _, selector := range []string{"a:hover", "input[type=text]", "> p", "p >", "a + b", "p::before"} {
+		assert.Nil(t, parseSelector(selector), selector)
+	}
+}
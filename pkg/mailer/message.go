@@ -0,0 +1,336 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// base64LineLength is the maximum length of lines of base64 encoded attachments
+const base64LineLength = 76
+
+var (
+	// headerName matches valid header names
+	headerName = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+	// generatedHeaders contains the headers which are generated from the fields of a message, in the order they
+	// are written
+	generatedHeaders = []string{
+		"From", "To", "Cc", "Bcc", "Reply-To", "Subject", "Date", "Message-ID", "MIME-Version",
+		"Content-Type", "Content-Transfer-Encoding",
+	}
+)
+
+// ErrInvalidMessage is returned when a message cannot be sent because it is incomplete or invalid
+var ErrInvalidMessage = errors.New("invalid message")
+
+type (
+	// Message is an email message
+	Message struct {
+		// From stores the address the message is from
+		From string
+
+		// To stores the addresses the message is sent to
+		To []string
+
+		// CC stores the addresses which receive a copy of the message
+		CC []string
+
+		// BCC stores the addresses which receive a copy of the message without being listed as a recipient
+		BCC []string
+
+		// ReplyTo stores the address replies should be sent to, if it differs from the from address
+		ReplyTo string
+
+		// Subject stores the subject line
+		Subject string
+
+		// Text stores the plain text body
+		Text string
+
+		// HTML stores the HTML body, which is optional
+		HTML string
+
+		// Attachments stores the files attached to the message
+		Attachments []Attachment
+
+		// Headers stores additional headers, such as List-Unsubscribe, which cannot replace those which are
+		// generated from the other fields
+		Headers map[string]string
+	}
+
+	// Attachment is a file attached to a message
+	Attachment struct {
+		// Filename stores the name of the file
+		Filename string
+
+		// ContentType stores the content type of the file
+		ContentType string
+
+		// Data stores the contents of the file
+		Data []byte
+	}
+
+	// entity is a MIME entity, made up of headers and a body
+	entity struct {
+		header textproto.MIMEHeader
+		body   []byte
+	}
+)
+
+// Sender sends email messages
+type Sender interface {
+	// Send sends a message
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Sender returns the address of the sender, as used in the SMTP envelope
+func (m *Message) Sender() (string, error) {
+	addr, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid from address: %v", ErrInvalidMessage, err)
+	}
+	return addr.Address, nil
+}
+
+// Recipients returns the addresses of everyone the message is to be delivered to, including BCC
+func (m *Message) Recipients() ([]string, error) {
+	var rcpts []string
+	for _, list := range [][]string{m.To, m.CC, m.BCC} {
+		for _, a := range list {
+			addr, err := mail.ParseAddress(a)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid recipient address: %v", ErrInvalidMessage, err)
+			}
+			rcpts = append(rcpts, addr.Address)
+		}
+	}
+
+	if len(rcpts) == 0 {
+		return nil, fmt.Errorf("%w: no recipients", ErrInvalidMessage)
+	}
+	return rcpts, nil
+}
+
+// Bytes returns the message formatted as per RFC 5322, ready to be delivered.
+// When both a text and HTML body are provided, a multipart/alternative message is produced so the mail client
+// can choose which to display. BCC addresses are not included.
+func (m *Message) Bytes() ([]byte, error) {
+	from, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid from address: %v", ErrInvalidMessage, err)
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("From", from.String())
+
+	for name, list := range map[string][]string{"To": m.To, "Cc": m.CC} {
+		if len(list) == 0 {
+			continue
+		}
+		addrs, err := formatAddresses(list)
+		if err != nil {
+			return nil, err
+		}
+		header.Set(name, addrs)
+	}
+
+	if m.ReplyTo != "" {
+		replyTo, err := mail.ParseAddress(m.ReplyTo)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid reply-to address: %v", ErrInvalidMessage, err)
+		}
+		header.Set("Reply-To", replyTo.String())
+	}
+
+	messageID, err := newMessageID(from.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header.Set("Date", time.Now().Format(time.RFC1123Z))
+	header.Set("Message-ID", messageID)
+	header.Set("MIME-Version", "1.0")
+
+	body, err := m.body()
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range body.header {
+		header[k] = v
+	}
+
+	// Additional headers follow the generated headers, except for the content headers which must precede the body
+	extra := make([]string, 0, len(m.Headers))
+	for name, value := range m.Headers {
+		name = textproto.CanonicalMIMEHeaderKey(name)
+		switch {
+		case !headerName.MatchString(name), strings.ContainsAny(value, "\r\n"):
+			return nil, fmt.Errorf("%w: invalid header: %s", ErrInvalidMessage, name)
+		case slices.Contains(generatedHeaders, name):
+			return nil, fmt.Errorf("%w: header cannot be replaced: %s", ErrInvalidMessage, name)
+		}
+		header.Set(name, value)
+		extra = append(extra, name)
+	}
+	sort.Strings(extra)
+
+	var buf bytes.Buffer
+	order := slices.Clone(generatedHeaders[:len(generatedHeaders)-2])
+	order = append(order, extra...)
+	order = append(order, "Content-Type", "Content-Transfer-Encoding")
+	for _, name := range order {
+		if v := header.Get(name); v != "" {
+			fmt.Fprintf(&buf, "%s: %s\r\n", name, v)
+		}
+	}
+	buf.WriteString("\r\n")
+	buf.Write(body.body)
+
+	return buf.Bytes(), nil
+}
+
+// body builds the MIME entity containing the bodies and attachments of the message
+func (m *Message) body() (entity, error) {
+	text, err := textEntity("text/plain", m.Text)
+	if err != nil {
+		return entity{}, err
+	}
+
+	content := text
+	if m.HTML != "" {
+		html, err := textEntity("text/html", m.HTML)
+		if err != nil {
+			return entity{}, err
+		}
+
+		content, err = multipartEntity("alternative", []entity{text, html})
+		if err != nil {
+			return entity{}, err
+		}
+	}
+
+	if len(m.Attachments) == 0 {
+		return content, nil
+	}
+
+	parts := []entity{content}
+	for _, a := range m.Attachments {
+		parts = append(parts, attachmentEntity(a))
+	}
+	return multipartEntity("mixed", parts)
+}
+
+// textEntity creates a MIME entity of UTF-8 text, encoded as quoted-printable
+func textEntity(contentType, text string) (entity, error) {
+	var buf bytes.Buffer
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := io.WriteString(w, text); err != nil {
+		return entity{}, err
+	}
+	if err := w.Close(); err != nil {
+		return entity{}, err
+	}
+
+	return entity{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(contentType, map[string]string{"charset": "utf-8"})},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		},
+		body: buf.Bytes(),
+	}, nil
+}
+
+// attachmentEntity creates a MIME entity of an attachment, encoded as base64
+func attachmentEntity(a Attachment) entity {
+	contentType := "application/octet-stream"
+	if mediaType, params, err := mime.ParseMediaType(a.ContentType); err == nil {
+		contentType = mime.FormatMediaType(mediaType, params)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(a.Data)
+	var buf bytes.Buffer
+	for len(encoded) > base64LineLength {
+		buf.WriteString(encoded[:base64LineLength])
+		buf.WriteString("\r\n")
+		encoded = encoded[base64LineLength:]
+	}
+	buf.WriteString(encoded)
+
+	return entity{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+		},
+		body: buf.Bytes(),
+	}
+}
+
+// multipartEntity creates a multipart MIME entity of a given subtype containing other entities
+func multipartEntity(subtype string, parts []entity) (entity, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for _, p := range parts {
+		pw, err := w.CreatePart(p.header)
+		if err != nil {
+			return entity{}, err
+		}
+		if _, err := pw.Write(p.body); err != nil {
+			return entity{}, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return entity{}, err
+	}
+
+	return entity{
+		header: textproto.MIMEHeader{
+			"Content-Type": {mime.FormatMediaType("multipart/"+subtype, map[string]string{"boundary": w.Boundary()})},
+		},
+		body: buf.Bytes(),
+	}, nil
+}
+
+// formatAddresses parses and formats a list of addresses for use in a header
+func formatAddresses(list []string) (string, error) {
+	formatted := make([]string, 0, len(list))
+	for _, a := range list {
+		addr, err := mail.ParseAddress(a)
+		if err != nil {
+			return "", fmt.Errorf("%w: invalid recipient address: %v", ErrInvalidMessage, err)
+		}
+		formatted = append(formatted, addr.String())
+	}
+	return strings.Join(formatted, ", "), nil
+}
+
+// newMessageID generates a unique message ID using the domain of the sender
+func newMessageID(from string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	domain := "localhost"
+	if i := strings.LastIndex(from, "@"); i != -1 && i < len(from)-1 {
+		domain = from[i+1:]
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain), nil
+}
@@ -0,0 +1,183 @@
+package mailer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readPart reads the body of a MIME part, decoding the transfer encoding
+func readPart(t *testing.T, p *multipart.Part) string {
+	var r io.Reader = p
+	switch p.Header.Get("Content-Transfer-Encoding") {
+	case "base64":
+		r = base64.NewDecoder(base64.StdEncoding, p)
+	case "quoted-printable":
+		r = quotedprintable.NewReader(p)
+	}
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(b)
+}
+
+// readParts reads all parts of a multipart body
+func readParts(t *testing.T, contentType string, body io.Reader) (string, []*multipart.Part, []string) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+
+	var parts []*multipart.Part
+	var bodies []string
+	r := multipart.NewReader(body, params["boundary"])
+	for {
+		p, err := r.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		parts = append(parts, p)
+		bodies = append(bodies, readPart(t, p))
+	}
+	return mediaType, parts, bodies
+}
+
+func TestMessage_Bytes(t *testing.T) {
+	msg := Message{
+		From:    "Pagoda <admin@localhost>",
+		To:      []string{"a@localhost", "B <b@localhost>"},
+		CC:      []string{"c@localhost"},
+		BCC:     []string{"hidden@localhost"},
+		ReplyTo: "reply@localhost",
+		Subject: "Héllo\r\nBcc: injected@localhost",
+		Text:    "Hello\nworld",
+		HTML:    "<p>Hello</p><p>world</p>",
+		Attachments: []Attachment{
+			{Filename: "report.csv", ContentType: "text/csv", Data: []byte(strings.Repeat("a,b\n", 50))},
+			{Filename: "data.bin", ContentType: "invalid\r\nX-Injected: true", Data: []byte{0, 1, 2}},
+		},
+	}
+
+	data, err := msg.Bytes()
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hidden@localhost")
+	assert.NotContains(t, string(data), "X-Injected")
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, `"Pagoda" <admin@localhost>`, parsed.Header.Get("From"))
+	assert.Equal(t, `<a@localhost>, "B" <b@localhost>`, parsed.Header.Get("To"))
+	assert.Equal(t, "<c@localhost>", parsed.Header.Get("Cc"))
+	assert.Equal(t, "<reply@localhost>", parsed.Header.Get("Reply-To"))
+	assert.Empty(t, parsed.Header.Get("Bcc"))
+	assert.Equal(t, "1.0", parsed.Header.Get("MIME-Version"))
+	assert.Regexp(t, `^<[a-f0-9]{32}@localhost>$`, parsed.Header.Get("Message-ID"))
+	_, err = parsed.Header.Date()
+	assert.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, msg.Subject, subject)
+
+	// The outer part contains the alternative bodies followed by the attachments
+	mediaType, parts, bodies := readParts(t, parsed.Header.Get("Content-Type"), parsed.Body)
+	assert.Equal(t, "multipart/mixed", mediaType)
+	require.Len(t, parts, 3)
+
+	mediaType, alternatives, altBodies := readParts(t, parts[0].Header.Get("Content-Type"), strings.NewReader(bodies[0]))
+	assert.Equal(t, "multipart/alternative", mediaType)
+	require.Len(t, alternatives, 2)
+	assert.Equal(t, "text/plain; charset=utf-8", alternatives[0].Header.Get("Content-Type"))
+	assert.Equal(t, "Hello\r\nworld", altBodies[0])
+	assert.Equal(t, "text/html; charset=utf-8", alternatives[1].Header.Get("Content-Type"))
+	assert.Equal(t, msg.HTML, altBodies[1])
+
+	assert.Equal(t, "text/csv", parts[1].Header.Get("Content-Type"))
+	assert.Equal(t, "attachment; filename=report.csv", parts[1].Header.Get("Content-Disposition"))
+	assert.Equal(t, string(msg.Attachments[0].Data), bodies[1])
+	assert.Equal(t, "application/octet-stream", parts[2].Header.Get("Content-Type"))
+	assert.Equal(t, string(msg.Attachments[1].Data), bodies[2])
+}
+
+func TestMessage_BytesHeaders(t *testing.T) {
+	msg := Message{
+		From:    "admin@localhost",
+		To:      []string{"a@localhost"},
+		Subject: "Digest",
+		Text:    "Hello",
+		Headers: map[string]string{
+			"list-unsubscribe":      "<https://localhost/unsubscribe>",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}
+
+	data, err := msg.Bytes()
+	require.NoError(t, err)
+	parsed, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, "<https://localhost/unsubscribe>", parsed.Header.Get("List-Unsubscribe"))
+	assert.Equal(t, "List-Unsubscribe=One-Click", parsed.Header.Get("List-Unsubscribe-Post"))
+	assert.Regexp(t, `(?s)MIME-Version: 1.0\r\nList-Unsubscribe: .*\r\nList-Unsubscribe-Post: .*\r\nContent-Type: `, string(data))
+
+	for name, value := range map[string]string{
+		"Subject":       "Replaced",
+		"bcc":           "hidden@localhost",
+		"Content-Type":  "text/html",
+		"X-Invalid":     "a\r\nBcc: injected@localhost",
+		"X Invalid":     "a",
+		"X-Invalid:Bcc": "a",
+	} {
+		msg.Headers = map[string]string{name: value}
+		_, err = msg.Bytes()
+		assert.ErrorIs(t, err, ErrInvalidMessage, name)
+	}
+}
+
+func TestMessage_BytesText(t *testing.T) {
+	msg := Message{
+		From:    "admin@localhost",
+		To:      []string{"a@localhost"},
+		Subject: "Subject",
+		Text:    "Hello",
+	}
+
+	data, err := msg.Bytes()
+	require.NoError(t, err)
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=utf-8", parsed.Header.Get("Content-Type"))
+	assert.Equal(t, "quoted-printable", parsed.Header.Get("Content-Transfer-Encoding"))
+	assert.Empty(t, parsed.Header.Get("Reply-To"))
+	assert.Empty(t, parsed.Header.Get("Cc"))
+
+	msg.From = "invalid"
+	_, err = msg.Bytes()
+	assert.Error(t, err)
+}
+
+func TestMessage_Recipients(t *testing.T) {
+	msg := Message{
+		To:  []string{"A <a@localhost>"},
+		CC:  []string{"c@localhost"},
+		BCC: []string{"d@localhost"},
+	}
+
+	rcpts, err := msg.Recipients()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a@localhost", "c@localhost", "d@localhost"}, rcpts)
+
+	msg.CC = []string{"invalid"}
+	_, err = msg.Recipients()
+	assert.Error(t, err)
+
+	_, err = new(Message).Recipients()
+	assert.Error(t, err)
+}
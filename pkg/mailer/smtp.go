@@ -0,0 +1,130 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+
+	"github.com/mikestefanello/pagoda/config"
+)
+
+// SMTPSender sends messages through an SMTP server.
+// The connection is upgraded with STARTTLS whenever the server supports it, and authentication, which is
+// performed when a user is configured, is refused over unencrypted connections to anything but localhost.
+type SMTPSender struct {
+	// config stores the mail configuration
+	config config.MailConfig
+
+	// tlsConfig stores the TLS configuration used for STARTTLS
+	tlsConfig *tls.Config
+}
+
+// NewSMTPSender creates a new SMTPSender
+func NewSMTPSender(cfg config.MailConfig) *SMTPSender {
+	return &SMTPSender{
+		config: cfg,
+		tlsConfig: &tls.Config{
+			ServerName: cfg.Hostname,
+			MinVersion: tls.VersionTLS12,
+		},
+	}
+}
+
+// IsPermanent determines if an error returned when sending a message is permanent, meaning that the message
+// will never be delivered if it is sent again, such as when the message is invalid or the SMTP server rejects
+// it with a 5xx reply
+func IsPermanent(err error) bool {
+	if errors.Is(err, ErrInvalidMessage) {
+		return true
+	}
+
+	var reply *textproto.Error
+	if errors.As(err, &reply) {
+		return reply.Code >= 500 && reply.Code < 600
+	}
+	return false
+}
+
+// Send sends a message, opening a new connection to the server for each message
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	from, err := msg.Sender()
+	if err != nil {
+		return err
+	}
+
+	rcpts, err := msg.Recipients()
+	if err != nil {
+		return err
+	}
+
+	data, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	var d net.Dialer
+	addr := net.JoinHostPort(s.config.Hostname, strconv.Itoa(int(s.config.Port)))
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("unable to connect to smtp server: %w", err)
+	}
+
+	// The smtp package does not support contexts so rely on the deadline, if any, along with closing the
+	// connection if the context is cancelled
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	c, err := smtp.NewClient(conn, s.config.Hostname)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err = c.StartTLS(s.tlsConfig); err != nil {
+			return fmt.Errorf("unable to start tls: %w", err)
+		}
+	}
+
+	if s.config.User != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp server does not support authentication")
+		}
+		if err = c.Auth(smtp.PlainAuth("", s.config.User, s.config.Password, s.config.Hostname)); err != nil {
+			return fmt.Errorf("unable to authenticate: %w", err)
+		}
+	}
+
+	if err = c.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range rcpts {
+		if err = c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(data); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
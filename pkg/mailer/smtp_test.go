@@ -0,0 +1,313 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mikestefanello/pagoda/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// testSMTPServer is a minimal, in-process SMTP server which records the messages it receives
+	testSMTPServer struct {
+		listener net.Listener
+
+		// tls stores the TLS configuration which, when set, enables STARTTLS
+		tls *tls.Config
+
+		// user and password store the credentials which, when set, are required
+		user, password string
+
+		mu       sync.Mutex
+		messages []testSMTPMessage
+	}
+
+	// testSMTPMessage is a message received by a testSMTPServer
+	testSMTPMessage struct {
+		from  string
+		rcpts []string
+		data  []byte
+		tls   bool
+	}
+)
+
+// newTestSMTPServer starts a new testSMTPServer which is stopped when the test completes
+func newTestSMTPServer(t *testing.T, tlsConfig *tls.Config, user, password string) *testSMTPServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &testSMTPServer{
+		listener: l,
+		tls:      tlsConfig,
+		user:     user,
+		password: password,
+	}
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.handle(conn)
+		}
+	}()
+
+	return s
+}
+
+// config returns mail configuration to connect to the server
+func (s *testSMTPServer) config(user, password string) config.MailConfig {
+	addr := s.listener.Addr().(*net.TCPAddr)
+	return config.MailConfig{
+		Hostname: "127.0.0.1",
+		Port:     uint16(addr.Port),
+		User:     user,
+		Password: password,
+	}
+}
+
+// received returns the messages received by the server
+func (s *testSMTPServer) received() []testSMTPMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.messages
+}
+
+// handle handles an SMTP session
+func (s *testSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 localhost ESMTP")
+
+	var msg testSMTPMessage
+	authenticated := s.user == ""
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(cmd) {
+		case "EHLO", "HELO":
+			lines := []string{"localhost"}
+			if s.tls != nil && !msg.tls {
+				lines = append(lines, "STARTTLS")
+			}
+			if s.user != "" {
+				lines = append(lines, "AUTH PLAIN")
+			}
+			for i, l := range lines {
+				sep := "-"
+				if i == len(lines)-1 {
+					sep = " "
+				}
+				_ = tp.PrintfLine("250%s%s", sep, l)
+			}
+		case "STARTTLS":
+			_ = tp.PrintfLine("220 Ready to start TLS")
+			tlsConn := tls.Server(conn, s.tls)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			tp = textproto.NewConn(conn)
+			msg.tls = true
+		case "AUTH":
+			_, credentials, _ := strings.Cut(arg, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(credentials)
+			if string(decoded) == "\x00"+s.user+"\x00"+s.password {
+				authenticated = true
+				_ = tp.PrintfLine("235 Authenticated")
+			} else {
+				_ = tp.PrintfLine("535 Invalid credentials")
+			}
+		case "MAIL":
+			if !authenticated {
+				_ = tp.PrintfLine("530 Authentication required")
+				continue
+			}
+			msg.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			_ = tp.PrintfLine("250 OK")
+		case "RCPT":
+			msg.rcpts = append(msg.rcpts, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+			_ = tp.PrintfLine("250 OK")
+		case "DATA":
+			_ = tp.PrintfLine("354 Go ahead")
+			msg.data, err = tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			_ = tp.PrintfLine("250 OK")
+		case "QUIT":
+			_ = tp.PrintfLine("221 Bye")
+			return
+		default:
+			_ = tp.PrintfLine("250 OK")
+		}
+	}
+}
+
+// newTestCertificate generates a self-signed certificate for 127.0.0.1 and returns the server and client
+// TLS configurations which use it
+func newTestCertificate(t *testing.T) (*tls.Config, *tls.Config) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	server := &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	}
+	client := &tls.Config{
+		ServerName: "127.0.0.1",
+		RootCAs:    pool,
+	}
+	return server, client
+}
+
+// testMessage returns a message to send in tests
+func testMessage() *Message {
+	return &Message{
+		From:    "Pagoda <admin@localhost>",
+		To:      []string{"to@localhost"},
+		CC:      []string{"cc@localhost"},
+		BCC:     []string{"bcc@localhost"},
+		Subject: "Test",
+		Text:    "Hello",
+		HTML:    "<p>Hello</p>",
+	}
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	serverTLS, clientTLS := newTestCertificate(t)
+	server := newTestSMTPServer(t, serverTLS, "user", "password")
+
+	sender := NewSMTPSender(server.config("user", "password"))
+	sender.tlsConfig = clientTLS
+	require.NoError(t, sender.Send(context.Background(), testMessage()))
+
+	received := server.received()
+	require.Len(t, received, 1)
+	assert.True(t, received[0].tls)
+	assert.Equal(t, "admin@localhost", received[0].from)
+	assert.Equal(t, []string{"to@localhost", "cc@localhost", "bcc@localhost"}, received[0].rcpts)
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(received[0].data))
+	require.NoError(t, err)
+	assert.Equal(t, "Test", parsed.Header.Get("Subject"))
+	assert.Equal(t, "<to@localhost>", parsed.Header.Get("To"))
+	assert.NotContains(t, string(received[0].data), "bcc@localhost")
+}
+
+func TestSMTPSender_SendWithoutTLS(t *testing.T) {
+	server := newTestSMTPServer(t, nil, "", "")
+
+	sender := NewSMTPSender(server.config("", ""))
+	require.NoError(t, sender.Send(context.Background(), testMessage()))
+
+	received := server.received()
+	require.Len(t, received, 1)
+	assert.False(t, received[0].tls)
+}
+
+func TestSMTPSender_SendFailures(t *testing.T) {
+	t.Run("invalid credentials", func(t *testing.T) {
+		server := newTestSMTPServer(t, nil, "user", "password")
+		sender := NewSMTPSender(server.config("user", "wrong"))
+		err := sender.Send(context.Background(), testMessage())
+		assert.Error(t, err)
+		assert.True(t, IsPermanent(err))
+		assert.Empty(t, server.received())
+	})
+
+	t.Run("authentication unsupported", func(t *testing.T) {
+		server := newTestSMTPServer(t, nil, "", "")
+		sender := NewSMTPSender(server.config("user", "password"))
+		assert.Error(t, sender.Send(context.Background(), testMessage()))
+		assert.Empty(t, server.received())
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		serverTLS, _ := newTestCertificate(t)
+		server := newTestSMTPServer(t, serverTLS, "", "")
+		sender := NewSMTPSender(server.config("", ""))
+		assert.Error(t, sender.Send(context.Background(), testMessage()))
+		assert.Empty(t, server.received())
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		server := newTestSMTPServer(t, nil, "", "")
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		sender := NewSMTPSender(server.config("", ""))
+		assert.Error(t, sender.Send(ctx, testMessage()))
+	})
+
+	t.Run("no recipients", func(t *testing.T) {
+		server := newTestSMTPServer(t, nil, "", "")
+		msg := testMessage()
+		msg.To, msg.CC, msg.BCC = nil, nil, nil
+		sender := NewSMTPSender(server.config("", ""))
+		err := sender.Send(context.Background(), msg)
+		assert.ErrorIs(t, err, ErrInvalidMessage)
+		assert.True(t, IsPermanent(err))
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		server := newTestSMTPServer(t, nil, "", "")
+		cfg := server.config("", "")
+		require.NoError(t, server.listener.Close())
+		err := NewSMTPSender(cfg).Send(context.Background(), testMessage())
+		assert.Error(t, err)
+		assert.False(t, IsPermanent(err))
+	})
+}
+
+func TestIsPermanent(t *testing.T) {
+	assert.True(t, IsPermanent(&textproto.Error{Code: 550, Msg: "mailbox unavailable"}))
+	assert.True(t, IsPermanent(fmt.Errorf("wrapped: %w", &textproto.Error{Code: 554})))
+	assert.False(t, IsPermanent(&textproto.Error{Code: 421, Msg: "try again later"}))
+	assert.False(t, IsPermanent(errors.New("connection reset")))
+}
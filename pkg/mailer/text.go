@@ -0,0 +1,235 @@
+package mailer
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	// textSkipTags contains the tags whose content is not part of the text
+	textSkipTags = map[string]bool{
+		"head":   true,
+		"script": true,
+		"style":  true,
+		"title":  true,
+	}
+
+	// textBlockTags contains the tags which start on a new line and the amount of line breaks which
+	// separate them from surrounding content
+	textBlockTags = map[string]int{
+		"address":    2,
+		"article":    1,
+		"blockquote": 2,
+		"div":        1,
+		"footer":     1,
+		"h1":         2,
+		"h2":         2,
+		"h3":         2,
+		"h4":         2,
+		"h5":         2,
+		"h6":         2,
+		"header":     1,
+		"hr":         2,
+		"li":         1,
+		"ol":         2,
+		"p":          2,
+		"pre":        2,
+		"section":    1,
+		"table":      2,
+		"tr":         1,
+		"ul":         2,
+	}
+
+	// textWhitespace matches runs of whitespace
+	textWhitespace = regexp.MustCompile(`\s+`)
+
+	// textURL matches URLs within plain text
+	textURL = regexp.MustCompile(`https?://[^\s<>"]+`)
+)
+
+// textWriter builds plain text while collapsing whitespace and line breaks
+type textWriter struct {
+	b strings.Builder
+
+	// breaks stores the amount of line breaks to write before the next text
+	breaks int
+
+	// space indicates that a space should be written before the next text
+	space bool
+}
+
+// lineBreak requests that the next text is separated from the previous by at least n line breaks
+func (w *textWriter) lineBreak(n int) {
+	w.breaks = max(w.breaks, n)
+	w.space = false
+}
+
+// text writes text, collapsing whitespace unless it is preformatted
+func (w *textWriter) text(s string, pre bool) {
+	if pre {
+		w.write(s)
+		return
+	}
+
+	collapsed := textWhitespace.ReplaceAllString(s, " ")
+	if strings.TrimSpace(collapsed) == "" {
+		w.space = w.space || collapsed != ""
+		return
+	}
+
+	w.space = w.space || collapsed[0] == ' '
+	w.write(strings.TrimSpace(collapsed))
+	w.space = collapsed[len(collapsed)-1] == ' '
+}
+
+// write writes text preceded by any pending line breaks or space
+func (w *textWriter) write(s string) {
+	if w.b.Len() > 0 {
+		if w.breaks > 0 {
+			w.b.WriteString(strings.Repeat("\n", w.breaks))
+		} else if w.space {
+			w.b.WriteString(" ")
+		}
+	}
+	w.breaks = 0
+	w.space = false
+	w.b.WriteString(s)
+}
+
+// HTMLToText converts HTML in to plain text for use as the alternative body of an email.
+// Block elements are separated by line breaks, list items are prefixed with a dash and the URLs of links
+// are included after their text.
+func HTMLToText(source string) string {
+	var w textWriter
+	z := html.NewTokenizer(strings.NewReader(source))
+	skip, pre := 0, 0
+
+	// hrefs and linkText store the URL and text of the links being written, which can be nested in
+	// invalid HTML
+	var hrefs, linkText []string
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return strings.TrimSpace(w.b.String())
+
+		case html.TextToken:
+			if skip > 0 {
+				continue
+			}
+			text := string(z.Text())
+			if len(linkText) > 0 {
+				linkText[len(linkText)-1] += text
+			}
+			w.text(text, pre > 0)
+
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			name, hasAttr := z.TagName()
+			tag := string(name)
+			start := tt != html.EndTagToken
+
+			if textSkipTags[tag] {
+				if tt == html.StartTagToken {
+					skip++
+				} else if tt == html.EndTagToken && skip > 0 {
+					skip--
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+
+			if n, ok := textBlockTags[tag]; ok {
+				w.lineBreak(n)
+			}
+
+			switch tag {
+			case "br":
+				w.lineBreak(1)
+			case "pre":
+				if tt == html.StartTagToken {
+					pre++
+				} else if tt == html.EndTagToken && pre > 0 {
+					pre--
+				}
+			case "li":
+				if start {
+					w.write("-")
+					w.space = true
+				}
+			case "td", "th":
+				if !start {
+					w.space = true
+				}
+			case "a":
+				if tt == html.StartTagToken {
+					href := ""
+					for hasAttr {
+						var k, v []byte
+						k, v, hasAttr = z.TagAttr()
+						if string(k) == "href" {
+							href = string(v)
+						}
+					}
+					hrefs = append(hrefs, href)
+					linkText = append(linkText, "")
+				} else if tt == html.EndTagToken && len(hrefs) > 0 {
+					href, text := hrefs[len(hrefs)-1], linkText[len(linkText)-1]
+					hrefs, linkText = hrefs[:len(hrefs)-1], linkText[:len(linkText)-1]
+
+					// Omit the URL when it is already the text of the link or is not useful outside of HTML
+					url := strings.TrimPrefix(href, "mailto:")
+					if url != "" && !strings.HasPrefix(url, "#") && strings.TrimSpace(text) != url {
+						w.space = true
+						w.write("(" + url + ")")
+					}
+				}
+			}
+		}
+	}
+}
+
+// Links returns the unique URLs linked to within the message, in the order they appear.
+// The links within the HTML body are used if there is one, otherwise the URLs within the text body.
+func (m *Message) Links() []string {
+	var links []string
+	seen := make(map[string]bool)
+	add := func(link string) {
+		if !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	}
+
+	if m.HTML == "" {
+		for _, link := range textURL.FindAllString(m.Text, -1) {
+			add(strings.TrimRight(link, ".,;:!?)"))
+		}
+		return links
+	}
+
+	z := html.NewTokenizer(strings.NewReader(m.HTML))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return links
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			if string(name) != "a" {
+				continue
+			}
+			for hasAttr {
+				var k, v []byte
+				k, v, hasAttr = z.TagAttr()
+				href := string(v)
+				if string(k) == "href" && (strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://")) {
+					add(href)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLToText(t *testing.T) {
+	tests := map[string]struct {
+		html     string
+		expected string
+	}{
+		"plain": {
+			html:     "Hello   world",
+			expected: "Hello world",
+		},
+		"document": {
+			html: `<html><head><title>Title</title><style>p { color: red; }</style></head>
+				<body><h1>Welcome</h1><p>First   paragraph
+				spanning lines.</p><p>Second<br>line</p><script>alert(1)</script></body></html>`,
+			expected: "Welcome\n\nFirst paragraph spanning lines.\n\nSecond\nline",
+		},
+		"links": {
+			html:     `<p>Click <a href="https://example.com/verify">here</a> or visit <a href="https://example.com">https://example.com</a>.</p>`,
+			expected: "Click here (https://example.com/verify) or visit https://example.com.",
+		},
+		"mailto": {
+			html:     `<a href="mailto:admin@localhost">admin@localhost</a> <a href="#top">Top</a>`,
+			expected: "admin@localhost Top",
+		},
+		"list": {
+			html:     "<p>Items:</p><ul><li>One</li><li><b>Two</b> items</li></ul><p>Done</p>",
+			expected: "Items:\n\n- One\n- Two items\n\nDone",
+		},
+		"table": {
+			html:     "<table><tr><td>A</td><td>B</td></tr><tr><td>C</td><td>D</td></tr></table>",
+			expected: "A B\nC D",
+		},
+		"preformatted": {
+			html:     "<pre>line 1\n  line 2</pre>",
+			expected: "line 1\n  line 2",
+		},
+		"entities": {
+			html:     "<p>Fish &amp; chips &lt;3</p>",
+			expected: "Fish & chips <3",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, HTMLToText(test.html))
+		})
+	}
+}
+
+func TestMessage_Links(t *testing.T) {
+	msg := &Message{
+		HTML: `<p><a href="https://example.com/verify?a=1&amp;b=2">Verify</a> <a href="mailto:a@b">Mail</a></p>` +
+			`<a href="https://example.com/verify?a=1&amp;b=2">Again</a><a href="http://example.com/">Home</a>`,
+		Text: "https://ignored.com",
+	}
+	assert.Equal(t, []string{"https://example.com/verify?a=1&b=2", "http://example.com/"}, msg.Links())
+
+	msg = &Message{Text: "Visit https://example.com/reset/1. Or (https://example.com/help)"}
+	assert.Equal(t, []string{"https://example.com/reset/1", "https://example.com/help"}, msg.Links())
+}
@@ -0,0 +1,124 @@
+package markdown
+
+import (
+	"bytes"
+	"html/template"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+type (
+	// Renderer converts Markdown in to sanitized HTML.
+	// CommonMark is supported along with the GFM extensions (tables, strikethrough, autolinks and
+	// task lists) and footnotes. Fenced code blocks are highlighted server-side and all headings
+	// receive an ID so they can be used as anchors.
+	Renderer struct {
+		// md stores the Markdown parser and renderer
+		md goldmark.Markdown
+
+		// policy stores the allowlist used to sanitize the rendered HTML
+		policy *bluemonday.Policy
+	}
+
+	// Document is the output of rendering Markdown
+	Document struct {
+		// HTML stores the sanitized HTML
+		HTML template.HTML
+
+		// TOC stores the table of contents, built from the headings
+		TOC []Heading
+	}
+
+	// Heading represents a heading within a Document
+	Heading struct {
+		// Level stores the heading level, 1 through 6
+		Level int
+
+		// ID stores the anchor ID of the heading
+		ID string
+
+		// Text stores the plain text of the heading
+		Text string
+	}
+)
+
+// NewRenderer creates a new Renderer
+func NewRenderer() *Renderer {
+	md := goldmark.New(
+		goldmark.WithExtensions(
+			extension.GFM,
+			extension.Footnote,
+			highlighting.NewHighlighting(
+				highlighting.WithStyle("github"),
+				highlighting.WithFormatOptions(
+					chromahtml.TabWidth(4),
+				),
+			),
+		),
+		goldmark.WithParserOptions(
+			parser.WithAutoHeadingID(),
+		),
+	)
+
+	// Start with the policy for user generated content, which includes standard attributes
+	// such as IDs, and add what is needed for the highlighted code and task lists
+	policy := bluemonday.UGCPolicy()
+	policy.AllowStyles("color", "background-color", "font-weight", "font-style", "text-decoration").
+		OnElements("span", "pre")
+	policy.AllowAttrs("type", "checked", "disabled").OnElements("input")
+
+	return &Renderer{
+		md:     md,
+		policy: policy,
+	}
+}
+
+// Render renders Markdown source in to a sanitized Document
+func (r *Renderer) Render(source string) (*Document, error) {
+	src := []byte(source)
+	doc := r.md.Parser().Parse(text.NewReader(src))
+
+	buf := new(bytes.Buffer)
+	if err := r.md.Renderer().Render(buf, src, doc); err != nil {
+		return nil, err
+	}
+
+	return &Document{
+		HTML: template.HTML(r.policy.SanitizeBytes(buf.Bytes())),
+		TOC:  r.headings(doc, src),
+	}, nil
+}
+
+// headings extracts all headings from a parsed document
+func (r *Renderer) headings(doc ast.Node, src []byte) []Heading {
+	var headings []Heading
+
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		h, ok := n.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+
+		heading := Heading{
+			Level: h.Level,
+			Text:  string(h.Text(src)),
+		}
+		if id, ok := h.AttributeString("id"); ok {
+			if b, ok := id.([]byte); ok {
+				heading.ID = string(b)
+			}
+		}
+		headings = append(headings, heading)
+
+		return ast.WalkSkipChildren, nil
+	})
+
+	return headings
+}
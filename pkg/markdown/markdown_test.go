@@ -0,0 +1,67 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	r := NewRenderer()
+
+	t.Run("commonmark", func(t *testing.T) {
+		doc, err := r.Render("Some *emphasis* and **strong** text.")
+		require.NoError(t, err)
+		assert.Equal(t, "<p>Some <em>emphasis</em> and <strong>strong</strong> text.</p>\n", string(doc.HTML))
+	})
+
+	t.Run("table", func(t *testing.T) {
+		doc, err := r.Render("| a | b |\n|---|---|\n| 1 | 2 |")
+		require.NoError(t, err)
+		assert.Contains(t, string(doc.HTML), "<table>")
+		assert.Contains(t, string(doc.HTML), "<td>1</td>")
+	})
+
+	t.Run("footnote", func(t *testing.T) {
+		doc, err := r.Render("Text[^1]\n\n[^1]: The note.")
+		require.NoError(t, err)
+		assert.Contains(t, string(doc.HTML), `id="fn:1"`)
+		assert.Contains(t, string(doc.HTML), "The note.")
+	})
+
+	t.Run("highlighting", func(t *testing.T) {
+		doc, err := r.Render("```go\nfunc main() {}\n```")
+		require.NoError(t, err)
+		assert.Contains(t, string(doc.HTML), "<pre")
+		assert.Contains(t, string(doc.HTML), `<span style="`)
+	})
+
+	t.Run("sanitized", func(t *testing.T) {
+		doc, err := r.Render(`<script>alert(1)</script><a href="javascript:alert(1)" onclick="x()">link</a>`)
+		require.NoError(t, err)
+		assert.NotContains(t, string(doc.HTML), "<script")
+		assert.NotContains(t, string(doc.HTML), "javascript:")
+		assert.NotContains(t, string(doc.HTML), "onclick")
+	})
+
+	t.Run("toc", func(t *testing.T) {
+		doc, err := r.Render("# First heading\n\ntext\n\n## Second *one*\n\n### Third")
+		require.NoError(t, err)
+		require.Len(t, doc.TOC, 3)
+		assert.Equal(t, Heading{Level: 1, ID: "first-heading", Text: "First heading"}, doc.TOC[0])
+		assert.Equal(t, Heading{Level: 2, ID: "second-one", Text: "Second one"}, doc.TOC[1])
+		assert.Equal(t, 3, doc.TOC[2].Level)
+		assert.True(t, strings.Contains(string(doc.HTML), `<h1 id="first-heading">`))
+	})
+}
+
+func TestPlainText(t *testing.T) {
+	source := "# Heading\n\nSome **strong** and [linked](https://example.com) text,\nwith `code`.\n\n" +
+		"```go\nfunc main() {}\n```\n\n- One\n- <https://example.org>\n\n<div class=\"note\">HTML <b>text</b></div>\n\n" +
+		"<script>alert(1)</script> after"
+	expected := "Heading\nSome strong and linked text,\nwith code.\nfunc main() {}\nOne\nhttps://example.org\n" +
+		"HTML text\n after"
+	assert.Equal(t, expected, PlainText(source))
+}
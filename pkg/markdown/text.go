@@ -0,0 +1,76 @@
+package markdown
+
+import (
+	"html"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+var (
+	// textParser stores the parser used to extract plain text, which supports the same syntax as the Renderer
+	textParser = goldmark.New(goldmark.WithExtensions(extension.GFM, extension.Footnote)).Parser()
+
+	// textPolicy stores the policy which removes all tags from HTML within Markdown, keeping only its text
+	textPolicy = bluemonday.StrictPolicy()
+)
+
+// PlainText returns the text of Markdown source without any of its syntax, such as emphasis, link destinations
+// and code fences, or HTML tags. Each block, such as a paragraph or heading, is placed on its own line.
+func PlainText(source string) string {
+	src := []byte(source)
+	doc := textParser.Parse(text.NewReader(src))
+
+	var b strings.Builder
+	newline := func() {
+		if s := b.String(); s != "" && !strings.HasSuffix(s, "\n") {
+			b.WriteByte('\n')
+		}
+	}
+	lines := func(n ast.Node) string {
+		var l strings.Builder
+		for i := 0; i < n.Lines().Len(); i++ {
+			seg := n.Lines().At(i)
+			l.Write(seg.Value(src))
+		}
+		return l.String()
+	}
+
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			if n.Type() == ast.TypeBlock {
+				newline()
+			}
+			return ast.WalkContinue, nil
+		}
+
+		switch n := n.(type) {
+		case *ast.Text:
+			b.Write(n.Segment.Value(src))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				b.WriteByte('\n')
+			}
+		case *ast.String:
+			b.Write(n.Value)
+		case *ast.AutoLink:
+			b.Write(n.Label(src))
+			return ast.WalkSkipChildren, nil
+		case *ast.CodeBlock, *ast.FencedCodeBlock:
+			b.WriteString(lines(n))
+			return ast.WalkSkipChildren, nil
+		case *ast.HTMLBlock:
+			b.WriteString(html.UnescapeString(textPolicy.Sanitize(lines(n))))
+			return ast.WalkSkipChildren, nil
+		case *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	return strings.TrimSpace(b.String())
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/mikestefanello/pagoda/ent"
 	"github.com/mikestefanello/pagoda/pkg/context"
 	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/permission"
 	"github.com/mikestefanello/pagoda/pkg/services"
 
 	"github.com/labstack/echo/v4"
@@ -25,6 +26,9 @@ func LoadAuthenticatedUser(authClient *services.AuthClient) echo.MiddlewareFunc
 			case nil:
 				c.Set(context.AuthenticatedUserKey, u)
 				c.Logger().Infof("auth user loaded in to context: %d", u.ID)
+
+				// Entity privacy policies act on behalf of the user
+				c.SetRequest(c.Request().WithContext(permission.NewContext(c.Request().Context(), u)))
 			default:
 				return echo.NewHTTPError(
 					http.StatusInternalServerError,
@@ -107,3 +111,21 @@ func RequireNoAuthentication() echo.MiddlewareFunc {
 		}
 	}
 }
+
+// RequirePermission requires that the role of the authenticated user grants a given permission in order to proceed
+// This requires that the authenticated user is loaded in to context
+func RequirePermission(p permission.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			u, ok := c.Get(context.AuthenticatedUserKey).(*ent.User)
+			switch {
+			case !ok:
+				return echo.NewHTTPError(http.StatusUnauthorized)
+			case !permission.Has(u, p):
+				return echo.NewHTTPError(http.StatusForbidden)
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/user"
 	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/permission"
 	"github.com/mikestefanello/pagoda/pkg/tests"
 
 	"github.com/stretchr/testify/require"
@@ -33,6 +35,11 @@ func TestLoadAuthenticatedUser(t *testing.T) {
 	ctxUsr, ok := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
 	require.True(t, ok)
 	assert.Equal(t, usr.ID, ctxUsr.ID)
+
+	// Verify the user is the viewer of entity privacy policies
+	viewer := permission.FromContext(ctx.Request().Context())
+	require.NotNil(t, viewer)
+	assert.Equal(t, usr.ID, viewer.ID)
 }
 
 func TestRequireAuthentication(t *testing.T) {
@@ -71,6 +78,27 @@ func TestRequireNoAuthentication(t *testing.T) {
 	tests.AssertHTTPErrorCode(t, err, http.StatusForbidden)
 }
 
+func TestRequirePermission(t *testing.T) {
+	ctx, _ := tests.NewContext(c.Web, "/")
+
+	// Not logged in
+	err := tests.ExecuteMiddleware(ctx, RequirePermission(permission.ManageSite))
+	tests.AssertHTTPErrorCode(t, err, http.StatusUnauthorized)
+
+	// Not permitted
+	ctx.Set(context.AuthenticatedUserKey, &ent.User{ID: usr.ID, Role: user.RoleEditor})
+	err = tests.ExecuteMiddleware(ctx, RequirePermission(permission.ManageSite))
+	tests.AssertHTTPErrorCode(t, err, http.StatusForbidden)
+
+	// Permitted
+	err = tests.ExecuteMiddleware(ctx, RequirePermission(permission.EditAnyPost))
+	assert.Nil(t, err)
+
+	ctx.Set(context.AuthenticatedUserKey, &ent.User{ID: usr.ID, Role: user.RoleAdmin})
+	err = tests.ExecuteMiddleware(ctx, RequirePermission(permission.ManageSite))
+	assert.Nil(t, err)
+}
+
 func TestLoadValidPasswordToken(t *testing.T) {
 	ctx, _ := tests.NewContext(c.Web, "/")
 	tests.InitSession(ctx)
@@ -109,3 +137,17 @@ func TestLoadValidPasswordToken(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, pt.ID, ctxPt.ID)
 }
+
+func TestRequireAuthentication_TwoFactor(t *testing.T) {
+	ctx, _ := tests.NewContext(c.Web, "/")
+	tests.InitSession(ctx)
+
+	// A half-authenticated session, awaiting a two-factor code, is not logged in
+	err := c.Auth.StartTwoFactorLogin(ctx, usr.ID)
+	require.NoError(t, err)
+	_ = tests.ExecuteMiddleware(ctx, LoadAuthenticatedUser(c.Auth))
+	assert.Nil(t, ctx.Get(context.AuthenticatedUserKey))
+
+	err = tests.ExecuteMiddleware(ctx, RequireAuthentication())
+	tests.AssertHTTPErrorCode(t, err, http.StatusUnauthorized)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/htmx"
 	"github.com/mikestefanello/pagoda/pkg/services"
 
 	"github.com/go-redis/redis/v8"
@@ -32,7 +33,7 @@ type CachedPage struct {
 
 // ServeCachedPage attempts to load a page from the cache by matching on the complete request URL
 // If a page is cached for the requested URL, it will be served here and the request terminated.
-// Any request made by an authenticated user or that is not a GET will be skipped.
+// Any request made by an authenticated user, that is not a GET or that is an HTMX partial request will be skipped.
 func ServeCachedPage(ch *services.CacheClient) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -46,6 +47,11 @@ func ServeCachedPage(ch *services.CacheClient) echo.MiddlewareFunc {
 				return next(c)
 			}
 
+			// Skip HTMX partial requests since only full pages are cached
+			if r := htmx.GetRequest(c); r.Enabled && !r.Boosted {
+				return next(c)
+			}
+
 			// Attempt to load from cache
 			res, err := ch.
 				Get().
@@ -6,6 +6,9 @@ import (
 	"strconv"
 
 	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/comment"
+	"github.com/mikestefanello/pagoda/ent/media"
+	"github.com/mikestefanello/pagoda/ent/post"
 	"github.com/mikestefanello/pagoda/ent/user"
 	"github.com/mikestefanello/pagoda/pkg/context"
 
@@ -41,3 +44,160 @@ func LoadUser(orm *ent.Client) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// LoadAuthor loads the user based on the ID provided as a path parameter, but only if they have at least one
+// published post, so accounts which have not published anything cannot be discovered
+func LoadAuthor(orm *ent.Client) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, err := strconv.Atoi(c.Param("user"))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusNotFound)
+			}
+
+			u, err := orm.User.
+				Query().
+				Where(
+					user.ID(userID),
+					user.HasPostsWith(post.StatusEQ(post.StatusPublished)),
+				).
+				Only(c.Request().Context())
+
+			switch err.(type) {
+			case nil:
+				c.Set(context.UserKey, u)
+				return next(c)
+			case *ent.NotFoundError:
+				return echo.NewHTTPError(http.StatusNotFound)
+			default:
+				return echo.NewHTTPError(
+					http.StatusInternalServerError,
+					fmt.Sprintf("error querying user: %v", err),
+				)
+			}
+		}
+	}
+}
+
+// LoadPost loads the post based on the ID provided as a path parameter, along with its author
+func LoadPost(orm *ent.Client) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			postID, err := strconv.Atoi(c.Param("post"))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusNotFound)
+			}
+
+			p, err := orm.Post.
+				Query().
+				Where(post.ID(postID)).
+				WithAuthor().
+				Only(c.Request().Context())
+
+			switch err.(type) {
+			case nil:
+				c.Set(context.PostKey, p)
+				return next(c)
+			case *ent.NotFoundError:
+				return echo.NewHTTPError(http.StatusNotFound)
+			default:
+				return echo.NewHTTPError(
+					http.StatusInternalServerError,
+					fmt.Sprintf("error querying post: %v", err),
+				)
+			}
+		}
+	}
+}
+
+// LoadComment loads the comment based on the ID provided as a path parameter, along with its post and
+// the author of the post
+func LoadComment(orm *ent.Client) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			commentID, err := strconv.Atoi(c.Param("comment"))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusNotFound)
+			}
+
+			cm, err := orm.Comment.
+				Query().
+				Where(comment.ID(commentID)).
+				WithPost(func(q *ent.PostQuery) {
+					q.WithAuthor()
+				}).
+				Only(c.Request().Context())
+
+			switch err.(type) {
+			case nil:
+				c.Set(context.CommentKey, cm)
+				return next(c)
+			case *ent.NotFoundError:
+				return echo.NewHTTPError(http.StatusNotFound)
+			default:
+				return echo.NewHTTPError(
+					http.StatusInternalServerError,
+					fmt.Sprintf("error querying comment: %v", err),
+				)
+			}
+		}
+	}
+}
+
+// LoadMedia loads the media based on the ID provided as a path parameter, along with its owner
+func LoadMedia(orm *ent.Client) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			mediaID, err := strconv.Atoi(c.Param("media"))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusNotFound)
+			}
+
+			m, err := orm.Media.
+				Query().
+				Where(media.ID(mediaID)).
+				WithOwner().
+				Only(c.Request().Context())
+
+			switch err.(type) {
+			case nil:
+				c.Set(context.MediaKey, m)
+				return next(c)
+			case *ent.NotFoundError:
+				return echo.NewHTTPError(http.StatusNotFound)
+			default:
+				return echo.NewHTTPError(
+					http.StatusInternalServerError,
+					fmt.Sprintf("error querying media: %v", err),
+				)
+			}
+		}
+	}
+}
+
+// LoadFailedMail loads the failed mail based on the ID provided as a path parameter
+func LoadFailedMail(orm *ent.Client) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			mailID, err := strconv.Atoi(c.Param("mail"))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusNotFound)
+			}
+
+			fm, err := orm.FailedMail.Get(c.Request().Context(), mailID)
+
+			switch err.(type) {
+			case nil:
+				c.Set(context.FailedMailKey, fm)
+				return next(c)
+			case *ent.NotFoundError:
+				return echo.NewHTTPError(http.StatusNotFound)
+			default:
+				return echo.NewHTTPError(
+					http.StatusInternalServerError,
+					fmt.Sprintf("error querying failed mail: %v", err),
+				)
+			}
+		}
+	}
+}
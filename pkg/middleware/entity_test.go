@@ -1,11 +1,14 @@
 package middleware
 
 import (
+	goctx "context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/mikestefanello/pagoda/ent"
 	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/mailer"
 	"github.com/mikestefanello/pagoda/pkg/tests"
 
 	"github.com/stretchr/testify/assert"
@@ -21,3 +24,121 @@ func TestLoadUser(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, usr.ID, ctxUsr.ID)
 }
+
+func TestLoadAuthor(t *testing.T) {
+	author, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	load := func() (*ent.User, error) {
+		ctx, _ := tests.NewContext(c.Web, "/")
+		ctx.SetParamNames("user")
+		ctx.SetParamValues(fmt.Sprintf("%d", author.ID))
+		err := tests.ExecuteMiddleware(ctx, LoadAuthor(c.ORM))
+		u, _ := ctx.Get(context.UserKey).(*ent.User)
+		return u, err
+	}
+
+	// Users without published posts are not found
+	_, err = load()
+	tests.AssertHTTPErrorCode(t, err, http.StatusNotFound)
+
+	_, err = tests.CreatePost(c.ORM, author)
+	require.NoError(t, err)
+	ctxUsr, err := load()
+	require.NoError(t, err)
+	require.NotNil(t, ctxUsr)
+	assert.Equal(t, author.ID, ctxUsr.ID)
+}
+
+func TestLoadPost(t *testing.T) {
+	p, err := tests.CreatePost(c.ORM, usr)
+	require.NoError(t, err)
+
+	ctx, _ := tests.NewContext(c.Web, "/")
+	ctx.SetParamNames("post")
+	ctx.SetParamValues(fmt.Sprintf("%d", p.ID))
+	_ = tests.ExecuteMiddleware(ctx, LoadPost(c.ORM))
+	ctxPost, ok := ctx.Get(context.PostKey).(*ent.Post)
+	require.True(t, ok)
+	assert.Equal(t, p.ID, ctxPost.ID)
+	require.NotNil(t, ctxPost.Edges.Author)
+	assert.Equal(t, usr.ID, ctxPost.Edges.Author.ID)
+
+	ctx, _ = tests.NewContext(c.Web, "/")
+	ctx.SetParamNames("post")
+	ctx.SetParamValues("0")
+	err = tests.ExecuteMiddleware(ctx, LoadPost(c.ORM))
+	tests.AssertHTTPErrorCode(t, err, http.StatusNotFound)
+}
+
+func TestLoadComment(t *testing.T) {
+	p, err := tests.CreatePost(c.ORM, usr)
+	require.NoError(t, err)
+	cm, err := tests.CreateComment(c.ORM, p)
+	require.NoError(t, err)
+
+	ctx, _ := tests.NewContext(c.Web, "/")
+	ctx.SetParamNames("comment")
+	ctx.SetParamValues(fmt.Sprintf("%d", cm.ID))
+	_ = tests.ExecuteMiddleware(ctx, LoadComment(c.ORM))
+	ctxComment, ok := ctx.Get(context.CommentKey).(*ent.Comment)
+	require.True(t, ok)
+	assert.Equal(t, cm.ID, ctxComment.ID)
+	require.NotNil(t, ctxComment.Edges.Post)
+	require.NotNil(t, ctxComment.Edges.Post.Edges.Author)
+	assert.Equal(t, usr.ID, ctxComment.Edges.Post.Edges.Author.ID)
+
+	ctx, _ = tests.NewContext(c.Web, "/")
+	ctx.SetParamNames("comment")
+	ctx.SetParamValues("0")
+	err = tests.ExecuteMiddleware(ctx, LoadComment(c.ORM))
+	tests.AssertHTTPErrorCode(t, err, http.StatusNotFound)
+}
+
+func TestLoadMedia(t *testing.T) {
+	m, err := tests.CreateMedia(c.ORM, usr)
+	require.NoError(t, err)
+
+	ctx, _ := tests.NewContext(c.Web, "/")
+	ctx.SetParamNames("media")
+	ctx.SetParamValues(fmt.Sprintf("%d", m.ID))
+	_ = tests.ExecuteMiddleware(ctx, LoadMedia(c.ORM))
+	ctxMedia, ok := ctx.Get(context.MediaKey).(*ent.Media)
+	require.True(t, ok)
+	assert.Equal(t, m.ID, ctxMedia.ID)
+	require.NotNil(t, ctxMedia.Edges.Owner)
+	assert.Equal(t, usr.ID, ctxMedia.Edges.Owner.ID)
+
+	ctx, _ = tests.NewContext(c.Web, "/")
+	ctx.SetParamNames("media")
+	ctx.SetParamValues("0")
+	err = tests.ExecuteMiddleware(ctx, LoadMedia(c.ORM))
+	tests.AssertHTTPErrorCode(t, err, http.StatusNotFound)
+}
+
+func TestLoadFailedMail(t *testing.T) {
+	fm, err := c.ORM.FailedMail.
+		Create().
+		SetTo("a@localhost").
+		SetSubject("Subject").
+		SetMessage(&mailer.Message{To: []string{"a@localhost"}, Subject: "Subject"}).
+		SetError("error").
+		SetAttempts(1).
+		Save(goctx.Background())
+	require.NoError(t, err)
+
+	ctx, _ := tests.NewContext(c.Web, "/")
+	ctx.SetParamNames("mail")
+	ctx.SetParamValues(fmt.Sprintf("%d", fm.ID))
+	_ = tests.ExecuteMiddleware(ctx, LoadFailedMail(c.ORM))
+	ctxMail, ok := ctx.Get(context.FailedMailKey).(*ent.FailedMail)
+	require.True(t, ok)
+	assert.Equal(t, fm.ID, ctxMail.ID)
+	assert.Equal(t, "Subject", ctxMail.Message.Subject)
+
+	ctx, _ = tests.NewContext(c.Web, "/")
+	ctx.SetParamNames("mail")
+	ctx.SetParamValues("0")
+	err = tests.ExecuteMiddleware(ctx, LoadFailedMail(c.ORM))
+	tests.AssertHTTPErrorCode(t, err, http.StatusNotFound)
+}
@@ -3,10 +3,13 @@ package middleware
 import (
 	"fmt"
 
+	"github.com/mikestefanello/pagoda/pkg/audit"
+
 	"github.com/labstack/echo/v4"
 )
 
-// LogRequestID includes the request ID in all logs for the given request
+// LogRequestID includes the request ID in all logs for the given request, and stores it in the request context,
+// along with the IP address and user agent of the client, so it is included in all audit events
 // This requires that middleware that includes the request ID first execute
 func LogRequestID() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -14,6 +17,14 @@ func LogRequestID() echo.MiddlewareFunc {
 			rID := c.Response().Header().Get(echo.HeaderXRequestID)
 			format := `{"time":"${time_rfc3339_nano}","id":"%s","level":"${level}","prefix":"${prefix}","file":"${short_file}","line":"${line}"}`
 			c.Logger().SetHeader(fmt.Sprintf(format, rID))
+
+			ctx := audit.NewContext(c.Request().Context(), audit.Request{
+				ID:        rID,
+				IP:        c.RealIP(),
+				UserAgent: c.Request().UserAgent(),
+			})
+			c.SetRequest(c.Request().WithContext(ctx))
+
 			return next(c)
 		}
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/mikestefanello/pagoda/pkg/audit"
 	"github.com/mikestefanello/pagoda/pkg/tests"
 
 	"github.com/labstack/echo/v4"
@@ -24,4 +25,9 @@ func TestLogRequestID(t *testing.T) {
 	ctx.Logger().Info("test")
 	rID := ctx.Response().Header().Get(echo.HeaderXRequestID)
 	assert.Contains(t, buf.String(), fmt.Sprintf(`id":"%s"`, rID))
+
+	// Verify the request is stored for audit events
+	r := audit.FromContext(ctx.Request().Context())
+	assert.Equal(t, rID, r.ID)
+	assert.Equal(t, ctx.RealIP(), r.IP)
 }
@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/services"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// rateLimitKeyPrefix is the prefix of the cache keys which store the token bucket of each client of each policy
+	rateLimitKeyPrefix = "ratelimit:"
+
+	// HeaderRateLimitLimit is the header which contains the amount of requests allowed by the rate limit
+	HeaderRateLimitLimit = "RateLimit-Limit"
+
+	// HeaderRateLimitRemaining is the header which contains the amount of requests remaining before the client is
+	// rate limited
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+
+	// HeaderRateLimitReset is the header which contains the amount of seconds until the client can make the full
+	// amount of requests again
+	HeaderRateLimitReset = "RateLimit-Reset"
+
+	// HeaderRateLimitPolicy is the header which describes the rate limit policy
+	HeaderRateLimitPolicy = "RateLimit-Policy"
+)
+
+// rateLimitScript takes a token from the bucket of a client, after refilling it based on how long ago a token
+// was last taken, using the time of the cache server so that all instances of the application agree.
+// It returns whether a token was taken, the amount of tokens remaining and the milliseconds until another token
+// is available and until the bucket is full.
+var rateLimitScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(bucket[1]) or limit
+local updated = tonumber(bucket[2]) or now
+tokens = math.min(limit, tokens + math.max(0, now - updated) * limit / period)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", tostring(now))
+redis.call("PEXPIRE", KEYS[1], period)
+
+local retry = 0
+if allowed == 0 then
+	retry = math.ceil((1 - tokens) * period / limit)
+end
+
+return {allowed, math.floor(tokens), retry, math.ceil((limit - tokens) * period / limit)}
+`)
+
+// RateLimit limits requests by a given rate limit policy declared in configuration. Each client has a token
+// bucket, stored in the cache so the limit applies across every instance of the application, which allows bursts
+// of requests up to the limit of the policy and refills steadily over its period.
+// Every response includes RateLimit headers describing the limit, and requests which exceed it are refused with a
+// 429 and a Retry-After header. If the cache cannot be reached, requests are allowed rather than refused.
+func RateLimit(cfg config.RateLimitConfig, ch *services.CacheClient, policy string) echo.MiddlewareFunc {
+	if !cfg.Enabled {
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return next
+		}
+	}
+
+	p, ok := cfg.Policies[policy]
+	if !ok {
+		panic(fmt.Sprintf("rate limit policy %q is not declared in configuration", policy))
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			res, err := rateLimitScript.Run(
+				c.Request().Context(),
+				ch.Client,
+				[]string{rateLimitKey(c, policy, p.Key)},
+				p.Limit,
+				p.Period.Milliseconds(),
+			).Int64Slice()
+
+			if err != nil {
+				if context.IsCanceledError(err) {
+					return nil
+				}
+				c.Logger().Errorf("failed to check rate limit: %v", err)
+				return next(c)
+			}
+
+			allowed, remaining, retry, reset := res[0] == 1, res[1], res[2], res[3]
+
+			h := c.Response().Header()
+			h.Set(HeaderRateLimitLimit, strconv.Itoa(p.Limit))
+			h.Set(HeaderRateLimitRemaining, strconv.FormatInt(remaining, 10))
+			h.Set(HeaderRateLimitReset, strconv.FormatInt(millisecondsToSeconds(reset), 10))
+			h.Set(HeaderRateLimitPolicy, fmt.Sprintf("%d;w=%d", p.Limit, int64(p.Period.Seconds())))
+
+			if !allowed {
+				h.Set(echo.HeaderRetryAfter, strconv.FormatInt(millisecondsToSeconds(retry), 10))
+				return echo.NewHTTPError(http.StatusTooManyRequests, fmt.Sprintf("rate limit exceeded: %s", policy))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// rateLimitKey returns the cache key which stores the token bucket of the client of a request for a given policy
+func rateLimitKey(c echo.Context, policy string, key config.RateLimitKey) string {
+	client := fmt.Sprintf("ip:%s", c.RealIP())
+
+	switch key {
+	case config.RateLimitKeyUser:
+		if u, ok := c.Get(context.AuthenticatedUserKey).(*ent.User); ok {
+			client = fmt.Sprintf("user:%d", u.ID)
+		}
+	}
+
+	return fmt.Sprintf("%s%s:%s", rateLimitKeyPrefix, policy, client)
+}
+
+// millisecondsToSeconds converts milliseconds to seconds, rounding up
+func millisecondsToSeconds(ms int64) int64 {
+	return (ms + 999) / 1000
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	cfg := config.RateLimitConfig{
+		Enabled: true,
+		Policies: map[string]config.RateLimitPolicy{
+			"ip":   {Limit: 2, Period: time.Hour, Key: config.RateLimitKeyIP},
+			"user": {Limit: 1, Period: time.Hour, Key: config.RateLimitKeyUser},
+		},
+	}
+
+	limit := func(policy string, prepare func(echo.Context)) (echo.Context, error) {
+		ctx, _ := tests.NewContext(c.Web, "/")
+		if prepare != nil {
+			prepare(ctx)
+		}
+		return ctx, tests.ExecuteMiddleware(ctx, RateLimit(cfg, c.Cache, policy))
+	}
+
+	// Requests are allowed until the bucket is empty
+	ctx, err := limit("ip", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "2", ctx.Response().Header().Get(HeaderRateLimitLimit))
+	assert.Equal(t, "1", ctx.Response().Header().Get(HeaderRateLimitRemaining))
+	assert.Equal(t, "1800", ctx.Response().Header().Get(HeaderRateLimitReset))
+	assert.Equal(t, "2;w=3600", ctx.Response().Header().Get(HeaderRateLimitPolicy))
+
+	ctx, err = limit("ip", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "0", ctx.Response().Header().Get(HeaderRateLimitRemaining))
+
+	ctx, err = limit("ip", nil)
+	tests.AssertHTTPErrorCode(t, err, http.StatusTooManyRequests)
+	assert.Equal(t, "0", ctx.Response().Header().Get(HeaderRateLimitRemaining))
+	assert.Equal(t, "1800", ctx.Response().Header().Get(echo.HeaderRetryAfter))
+
+	// Other IP addresses have their own bucket
+	_, err = limit("ip", func(ctx echo.Context) {
+		ctx.Request().Header.Set(echo.HeaderXRealIP, "192.0.2.30")
+	})
+	assert.NoError(t, err)
+
+	// Users have their own bucket, regardless of their IP address
+	asUser := func(ctx echo.Context) {
+		ctx.Set(context.AuthenticatedUserKey, usr)
+	}
+	_, err = limit("user", asUser)
+	assert.NoError(t, err)
+	_, err = limit("user", asUser)
+	tests.AssertHTTPErrorCode(t, err, http.StatusTooManyRequests)
+	_, err = limit("user", nil)
+	assert.NoError(t, err)
+
+	// Policies must be declared
+	assert.Panics(t, func() {
+		RateLimit(cfg, c.Cache, "missing")
+	})
+
+	// Nothing is limited when rate limiting is disabled
+	cfg.Enabled = false
+	for i := 0; i < 3; i++ {
+		_, err = limit("user", asUser)
+		assert.NoError(t, err)
+	}
+}
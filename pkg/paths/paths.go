@@ -0,0 +1,39 @@
+// Package paths contains the paths of routes which are linked to from outside of a request, such as in email
+// sent by tasks. The worker does not register the routes so they cannot be reversed by name, which is why the
+// router registers these routes with the same paths.
+package paths
+
+import (
+	"net/url"
+	"strings"
+)
+
+const (
+	// Post is the path of a post, by its slug
+	Post = "/post/:slug"
+
+	// CommentModeration is the path of the page where comments awaiting moderation are approved or marked as spam
+	CommentModeration = "/posts/comments"
+
+	// NewsletterUnsubscribe is the path which unsubscribes from the newsletter, by the unsubscribe token
+	NewsletterUnsubscribe = "/newsletter/unsubscribe/:token"
+)
+
+// Build replaces the parameters of a path, in the order they appear, with the given values, which are escaped
+func Build(path string, params ...string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, ":") || len(params) == 0 {
+			continue
+		}
+		segments[i] = url.PathEscape(params[0])
+		params = params[1:]
+	}
+	return strings.Join(segments, "/")
+}
+
+// Absolute returns the absolute URL of a given path on the public URL of the application, such as
+// config.AppConfig.URL
+func Absolute(appURL, path string) string {
+	return strings.TrimSuffix(appURL, "/") + path
+}
@@ -0,0 +1,19 @@
+package paths
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuild(t *testing.T) {
+	assert.Equal(t, "/post/hello-world", Build(Post, "hello-world"))
+	assert.Equal(t, "/newsletter/unsubscribe/a%2Fb", Build(NewsletterUnsubscribe, "a/b"))
+	assert.Equal(t, "/a/1/b/2", Build("/a/:x/b/:y", "1", "2"))
+	assert.Equal(t, "/a", Build("/a"))
+}
+
+func TestAbsolute(t *testing.T) {
+	assert.Equal(t, "https://example.com/post/a", Absolute("https://example.com/", "/post/a"))
+	assert.Equal(t, "https://example.com/post/a", Absolute("https://example.com", "/post/a"))
+}
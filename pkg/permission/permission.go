@@ -0,0 +1,88 @@
+package permission
+
+import (
+	"context"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/privacy"
+	"github.com/mikestefanello/pagoda/ent/user"
+)
+
+// Permission is an action which users are granted by their role
+type Permission string
+
+const (
+	// CreatePost allows creating posts and editing them, but only saving them as drafts or submitting them for review
+	CreatePost Permission = "create_post"
+
+	// PublishPost allows publishing and scheduling posts
+	PublishPost Permission = "publish_post"
+
+	// EditAnyPost allows editing, publishing and deleting posts by any author
+	EditAnyPost Permission = "edit_any_post"
+
+	// ModerateComments allows moderating comments on the user's own posts
+	ModerateComments Permission = "moderate_comments"
+
+	// ModerateAnyComment allows moderating comments on posts by any author
+	ModerateAnyComment Permission = "moderate_any_comment"
+
+	// UploadMedia allows uploading media to the media library
+	UploadMedia Permission = "upload_media"
+
+	// ManageSite allows access to the admin area
+	ManageSite Permission = "manage_site"
+)
+
+// roles stores the permissions granted to each role. Readers are granted none, and can only comment and manage
+// their own account.
+var roles = map[user.Role][]Permission{
+	user.RoleAdmin: {
+		CreatePost, PublishPost, EditAnyPost, ModerateComments, ModerateAnyComment, UploadMedia, ManageSite,
+	},
+	user.RoleEditor: {
+		CreatePost, PublishPost, EditAnyPost, ModerateComments, ModerateAnyComment, UploadMedia,
+	},
+	user.RoleAuthor: {
+		CreatePost, PublishPost, ModerateComments, UploadMedia,
+	},
+	user.RoleContributor: {
+		CreatePost, UploadMedia,
+	},
+	user.RoleReader: {},
+}
+
+// viewerKey is the context key which stores the user actions are performed on behalf of
+type viewerKey struct{}
+
+// Has determines if a given user has been granted a given permission by their role
+func Has(u *ent.User, p Permission) bool {
+	if u == nil {
+		return false
+	}
+
+	for _, granted := range roles[u.Role] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
+
+// NewContext returns a copy of a given context which stores the user, or viewer, actions are performed on behalf of.
+// The privacy policies of entities use the viewer to determine which mutations are allowed.
+func NewContext(ctx context.Context, u *ent.User) context.Context {
+	return context.WithValue(ctx, viewerKey{}, u)
+}
+
+// FromContext returns the viewer stored in a given context, if one
+func FromContext(ctx context.Context) *ent.User {
+	u, _ := ctx.Value(viewerKey{}).(*ent.User)
+	return u
+}
+
+// SystemContext returns a copy of a given context which bypasses privacy policies. This must only be used for
+// actions the application performs itself, such as tasks, rather than on behalf of a user.
+func SystemContext(ctx context.Context) context.Context {
+	return privacy.DecisionContext(ctx, privacy.Allow)
+}
@@ -0,0 +1,70 @@
+package permission_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+	"github.com/mikestefanello/pagoda/pkg/services"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var c *services.Container
+
+func TestMain(m *testing.M) {
+	// Set the environment to test
+	config.SwitchEnvironment(config.EnvTest)
+
+	// Create a new container
+	c = services.NewContainer()
+
+	// Run tests
+	exitVal := m.Run()
+
+	// Shutdown the container
+	if err := c.Shutdown(); err != nil {
+		panic(err)
+	}
+
+	os.Exit(exitVal)
+}
+
+func TestHas(t *testing.T) {
+	assert.False(t, permission.Has(nil, permission.CreatePost))
+	assert.False(t, permission.Has(&ent.User{Role: user.RoleReader}, permission.CreatePost))
+	assert.True(t, permission.Has(&ent.User{Role: user.RoleContributor}, permission.CreatePost))
+	assert.False(t, permission.Has(&ent.User{Role: user.RoleContributor}, permission.PublishPost))
+	assert.True(t, permission.Has(&ent.User{Role: user.RoleAuthor}, permission.PublishPost))
+	assert.False(t, permission.Has(&ent.User{Role: user.RoleAuthor}, permission.EditAnyPost))
+	assert.True(t, permission.Has(&ent.User{Role: user.RoleEditor}, permission.EditAnyPost))
+	assert.False(t, permission.Has(&ent.User{Role: user.RoleEditor}, permission.ManageSite))
+	assert.True(t, permission.Has(&ent.User{Role: user.RoleAdmin}, permission.ManageSite))
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, permission.FromContext(ctx))
+
+	u := &ent.User{ID: 1}
+	assert.Equal(t, u, permission.FromContext(permission.NewContext(ctx, u)))
+}
+
+// createUser creates a user with a given role, and returns a context acting on their behalf
+func createUser(t *testing.T, role user.Role) (*ent.User, context.Context) {
+	u, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	u, err = u.Update().
+		SetRole(role).
+		Save(context.Background())
+	require.NoError(t, err)
+
+	return u, permission.NewContext(context.Background(), u)
+}
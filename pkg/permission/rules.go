@@ -0,0 +1,151 @@
+package permission
+
+import (
+	"context"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/comment"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/privacy"
+	"github.com/mikestefanello/pagoda/ent/user"
+)
+
+// PostMutationRule allows users who can edit any post to make any change. Otherwise, users who can create posts
+// can only change their own, and can only publish or schedule them, or change when they are published, if they have
+// permission to.
+func PostMutationRule() privacy.MutationRule {
+	return privacy.PostMutationRuleFunc(func(ctx context.Context, m *ent.PostMutation) error {
+		v := FromContext(ctx)
+		switch {
+		case v == nil:
+			return privacy.Denyf("no viewer")
+		case Has(v, EditAnyPost):
+			return privacy.Allow
+		case !Has(v, CreatePost):
+			return privacy.Denyf("viewer cannot create posts")
+		}
+
+		// Posts which were already published or scheduled, by someone permitted to, can still be edited
+		if status, ok := m.Status(); ok && !Has(v, PublishPost) {
+			if status == post.StatusPublished || status == post.StatusScheduled {
+				if m.Op() != ent.OpUpdateOne {
+					return privacy.Denyf("viewer cannot publish posts")
+				}
+
+				old, err := m.OldStatus(ctx)
+				switch {
+				case err != nil:
+					return privacy.Denyf("unable to load post status: %v", err)
+				case old != status:
+					return privacy.Denyf("viewer cannot publish posts")
+				}
+			}
+		}
+
+		// Nor can the time they are published at be changed, which would publish scheduled posts early
+		if publishAt, set := m.PublishAt(); (set || m.PublishAtCleared()) && !Has(v, PublishPost) {
+			switch m.Op() {
+			case ent.OpCreate:
+				// New posts can only be scheduled or published by those permitted to, which is checked above
+			case ent.OpUpdateOne:
+				status, ok := m.Status()
+				if !ok {
+					old, err := m.OldStatus(ctx)
+					if err != nil {
+						return privacy.Denyf("unable to load post status: %v", err)
+					}
+					status = old
+				}
+
+				if status == post.StatusPublished || status == post.StatusScheduled {
+					old, err := m.OldPublishAt(ctx)
+					switch {
+					case err != nil:
+						return privacy.Denyf("unable to load post publish time: %v", err)
+					case (old != nil) != set, old != nil && !old.Equal(publishAt):
+						return privacy.Denyf("viewer cannot change when posts are published")
+					}
+				}
+			default:
+				return privacy.Denyf("viewer cannot change when posts are published")
+			}
+		}
+
+		// Posts cannot be given to another author
+		if id, ok := m.AuthorID(); m.AuthorCleared() || (ok && id != v.ID) {
+			return privacy.Denyf("viewer cannot change the author of posts")
+		}
+
+		own := post.HasAuthorWith(user.ID(v.ID))
+		switch m.Op() {
+		case ent.OpCreate:
+			if _, ok := m.AuthorID(); !ok {
+				return privacy.Denyf("viewer must be the author of posts")
+			}
+		case ent.OpUpdateOne, ent.OpDeleteOne:
+			id, _ := m.ID()
+			exists, err := m.Client().Post.
+				Query().
+				Where(post.ID(id), own).
+				Exist(ctx)
+
+			switch {
+			case err != nil:
+				return privacy.Denyf("unable to query post: %v", err)
+			case !exists:
+				return privacy.Denyf("viewer is not the author of the post")
+			}
+		default:
+			// Limit changes to many posts to the viewer's own
+			m.Where(own)
+		}
+
+		return privacy.Allow
+	})
+}
+
+// CommentMutationRule allows anyone, including guests, to comment as themselves. Comments can only be changed by
+// users who can moderate comments on any post, or users who can moderate comments on their own posts.
+func CommentMutationRule() privacy.MutationRule {
+	return privacy.CommentMutationRuleFunc(func(ctx context.Context, m *ent.CommentMutation) error {
+		v := FromContext(ctx)
+
+		if m.Op() == ent.OpCreate {
+			if id, ok := m.AuthorID(); ok && (v == nil || id != v.ID) {
+				return privacy.Denyf("viewer can only comment as themselves")
+			}
+			return privacy.Allow
+		}
+
+		switch {
+		case v == nil:
+			return privacy.Denyf("no viewer")
+		case Has(v, ModerateAnyComment):
+			return privacy.Allow
+		case !Has(v, ModerateComments):
+			return privacy.Denyf("viewer cannot moderate comments")
+		}
+
+		own := comment.HasPostWith(post.HasAuthorWith(user.ID(v.ID)))
+		switch m.Op() {
+		case ent.OpUpdateOne, ent.OpDeleteOne:
+			id, _ := m.ID()
+			exists, err := m.Client().Comment.
+				Query().
+				Where(comment.ID(id), own).
+				Exist(ctx)
+
+			switch {
+			case err != nil:
+				return privacy.Denyf("unable to query comment: %v", err)
+			case !exists:
+				return privacy.Denyf("viewer is not the author of the post")
+			}
+		default:
+			// Limit changes to many comments to those on the viewer's own posts
+			m.Where(own)
+		}
+
+		return privacy.Allow
+	})
+}
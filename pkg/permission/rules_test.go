@@ -0,0 +1,154 @@
+package permission_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent/comment"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/privacy"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostMutationRule(t *testing.T) {
+	_, readerCtx := createUser(t, user.RoleReader)
+	contributor, contributorCtx := createUser(t, user.RoleContributor)
+	author, authorCtx := createUser(t, user.RoleAuthor)
+	_, editorCtx := createUser(t, user.RoleEditor)
+
+	create := func(ctx context.Context, authorID int, status post.Status) error {
+		return c.ORM.Post.
+			Create().
+			SetTitle(fmt.Sprintf("Title %d", rand.Int())).
+			SetBody("Body").
+			SetStatus(status).
+			SetAuthorID(authorID).
+			Exec(ctx)
+	}
+
+	// Users must be acting on behalf of someone
+	err := create(context.Background(), author.ID, post.StatusDraft)
+	assert.ErrorIs(t, err, privacy.Deny)
+
+	// Readers cannot create posts
+	err = create(readerCtx, author.ID, post.StatusDraft)
+	assert.ErrorIs(t, err, privacy.Deny)
+
+	// Contributors can create posts, but not publish them or create them for someone else
+	assert.NoError(t, create(contributorCtx, contributor.ID, post.StatusInReview))
+	err = create(contributorCtx, contributor.ID, post.StatusPublished)
+	assert.ErrorIs(t, err, privacy.Deny)
+	err = create(contributorCtx, author.ID, post.StatusDraft)
+	assert.ErrorIs(t, err, privacy.Deny)
+
+	// Authors can publish their own posts
+	assert.NoError(t, create(authorCtx, author.ID, post.StatusPublished))
+
+	p, err := tests.CreatePost(c.ORM, author)
+	require.NoError(t, err)
+
+	// But not edit or delete those of someone else
+	err = p.Update().SetTitle("Contributor").Exec(contributorCtx)
+	assert.ErrorIs(t, err, privacy.Deny)
+	err = c.ORM.Post.DeleteOne(p).Exec(contributorCtx)
+	assert.ErrorIs(t, err, privacy.Deny)
+
+	// Nor give their own to someone else
+	err = p.Update().SetAuthorID(contributor.ID).Exec(authorCtx)
+	assert.ErrorIs(t, err, privacy.Deny)
+
+	assert.NoError(t, p.Update().SetTitle("Author").Exec(authorCtx))
+
+	// Changes to many posts only change the user's own
+	count, err := c.ORM.Post.
+		Update().
+		Where(post.ID(p.ID)).
+		SetTitle("Contributor").
+		Save(contributorCtx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// Editors can edit any post
+	assert.NoError(t, p.Update().SetTitle("Editor").Exec(editorCtx))
+
+	// The application can act for itself
+	assert.NoError(t, p.Update().SetTitle("System").Exec(permission.SystemContext(context.Background())))
+
+	// Contributors can edit their own posts which someone else published, but not publish them again
+	cp, err := tests.CreatePost(c.ORM, contributor)
+	require.NoError(t, err)
+	assert.NoError(t, cp.Update().SetTitle("Contributor").SetStatus(post.StatusPublished).Exec(contributorCtx))
+	assert.NoError(t, cp.Update().SetStatus(post.StatusDraft).Exec(contributorCtx))
+	err = cp.Update().SetStatus(post.StatusPublished).Exec(contributorCtx)
+	assert.ErrorIs(t, err, privacy.Deny)
+
+	// Nor change when posts an editor scheduled are published
+	publishAt := time.Now().Add(7 * 24 * time.Hour).Truncate(time.Minute)
+	assert.NoError(t, cp.Update().SetStatus(post.StatusScheduled).SetPublishAt(publishAt).Exec(editorCtx))
+	assert.NoError(t, cp.Update().SetTitle("Scheduled").SetPublishAt(publishAt).Exec(contributorCtx))
+	err = cp.Update().SetPublishAt(time.Now()).Exec(contributorCtx)
+	assert.ErrorIs(t, err, privacy.Deny)
+	err = cp.Update().ClearPublishAt().Exec(contributorCtx)
+	assert.ErrorIs(t, err, privacy.Deny)
+	assert.NoError(t, cp.Update().SetStatus(post.StatusDraft).ClearPublishAt().Exec(contributorCtx))
+
+	assert.NoError(t, c.ORM.Post.DeleteOne(p).Exec(authorCtx))
+}
+
+func TestCommentMutationRule(t *testing.T) {
+	reader, readerCtx := createUser(t, user.RoleReader)
+	author, authorCtx := createUser(t, user.RoleAuthor)
+	_, otherAuthorCtx := createUser(t, user.RoleAuthor)
+	_, editorCtx := createUser(t, user.RoleEditor)
+
+	p, err := tests.CreatePost(c.ORM, author)
+	require.NoError(t, err)
+
+	// Guests can comment
+	cm, err := tests.CreateComment(c.ORM, p)
+	require.NoError(t, err)
+
+	// Users can only comment as themselves
+	create := func(ctx context.Context) error {
+		return c.ORM.Comment.
+			Create().
+			SetPost(p).
+			SetBody("Body").
+			SetAuthor(reader).
+			Exec(ctx)
+	}
+
+	err = create(context.Background())
+	assert.ErrorIs(t, err, privacy.Deny)
+	err = create(authorCtx)
+	assert.ErrorIs(t, err, privacy.Deny)
+	assert.NoError(t, create(readerCtx))
+
+	// Only those who can moderate the comments on the post can change them
+	err = cm.Update().SetStatus(comment.StatusApproved).Exec(context.Background())
+	assert.ErrorIs(t, err, privacy.Deny)
+	err = cm.Update().SetStatus(comment.StatusApproved).Exec(readerCtx)
+	assert.ErrorIs(t, err, privacy.Deny)
+	err = cm.Update().SetStatus(comment.StatusApproved).Exec(otherAuthorCtx)
+	assert.ErrorIs(t, err, privacy.Deny)
+	assert.NoError(t, cm.Update().SetStatus(comment.StatusApproved).Exec(authorCtx))
+	assert.NoError(t, cm.Update().SetStatus(comment.StatusSpam).Exec(editorCtx))
+
+	// Changes to many comments only change those on the user's own posts
+	count, err := c.ORM.Comment.
+		Delete().
+		Where(comment.ID(cm.ID)).
+		Exec(otherAuthorCtx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	assert.NoError(t, c.ORM.Comment.DeleteOne(cm).Exec(authorCtx))
+}
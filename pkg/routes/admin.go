@@ -0,0 +1,67 @@
+package routes
+
+import (
+	"github.com/mikestefanello/pagoda/ent/comment"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	adminDashboard struct {
+		controller.Controller
+	}
+
+	adminDashboardData struct {
+		Users           int
+		BannedUsers     int
+		Posts           int
+		PostsInReview   int
+		PendingComments int
+		FailedMail      int
+	}
+)
+
+func (c *adminDashboard) Get(ctx echo.Context) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageAdmin
+	page.Title = "Admin"
+
+	var (
+		data adminDashboardData
+		err  error
+		rctx = ctx.Request().Context()
+	)
+
+	if data.Users, err = c.Container.ORM.User.Query().Count(rctx); err != nil {
+		return c.Fail(err, "unable to count users")
+	}
+
+	if data.BannedUsers, err = c.Container.ORM.User.Query().Where(user.BannedAtNotNil()).Count(rctx); err != nil {
+		return c.Fail(err, "unable to count banned users")
+	}
+
+	if data.Posts, err = c.Container.ORM.Post.Query().Count(rctx); err != nil {
+		return c.Fail(err, "unable to count posts")
+	}
+
+	if data.PostsInReview, err = c.Container.ORM.Post.Query().Where(post.StatusEQ(post.StatusInReview)).Count(rctx); err != nil {
+		return c.Fail(err, "unable to count posts in review")
+	}
+
+	if data.PendingComments, err = c.Container.ORM.Comment.Query().Where(comment.StatusEQ(comment.StatusPending)).Count(rctx); err != nil {
+		return c.Fail(err, "unable to count pending comments")
+	}
+
+	if data.FailedMail, err = c.Container.ORM.FailedMail.Query().Count(rctx); err != nil {
+		return c.Fail(err, "unable to count failed mail")
+	}
+
+	page.Data = data
+
+	return c.RenderPage(ctx, page)
+}
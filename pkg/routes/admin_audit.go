@@ -0,0 +1,213 @@
+package routes
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/auditevent"
+	"github.com/mikestefanello/pagoda/ent/predicate"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/audit"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// adminAuditDateLayout is the layout of the dates audit events can be filtered by
+	adminAuditDateLayout = "2006-01-02"
+
+	// adminAuditExportBatchSize is the amount of audit events loaded at a time while exporting
+	adminAuditExportBatchSize = 500
+)
+
+type (
+	adminAudit struct {
+		controller.Controller
+	}
+
+	adminAuditData struct {
+		Filter  adminAuditFilter
+		Actions []audit.Action
+		Events  []*ent.AuditEvent
+	}
+
+	adminAuditFilter struct {
+		Action string `query:"action"`
+		User   string `query:"user"`
+		From   string `query:"from"`
+		To     string `query:"to"`
+	}
+)
+
+func (c *adminAudit) Get(ctx echo.Context) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageAdminAudit
+	page.Title = "Audit log"
+	page.Pager = controller.NewPager(ctx, controller.DefaultItemsPerPage)
+
+	var filter adminAuditFilter
+	if err := ctx.Bind(&filter); err != nil {
+		return c.Fail(err, "unable to parse audit log filter")
+	}
+
+	query := c.Container.ORM.AuditEvent.
+		Query().
+		Where(filter.predicates()...)
+
+	count, err := query.Clone().Count(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to count audit events")
+	}
+	page.Pager.SetItems(count)
+
+	events, err := query.
+		WithUser().
+		Order(ent.Desc(auditevent.FieldCreatedAt), ent.Desc(auditevent.FieldID)).
+		Offset(page.Pager.GetOffset()).
+		Limit(page.Pager.ItemsPerPage).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to query audit events")
+	}
+
+	page.Data = adminAuditData{
+		Filter:  filter,
+		Actions: audit.Actions,
+		Events:  events,
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+// Export writes the audit events which match the filter as CSV
+func (c *adminAudit) Export(ctx echo.Context) error {
+	var filter adminAuditFilter
+	if err := ctx.Bind(&filter); err != nil {
+		return c.Fail(err, "unable to parse audit log filter")
+	}
+
+	ctx.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	ctx.Response().Header().Set(
+		echo.HeaderContentDisposition,
+		fmt.Sprintf(`attachment; filename="audit-%s.csv"`, time.Now().Format(adminAuditDateLayout)),
+	)
+	ctx.Response().WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(ctx.Response())
+	err := w.Write([]string{
+		"id", "time", "action", "user_id", "user_email", "subject_type", "subject_id", "details", "request_id", "ip",
+		"user_agent",
+	})
+	if err != nil {
+		return err
+	}
+
+	// Load the events in batches, newest first, so the entire log is not held in memory. Batches start after the
+	// last event of the previous batch, rather than at an offset, so events recorded meanwhile are not repeated.
+	for lastID := 0; ; {
+		query := c.Container.ORM.AuditEvent.
+			Query().
+			Where(filter.predicates()...)
+
+		if lastID != 0 {
+			query.Where(auditevent.IDLT(lastID))
+		}
+
+		events, err := query.
+			WithUser().
+			Order(ent.Desc(auditevent.FieldID)).
+			Limit(adminAuditExportBatchSize).
+			All(ctx.Request().Context())
+
+		if err != nil {
+			// The response has already been started, so the error can only be logged
+			ctx.Logger().Errorf("unable to query audit events: %v", err)
+			return nil
+		}
+
+		for _, e := range events {
+			lastID = e.ID
+
+			var userID, userEmail, subjectID string
+			if e.Edges.User != nil {
+				userID = strconv.Itoa(e.Edges.User.ID)
+				userEmail = e.Edges.User.Email
+			}
+			if e.SubjectID != nil {
+				subjectID = strconv.Itoa(*e.SubjectID)
+			}
+
+			err = w.Write([]string{
+				strconv.Itoa(e.ID),
+				e.CreatedAt.UTC().Format(time.RFC3339),
+				csvCell(e.Action),
+				userID,
+				csvCell(userEmail),
+				csvCell(e.SubjectType),
+				subjectID,
+				csvCell(e.Details),
+				csvCell(e.RequestID),
+				csvCell(e.IP),
+				csvCell(e.UserAgent),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		w.Flush()
+		if err = w.Error(); err != nil {
+			return err
+		}
+
+		if len(events) < adminAuditExportBatchSize {
+			return nil
+		}
+	}
+}
+
+// csvCell returns a value to write to a CSV cell, prefixed with a quote if it starts with a character which
+// spreadsheets would otherwise evaluate as a formula, since values such as the user agent are provided by anyone
+func csvCell(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+// predicates returns the predicates which match the audit events the filter applies to
+func (f adminAuditFilter) predicates() []predicate.AuditEvent {
+	predicates := make([]predicate.AuditEvent, 0)
+
+	if f.Action != "" {
+		predicates = append(predicates, auditevent.Action(f.Action))
+	}
+
+	if f.User != "" {
+		if id, err := strconv.Atoi(f.User); err == nil {
+			predicates = append(predicates, auditevent.HasUserWith(user.ID(id)))
+		} else {
+			predicates = append(predicates, auditevent.HasUserWith(user.EmailContainsFold(f.User)))
+		}
+	}
+
+	if from, err := time.ParseInLocation(adminAuditDateLayout, f.From, time.Local); err == nil {
+		predicates = append(predicates, auditevent.CreatedAtGTE(from))
+	}
+
+	// The end date is inclusive
+	if to, err := time.ParseInLocation(adminAuditDateLayout, f.To, time.Local); err == nil {
+		predicates = append(predicates, auditevent.CreatedAtLT(to.AddDate(0, 0, 1)))
+	}
+
+	return predicates
+}
@@ -0,0 +1,97 @@
+package routes
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/pkg/audit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVCell(t *testing.T) {
+	for in, out := range map[string]string{
+		"":                         "",
+		"login":                    "login",
+		"a=b":                      "a=b",
+		`=HYPERLINK("x")`:          `'=HYPERLINK("x")`,
+		"+1":                       "'+1",
+		"-1":                       "'-1",
+		"@SUM(A1)":                 "'@SUM(A1)",
+		"\t=cmd":                   "'\t=cmd",
+		"\r=cmd":                   "'\r=cmd",
+		"Mozilla/5.0 (X11; Linux)": "Mozilla/5.0 (X11; Linux)",
+	} {
+		assert.Equal(t, out, csvCell(in), in)
+	}
+}
+
+func TestAdminAudit_Export(t *testing.T) {
+	admin := createAdmin(t)
+	usr := createUser(t)
+
+	// Enough events for more than one batch
+	builders := make([]*ent.AuditEventCreate, adminAuditExportBatchSize+1)
+	for i := range builders {
+		builders[i] = c.ORM.AuditEvent.
+			Create().
+			SetAction(string(audit.Logout)).
+			SetUser(usr)
+	}
+	_, err := c.ORM.AuditEvent.CreateBulk(builders...).Save(context.Background())
+	require.NoError(t, err)
+
+	// Anyone can provide the email of a failed login and their user agent
+	_, err = c.ORM.AuditEvent.
+		Create().
+		SetAction(string(audit.LoginFailed)).
+		SetUser(usr).
+		SetDetails(`=HYPERLINK("https://example.com")`).
+		SetUserAgent("=cmd|' /C calc'!A0").
+		Save(context.Background())
+	require.NoError(t, err)
+
+	export := func(filter string) [][]string {
+		resp := request(t).
+			login(admin).
+			getURL(srv.URL + c.Web.Reverse(routeNameAdminAuditExport) + "?" + filter).
+			assertStatusCode(http.StatusOK)
+		defer resp.Body.Close()
+
+		assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+		rows, err := csv.NewReader(resp.Body).ReadAll()
+		require.NoError(t, err)
+		require.NotEmpty(t, rows)
+		assert.Equal(t, "id", rows[0][0])
+		return rows[1:]
+	}
+
+	// Filtering by user includes every batch, newest first, without repeating any events
+	rows := export("user=" + strconv.Itoa(usr.ID))
+	require.Len(t, rows, adminAuditExportBatchSize+2)
+	seen := make(map[string]bool, len(rows))
+	for i, row := range rows {
+		assert.False(t, seen[row[0]])
+		seen[row[0]] = true
+		assert.Equal(t, strconv.Itoa(usr.ID), row[3])
+		assert.Equal(t, usr.Email, row[4])
+
+		if i > 0 {
+			prev, _ := strconv.Atoi(rows[i-1][0])
+			id, _ := strconv.Atoi(row[0])
+			assert.Less(t, id, prev)
+		}
+	}
+
+	// Values which would be evaluated as formulas are escaped
+	rows = export("action=" + string(audit.LoginFailed) + "&user=" + strconv.Itoa(usr.ID))
+	require.Len(t, rows, 1)
+	assert.Equal(t, string(audit.LoginFailed), rows[0][2])
+	assert.Equal(t, `'=HYPERLINK("https://example.com")`, rows[0][7])
+	assert.Equal(t, "'=cmd|' /C calc'!A0", rows[0][10])
+}
@@ -0,0 +1,99 @@
+package routes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mikestefanello/pagoda/pkg/audit"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/middleware"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/services"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	adminCache struct {
+		controller.Controller
+	}
+
+	adminCacheData struct {
+		Groups []string
+		Tags   []string
+	}
+
+	adminCacheForm struct {
+		Group      string `form:"group"`
+		Tags       string `form:"tags"`
+		Submission controller.FormSubmission
+	}
+)
+
+func (c *adminCache) Get(ctx echo.Context) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageAdminCache
+	page.Title = "Cache"
+	page.Form = adminCacheForm{}
+	page.Data = adminCacheData{
+		Groups: []string{middleware.CachedPageGroup, services.MarkdownCacheGroup},
+		Tags:   []string{services.CacheTagTags, services.CacheTagFeeds},
+	}
+
+	if form := ctx.Get(context.FormKey); form != nil {
+		page.Form = form.(*adminCacheForm)
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *adminCache) Post(ctx echo.Context) error {
+	var form adminCacheForm
+	ctx.Set(context.FormKey, &form)
+
+	// Parse the form values
+	if err := ctx.Bind(&form); err != nil {
+		return c.Fail(err, "unable to parse cache form")
+	}
+
+	if err := form.Submission.Process(ctx, form); err != nil {
+		return c.Fail(err, "unable to process form submission")
+	}
+
+	tags := make([]string, 0)
+	for _, tag := range strings.Split(form.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	if form.Group == "" && len(tags) == 0 {
+		form.Submission.SetFieldError("Group", "Enter a group or tags to flush.")
+		return c.Get(ctx)
+	}
+
+	err := c.Container.Cache.
+		Flush().
+		Group(strings.TrimSpace(form.Group)).
+		Tags(tags...).
+		Execute(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to flush cache")
+	}
+
+	ctx.Logger().Infof("cache flushed: group %q, tags %v", form.Group, tags)
+
+	err = audit.Record(ctx.Request().Context(), c.Container.ORM, audit.Event{
+		Action:  audit.CacheFlushed,
+		Details: fmt.Sprintf("Group: %s, tags: %s", form.Group, strings.Join(tags, ", ")),
+	})
+	if err != nil {
+		return c.Fail(err, "unable to record audit event")
+	}
+	msg.Success(ctx, "The cache has been flushed.")
+	return c.Redirect(ctx, routeNameAdminCache)
+}
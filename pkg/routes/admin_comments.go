@@ -0,0 +1,152 @@
+package routes
+
+import (
+	"fmt"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/comment"
+	"github.com/mikestefanello/pagoda/pkg/audit"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	adminComments struct {
+		controller.Controller
+	}
+
+	adminCommentsData struct {
+		Status   string
+		Statuses []comment.Status
+		Sort     controller.Sorter
+		Comments []*ent.Comment
+	}
+
+	adminCommentsForm struct {
+		IDs        []int  `form:"ids" validate:"required"`
+		Action     string `form:"action" validate:"required,oneof=approve spam delete"`
+		Submission controller.FormSubmission
+	}
+)
+
+func (c *adminComments) Get(ctx echo.Context) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageAdminComments
+	page.Title = "Comments"
+	page.Pager = controller.NewPager(ctx, controller.DefaultItemsPerPage)
+
+	sort := controller.NewSorter(
+		ctx,
+		[]string{comment.FieldCreatedAt},
+		comment.FieldCreatedAt,
+		true,
+	)
+
+	// Default to the queue of comments awaiting moderation
+	status := comment.Status(ctx.QueryParam("status"))
+	if comment.StatusValidator(status) != nil {
+		status = comment.StatusPending
+	}
+
+	query := c.Container.ORM.Comment.
+		Query().
+		Where(comment.StatusEQ(status))
+
+	count, err := query.Clone().Count(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to count comments")
+	}
+	page.Pager.SetItems(count)
+
+	comments, err := query.
+		WithPost().
+		WithAuthor().
+		Order(sort.Order(), ent.Asc(comment.FieldID)).
+		Offset(page.Pager.GetOffset()).
+		Limit(page.Pager.ItemsPerPage).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to query comments")
+	}
+
+	page.Data = adminCommentsData{
+		Status:   string(status),
+		Statuses: []comment.Status{comment.StatusPending, comment.StatusApproved, comment.StatusSpam},
+		Sort:     sort,
+		Comments: comments,
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *adminComments) Post(ctx echo.Context) error {
+	var form adminCommentsForm
+
+	// Parse the form values
+	if err := ctx.Bind(&form); err != nil {
+		return c.Fail(err, "unable to parse comment moderation form")
+	}
+
+	if err := form.Submission.Process(ctx, form); err != nil {
+		return c.Fail(err, "unable to process form submission")
+	}
+
+	if form.Submission.HasErrors() {
+		msg.Warning(ctx, "Select at least one comment and an action.")
+		return c.Redirect(ctx, routeNameAdminComments)
+	}
+
+	var (
+		count int
+		err   error
+	)
+	switch form.Action {
+	case "approve":
+		count, err = c.Container.ORM.Comment.
+			Update().
+			Where(comment.IDIn(form.IDs...)).
+			SetStatus(comment.StatusApproved).
+			Save(ctx.Request().Context())
+	case "spam":
+		count, err = c.Container.ORM.Comment.
+			Update().
+			Where(comment.IDIn(form.IDs...)).
+			SetStatus(comment.StatusSpam).
+			Save(ctx.Request().Context())
+	case "delete":
+		count, err = c.Container.ORM.Comment.
+			Delete().
+			Where(comment.IDIn(form.IDs...)).
+			Exec(ctx.Request().Context())
+	}
+
+	if err != nil {
+		return c.Fail(err, "unable to moderate comments")
+	}
+
+	ctx.Logger().Infof("comments moderated: %v, %s", form.IDs, form.Action)
+
+	err = audit.Record(ctx.Request().Context(), c.Container.ORM, audit.Event{
+		Action:  audit.CommentsModerated,
+		Details: fmt.Sprintf("%s: %v", form.Action, form.IDs),
+	})
+	if err != nil {
+		return c.Fail(err, "unable to record audit event")
+	}
+
+	switch form.Action {
+	case "approve":
+		msg.Success(ctx, fmt.Sprintf("%d comment(s) approved.", count))
+	case "spam":
+		msg.Warning(ctx, fmt.Sprintf("%d comment(s) marked as spam.", count))
+	case "delete":
+		msg.Danger(ctx, fmt.Sprintf("%d comment(s) deleted.", count))
+	}
+
+	return c.Redirect(ctx, routeNameAdminComments)
+}
@@ -0,0 +1,129 @@
+package routes
+
+import (
+	"fmt"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/failedmail"
+	"github.com/mikestefanello/pagoda/pkg/audit"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	adminMail struct {
+		controller.Controller
+	}
+
+	adminMailRetry struct {
+		controller.Controller
+	}
+
+	adminMailDelete struct {
+		controller.Controller
+	}
+)
+
+func (c *adminMail) Get(ctx echo.Context) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageAdminMail
+	page.Title = "Failed mail"
+	page.Pager = controller.NewPager(ctx, controller.DefaultItemsPerPage)
+
+	count, err := c.Container.ORM.FailedMail.
+		Query().
+		Count(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to count failed mail")
+	}
+	page.Pager.SetItems(count)
+
+	mails, err := c.Container.ORM.FailedMail.
+		Query().
+		Order(ent.Desc(failedmail.FieldCreatedAt), ent.Desc(failedmail.FieldID)).
+		Offset(page.Pager.GetOffset()).
+		Limit(page.Pager.ItemsPerPage).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to query failed mail")
+	}
+	page.Data = mails
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *adminMail) View(ctx echo.Context) error {
+	fm := ctx.Get(context.FailedMailKey).(*ent.FailedMail)
+
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageAdminMailView
+	page.Title = fm.Subject
+	page.Data = fm
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *adminMailRetry) Post(ctx echo.Context) error {
+	fm := ctx.Get(context.FailedMailKey).(*ent.FailedMail)
+
+	if err := c.Container.Mail.Queue(fm.Message); err != nil {
+		return c.Fail(err, "unable to queue mail")
+	}
+
+	// The email will be stored again if it fails
+	err := c.Container.ORM.FailedMail.
+		DeleteOne(fm).
+		Exec(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to delete failed mail")
+	}
+
+	ctx.Logger().Infof("failed mail queued for retry: %d", fm.ID)
+
+	err = audit.Record(ctx.Request().Context(), c.Container.ORM, audit.Event{
+		Action:      audit.MailRetried,
+		SubjectType: failedmail.Label,
+		SubjectID:   fm.ID,
+		Details:     fmt.Sprintf("%s to %s", fm.Subject, fm.To),
+	})
+	if err != nil {
+		return c.Fail(err, "unable to record audit event")
+	}
+	msg.Success(ctx, fmt.Sprintf("The email to %s has been queued.", fm.To))
+	return c.Redirect(ctx, routeNameAdminMail)
+}
+
+func (c *adminMailDelete) Post(ctx echo.Context) error {
+	fm := ctx.Get(context.FailedMailKey).(*ent.FailedMail)
+
+	err := c.Container.ORM.FailedMail.
+		DeleteOne(fm).
+		Exec(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to delete failed mail")
+	}
+
+	ctx.Logger().Infof("failed mail deleted: %d", fm.ID)
+
+	err = audit.Record(ctx.Request().Context(), c.Container.ORM, audit.Event{
+		Action:      audit.MailDeleted,
+		SubjectType: failedmail.Label,
+		SubjectID:   fm.ID,
+		Details:     fmt.Sprintf("%s to %s", fm.Subject, fm.To),
+	})
+	if err != nil {
+		return c.Fail(err, "unable to record audit event")
+	}
+	msg.Success(ctx, fmt.Sprintf("The email to %s has been deleted.", fm.To))
+	return c.Redirect(ctx, routeNameAdminMail)
+}
@@ -0,0 +1,96 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/mailer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminMail_RequiresAdmin(t *testing.T) {
+	request(t).
+		setRoute(routeNameAdminMail).
+		get().
+		assertStatusCode(http.StatusUnauthorized)
+
+	fm := createFailedMail(t)
+	request(t).
+		login(createUser(t)).
+		setRoute(routeNameAdminMailRetry, fm.ID).
+		postFrom(routeNameContact).
+		assertStatusCode(http.StatusForbidden)
+
+	_, err := c.ORM.FailedMail.Get(context.Background(), fm.ID)
+	assert.NoError(t, err)
+}
+
+func TestAdminMail_Retry(t *testing.T) {
+	fm := createFailedMail(t)
+
+	req := request(t).login(createAdmin(t))
+
+	req.setRoute(routeNameAdminMail).
+		get().
+		assertStatusCode(http.StatusOK)
+
+	// The email is queued to be sent again and is no longer stored as failed
+	req.setRoute(routeNameAdminMailRetry, fm.ID).
+		postFrom(routeNameAdminMailView, fm.ID).
+		assertStatusCode(http.StatusOK)
+
+	_, err := c.ORM.FailedMail.Get(context.Background(), fm.ID)
+	assert.True(t, ent.IsNotFound(err))
+
+	messages := queuedMail(t, fm.Message.To[0])
+	require.Len(t, messages, 1)
+	assert.Equal(t, fm.Message.Subject, messages[0].Subject)
+	assert.Equal(t, fm.Message.Text, messages[0].Text)
+}
+
+func TestAdminMail_Delete(t *testing.T) {
+	fm := createFailedMail(t)
+
+	request(t).
+		login(createAdmin(t)).
+		setRoute(routeNameAdminMailDelete, fm.ID).
+		postFrom(routeNameAdminMailView, fm.ID).
+		assertStatusCode(http.StatusOK)
+
+	_, err := c.ORM.FailedMail.Get(context.Background(), fm.ID)
+	assert.True(t, ent.IsNotFound(err))
+	assert.Empty(t, queuedMail(t, fm.Message.To[0]))
+}
+
+// createAdmin creates a user, which can log in, with the admin role
+func createAdmin(t *testing.T) *ent.User {
+	return createUserWithRole(t, user.RoleAdmin)
+}
+
+// createFailedMail creates a failed mail entity of a message sent to a random address
+func createFailedMail(t *testing.T) *ent.FailedMail {
+	msg := &mailer.Message{
+		From:    c.Config.Mail.FromAddress,
+		To:      []string{fmt.Sprintf("failed-%d@localhost.localhost", time.Now().UnixNano())},
+		Subject: "Failed",
+		Text:    "Hello",
+	}
+
+	fm, err := c.ORM.FailedMail.
+		Create().
+		SetTo(msg.To[0]).
+		SetSubject(msg.Subject).
+		SetMessage(msg).
+		SetError("connection refused").
+		SetAttempts(3).
+		Save(context.Background())
+	require.NoError(t, err)
+	return fm
+}
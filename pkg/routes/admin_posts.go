@@ -0,0 +1,80 @@
+package routes
+
+import (
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	adminPosts struct {
+		controller.Controller
+	}
+
+	adminPostsData struct {
+		Status   string
+		Statuses []post.Status
+		Sort     controller.Sorter
+		Posts    []*ent.Post
+	}
+)
+
+func (c *adminPosts) Get(ctx echo.Context) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageAdminPosts
+	page.Title = "Posts"
+	page.Pager = controller.NewPager(ctx, controller.DefaultItemsPerPage)
+
+	sort := controller.NewSorter(
+		ctx,
+		[]string{post.FieldTitle, post.FieldStatus, post.FieldPublishedAt, post.FieldCreatedAt},
+		post.FieldCreatedAt,
+		true,
+	)
+
+	query := c.Container.ORM.Post.Query()
+
+	// Show posts of all statuses unless one is requested
+	status := post.Status(ctx.QueryParam("status"))
+	if post.StatusValidator(status) == nil {
+		query.Where(post.StatusEQ(status))
+	} else {
+		status = ""
+	}
+
+	count, err := query.Clone().Count(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to count posts")
+	}
+	page.Pager.SetItems(count)
+
+	posts, err := query.
+		WithAuthor().
+		Order(sort.Order(), ent.Asc(post.FieldID)).
+		Offset(page.Pager.GetOffset()).
+		Limit(page.Pager.ItemsPerPage).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to query posts")
+	}
+
+	page.Data = adminPostsData{
+		Status: string(status),
+		Statuses: []post.Status{
+			post.StatusDraft,
+			post.StatusInReview,
+			post.StatusScheduled,
+			post.StatusPublished,
+			post.StatusArchived,
+		},
+		Sort:  sort,
+		Posts: posts,
+	}
+
+	return c.RenderPage(ctx, page)
+}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type adminTasks struct {
+	controller.Controller
+}
+
+func (c *adminTasks) Get(ctx echo.Context) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageAdminTasks
+	page.Title = "Task queues"
+
+	queues, err := c.Container.Tasks.Queues()
+	if err != nil {
+		return c.Fail(err, "unable to load task queues")
+	}
+	page.Data = queues
+
+	return c.RenderPage(ctx, page)
+}
@@ -0,0 +1,273 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/auditevent"
+	"github.com/mikestefanello/pagoda/ent/comment"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/audit"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmin_RequiresAdmin(t *testing.T) {
+	usr, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	routes := []struct {
+		name   string
+		params []any
+	}{
+		{name: routeNameAdmin},
+		{name: routeNameAdminUsers},
+		{name: routeNameAdminUserEdit, params: []any{usr.ID}},
+		{name: routeNameAdminPosts},
+		{name: routeNameAdminComments},
+		{name: routeNameAdminTasks},
+		{name: routeNameAdminCache},
+		{name: routeNameAdminAudit},
+		{name: routeNameAdminAuditExport},
+	}
+
+	for _, route := range routes {
+		request(t).
+			setRoute(route.name, route.params...).
+			get().
+			assertStatusCode(http.StatusUnauthorized)
+	}
+
+	// Users without the manage_site permission are forbidden, even editors
+	req := request(t).login(createUserWithRole(t, user.RoleEditor))
+	for _, route := range routes {
+		req.setRoute(route.name, route.params...).
+			get().
+			assertStatusCode(http.StatusForbidden)
+	}
+}
+
+func TestAdminUserEdit(t *testing.T) {
+	admin := createAdmin(t)
+	usr := createUser(t)
+	other := createUser(t)
+
+	req := request(t).
+		noRedirects().
+		login(admin)
+
+	edit := func(u *ent.User, email string, role user.Role) *httpResponse {
+		return req.setRoute(routeNameAdminUserEditSubmit, u.ID).
+			setBody(url.Values{
+				"name":  []string{u.Name},
+				"email": []string{email},
+				"role":  []string{string(role)},
+			}).
+			postFrom(routeNameAdminUserEdit, u.ID)
+	}
+
+	// Changing only the role is recorded once
+	edit(usr, usr.Email, user.RoleEditor).
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameAdminUsers)
+
+	updated, err := c.ORM.User.Get(context.Background(), usr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, user.RoleEditor, updated.Role)
+	assert.Equal(t, 1, countUserEvents(t, usr, audit.RoleChanged))
+	assert.Zero(t, countUserEvents(t, usr, audit.UserUpdated))
+
+	// Email addresses must be unique
+	doc := edit(usr, other.Email, user.RoleEditor).
+		assertStatusCode(http.StatusOK).
+		toDoc()
+	assert.Equal(t, 1, doc.Find(`input[name="email"].is-danger`).Length())
+
+	updated, err = c.ORM.User.Get(context.Background(), usr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, usr.Email, updated.Email)
+
+	// Admins cannot remove their own admin role
+	edit(admin, admin.Email, user.RoleReader).
+		assertStatusCode(http.StatusOK)
+
+	updated, err = c.ORM.User.Get(context.Background(), admin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, user.RoleAdmin, updated.Role)
+}
+
+func TestAdminUserBan(t *testing.T) {
+	admin := createAdmin(t)
+	usr := createUser(t)
+
+	// The user is logged in before being banned
+	userReq := request(t).login(usr)
+	userReq.setRoute(routeNameSessions).
+		get().
+		assertStatusCode(http.StatusOK)
+
+	req := request(t).
+		noRedirects().
+		login(admin)
+
+	req.setRoute(routeNameAdminUserBan, usr.ID).
+		postFrom(routeNameAdminUserEdit, usr.ID).
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameAdminUsers)
+
+	banned, err := c.ORM.User.Get(context.Background(), usr.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, banned.BannedAt)
+
+	// And is logged out of all of their devices
+	userReq.setRoute(routeNameSessions).
+		get().
+		assertStatusCode(http.StatusUnauthorized)
+
+	req.setRoute(routeNameAdminUserUnban, usr.ID).
+		postFrom(routeNameAdminUserEdit, usr.ID).
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameAdminUsers)
+
+	unbanned, err := c.ORM.User.Get(context.Background(), usr.ID)
+	require.NoError(t, err)
+	assert.Nil(t, unbanned.BannedAt)
+
+	// Admins cannot ban themselves
+	req.setRoute(routeNameAdminUserBan, admin.ID).
+		postFrom(routeNameAdminUserEdit, admin.ID).
+		assertStatusCode(http.StatusFound)
+
+	self, err := c.ORM.User.Get(context.Background(), admin.ID)
+	require.NoError(t, err)
+	assert.Nil(t, self.BannedAt)
+}
+
+func TestAdminComments_Moderate(t *testing.T) {
+	p, err := tests.CreatePost(c.ORM, createUser(t))
+	require.NoError(t, err)
+
+	comments := make([]*ent.Comment, 3)
+	for i := range comments {
+		comments[i], err = tests.CreateComment(c.ORM, p)
+		require.NoError(t, err)
+	}
+
+	req := request(t).
+		noRedirects().
+		login(createAdmin(t))
+
+	moderate := func(action string, comments ...*ent.Comment) {
+		ids := make([]string, 0, len(comments))
+		for _, cm := range comments {
+			ids = append(ids, strconv.Itoa(cm.ID))
+		}
+
+		req.setRoute(routeNameAdminCommentsSubmit).
+			setBody(url.Values{
+				"action": []string{action},
+				"ids":    ids,
+			}).
+			postFrom(routeNameAdminComments).
+			assertStatusCode(http.StatusFound).
+			assertRedirect(t, routeNameAdminComments)
+	}
+
+	status := func(cm *ent.Comment) comment.Status {
+		cm, err := c.ORM.Comment.Get(context.Background(), cm.ID)
+		require.NoError(t, err)
+		return cm.Status
+	}
+
+	moderate("approve", comments[0], comments[1])
+	assert.Equal(t, comment.StatusApproved, status(comments[0]))
+	assert.Equal(t, comment.StatusApproved, status(comments[1]))
+	assert.Equal(t, comment.StatusPending, status(comments[2]))
+
+	moderate("spam", comments[1])
+	assert.Equal(t, comment.StatusSpam, status(comments[1]))
+
+	moderate("delete", comments[0], comments[2])
+	count, err := c.ORM.Comment.
+		Query().
+		Where(comment.IDIn(comments[0].ID, comments[2].ID)).
+		Count(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Equal(t, comment.StatusSpam, status(comments[1]))
+}
+
+func TestAdminCache_Flush(t *testing.T) {
+	seed := time.Now().UnixNano()
+	group := fmt.Sprintf("admin-test-%d", seed)
+	tag := fmt.Sprintf("admin-test-%d", seed)
+
+	set := func(group, key string, tags ...string) {
+		err := c.Cache.
+			Set().
+			Group(group).
+			Key(key).
+			Tags(tags...).
+			Data("data").
+			Save(context.Background())
+		require.NoError(t, err)
+	}
+
+	cached := func(group, key string) bool {
+		_, err := c.Cache.
+			Get().
+			Group(group).
+			Key(key).
+			Type(new(string)).
+			Fetch(context.Background())
+		return err == nil
+	}
+
+	set(group, "a")
+	set(group, "b")
+	set("admin-test", fmt.Sprint(seed), tag)
+
+	req := request(t).
+		noRedirects().
+		login(createAdmin(t))
+
+	flush := func(body url.Values) {
+		req.setRoute(routeNameAdminCacheSubmit).
+			setBody(body).
+			postFrom(routeNameAdminCache).
+			assertStatusCode(http.StatusFound).
+			assertRedirect(t, routeNameAdminCache)
+	}
+
+	// Flushing a group flushes all of its keys, but nothing else
+	flush(url.Values{"group": []string{group}})
+	assert.False(t, cached(group, "a"))
+	assert.False(t, cached(group, "b"))
+	assert.True(t, cached("admin-test", fmt.Sprint(seed)))
+
+	// Flushing a tag flushes the keys it was applied to
+	flush(url.Values{"tags": []string{"other, " + tag}})
+	assert.False(t, cached("admin-test", fmt.Sprint(seed)))
+}
+
+// countUserEvents returns the amount of audit events of a given action which were performed on a given user
+func countUserEvents(t *testing.T, u *ent.User, action audit.Action) int {
+	count, err := c.ORM.AuditEvent.
+		Query().
+		Where(
+			auditevent.Action(string(action)),
+			auditevent.SubjectType(user.Label),
+			auditevent.SubjectID(u.ID),
+		).
+		Count(context.Background())
+	require.NoError(t, err)
+	return count
+}
@@ -0,0 +1,230 @@
+package routes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/audit"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	adminUsers struct {
+		controller.Controller
+	}
+
+	adminUserEdit struct {
+		controller.Controller
+	}
+
+	adminUserBan struct {
+		controller.Controller
+	}
+
+	adminUserUnban struct {
+		controller.Controller
+	}
+
+	adminUsersData struct {
+		Sort  controller.Sorter
+		Users []*ent.User
+	}
+
+	adminUserForm struct {
+		Name       string `form:"name" validate:"required"`
+		Email      string `form:"email" validate:"required,email"`
+		Role       string `form:"role" validate:"required,oneof=admin editor author contributor reader"`
+		Verified   bool   `form:"verified"`
+		Submission controller.FormSubmission
+	}
+)
+
+func (c *adminUsers) Get(ctx echo.Context) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageAdminUsers
+	page.Title = "Users"
+	page.Pager = controller.NewPager(ctx, controller.DefaultItemsPerPage)
+
+	sort := controller.NewSorter(
+		ctx,
+		[]string{user.FieldName, user.FieldEmail, user.FieldRole, user.FieldCreatedAt},
+		user.FieldCreatedAt,
+		true,
+	)
+
+	count, err := c.Container.ORM.User.
+		Query().
+		Count(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to count users")
+	}
+	page.Pager.SetItems(count)
+
+	users, err := c.Container.ORM.User.
+		Query().
+		Order(sort.Order(), ent.Asc(user.FieldID)).
+		Offset(page.Pager.GetOffset()).
+		Limit(page.Pager.ItemsPerPage).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to query users")
+	}
+
+	page.Data = adminUsersData{
+		Sort:  sort,
+		Users: users,
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *adminUserEdit) Get(ctx echo.Context) error {
+	u := ctx.Get(context.UserKey).(*ent.User)
+
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageAdminUserEdit
+	page.Title = u.Name
+	page.Data = u
+	page.Form = adminUserForm{
+		Name:     u.Name,
+		Email:    u.Email,
+		Role:     string(u.Role),
+		Verified: u.Verified,
+	}
+
+	if form := ctx.Get(context.FormKey); form != nil {
+		page.Form = form.(*adminUserForm)
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *adminUserEdit) Post(ctx echo.Context) error {
+	u := ctx.Get(context.UserKey).(*ent.User)
+
+	var form adminUserForm
+	ctx.Set(context.FormKey, &form)
+
+	// Parse the form values
+	if err := ctx.Bind(&form); err != nil {
+		return c.Fail(err, "unable to parse user form")
+	}
+
+	if err := form.Submission.Process(ctx, form); err != nil {
+		return c.Fail(err, "unable to process form submission")
+	}
+
+	// Admins cannot lock themselves out of the admin area
+	authUser := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+	if u.ID == authUser.ID && form.Role != string(user.RoleAdmin) {
+		form.Submission.SetFieldError("Role", "You cannot remove your own admin role.")
+	}
+
+	if form.Submission.HasErrors() {
+		return c.Get(ctx)
+	}
+
+	old := u
+	u, err := u.Update().
+		SetName(form.Name).
+		SetEmail(form.Email).
+		SetRole(user.Role(form.Role)).
+		SetVerified(form.Verified).
+		Save(ctx.Request().Context())
+
+	switch err.(type) {
+	case nil:
+		ctx.Logger().Infof("user updated by admin: %d", u.ID)
+	case *ent.ConstraintError:
+		form.Submission.SetFieldError("Email", "A user with this email address already exists.")
+		return c.Get(ctx)
+	default:
+		return c.Fail(err, "unable to update user")
+	}
+
+	// Changes to the role are recorded by audit.UserHook
+	if details := adminUserChanges(old, u); details != "" {
+		err = audit.Record(ctx.Request().Context(), c.Container.ORM, audit.Event{
+			Action:      audit.UserUpdated,
+			SubjectType: user.Label,
+			SubjectID:   u.ID,
+			Details:     details,
+		})
+		if err != nil {
+			return c.Fail(err, "unable to record audit event")
+		}
+	}
+
+	msg.Success(ctx, "The user has been updated.")
+	return c.Redirect(ctx, routeNameAdminUsers)
+}
+
+func (c *adminUserBan) Post(ctx echo.Context) error {
+	u := ctx.Get(context.UserKey).(*ent.User)
+
+	if u.ID == ctx.Get(context.AuthenticatedUserKey).(*ent.User).ID {
+		msg.Danger(ctx, "You cannot ban yourself.")
+		return c.Redirect(ctx, routeNameAdminUsers)
+	}
+
+	err := u.Update().
+		SetBannedAt(time.Now()).
+		Exec(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to ban user")
+	}
+
+	// Log the user out of all of their devices
+	if err = c.Container.Auth.RevokeSessions(ctx.Request().Context(), u.ID); err != nil {
+		return c.Fail(err, "unable to revoke sessions")
+	}
+
+	ctx.Logger().Infof("user banned: %d", u.ID)
+	msg.Warning(ctx, "The user has been banned and logged out of all of their devices.")
+	return c.Redirect(ctx, routeNameAdminUsers)
+}
+
+func (c *adminUserUnban) Post(ctx echo.Context) error {
+	u := ctx.Get(context.UserKey).(*ent.User)
+
+	err := u.Update().
+		ClearBannedAt().
+		Exec(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to unban user")
+	}
+
+	ctx.Logger().Infof("user unbanned: %d", u.ID)
+	msg.Success(ctx, "The user is no longer banned.")
+	return c.Redirect(ctx, routeNameAdminUsers)
+}
+
+// adminUserChanges describes the changes made to a user other than to their role, or returns an empty string if
+// there are none
+func adminUserChanges(old, u *ent.User) string {
+	changes := make([]string, 0)
+	if old.Name != u.Name {
+		changes = append(changes, fmt.Sprintf("name: %s", u.Name))
+	}
+	if old.Email != u.Email {
+		changes = append(changes, fmt.Sprintf("email: %s", u.Email))
+	}
+	if old.Verified != u.Verified {
+		changes = append(changes, fmt.Sprintf("verified: %t", u.Verified))
+	}
+	return strings.Join(changes, ", ")
+}
@@ -0,0 +1,157 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/category"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/tag"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/services"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	tagArchive struct {
+		controller.Controller
+	}
+
+	categoryArchive struct {
+		controller.Controller
+	}
+
+	authorArchive struct {
+		controller.Controller
+	}
+
+	archiveData struct {
+		Type        string
+		Slug        string
+		Name        string
+		Description string
+		Posts       []*ent.Post
+	}
+)
+
+func (c *tagArchive) Get(ctx echo.Context) error {
+	t, err := c.Container.ORM.Tag.
+		Query().
+		Where(tag.Slug(ctx.Param("slug"))).
+		Only(ctx.Request().Context())
+
+	switch err.(type) {
+	case nil:
+	case *ent.NotFoundError:
+		return echo.NewHTTPError(http.StatusNotFound)
+	default:
+		return c.Fail(err, "unable to query tag")
+	}
+
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageArchive
+	page.Title = "Tagged: " + t.Name
+	page.Cache.Enabled = true
+	page.Cache.Tags = []string{services.CacheTagTag(t.Slug)}
+	page.Pager = controller.NewPager(ctx, controller.DefaultItemsPerPage)
+
+	posts, err := fetchArchivePosts(ctx, t.QueryPosts(), &page.Pager)
+	if err != nil {
+		return c.Fail(err, "unable to fetch tag posts")
+	}
+
+	page.Data = archiveData{
+		Type:  "tag",
+		Slug:  t.Slug,
+		Name:  t.Name,
+		Posts: posts,
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *categoryArchive) Get(ctx echo.Context) error {
+	cat, err := c.Container.ORM.Category.
+		Query().
+		Where(category.Slug(ctx.Param("slug"))).
+		Only(ctx.Request().Context())
+
+	switch err.(type) {
+	case nil:
+	case *ent.NotFoundError:
+		return echo.NewHTTPError(http.StatusNotFound)
+	default:
+		return c.Fail(err, "unable to query category")
+	}
+
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageArchive
+	page.Title = cat.Name
+	page.Metatags.Description = cat.Description
+	page.Cache.Enabled = true
+	page.Cache.Tags = []string{services.CacheTagCategory(cat.Slug)}
+	page.Pager = controller.NewPager(ctx, controller.DefaultItemsPerPage)
+
+	posts, err := fetchArchivePosts(ctx, cat.QueryPosts(), &page.Pager)
+	if err != nil {
+		return c.Fail(err, "unable to fetch category posts")
+	}
+
+	page.Data = archiveData{
+		Type:        "category",
+		Slug:        cat.Slug,
+		Name:        cat.Name,
+		Description: cat.Description,
+		Posts:       posts,
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *authorArchive) Get(ctx echo.Context) error {
+	u := ctx.Get(context.UserKey).(*ent.User)
+
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageArchive
+	page.Title = "Posts by " + u.Name
+	page.Pager = controller.NewPager(ctx, controller.DefaultItemsPerPage)
+
+	posts, err := fetchArchivePosts(ctx, u.QueryPosts(), &page.Pager)
+	if err != nil {
+		return c.Fail(err, "unable to fetch author posts")
+	}
+
+	page.Data = archiveData{
+		Type:  "author",
+		Slug:  strconv.Itoa(u.ID),
+		Name:  u.Name,
+		Posts: posts,
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+// fetchArchivePosts fetches the published posts of an archive for the current page, newest first
+func fetchArchivePosts(ctx echo.Context, query *ent.PostQuery, pager *controller.Pager) ([]*ent.Post, error) {
+	query = query.Where(post.StatusEQ(post.StatusPublished))
+
+	count, err := query.Clone().Count(ctx.Request().Context())
+	if err != nil {
+		return nil, err
+	}
+	pager.SetItems(count)
+
+	return query.
+		WithAuthor().
+		Order(ent.Desc(post.FieldPublishedAt)).
+		Offset(pager.GetOffset()).
+		Limit(pager.ItemsPerPage).
+		All(ctx.Request().Context())
+}
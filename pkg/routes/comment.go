@@ -0,0 +1,217 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/comment"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/htmx"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/tasks"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	commentCreate struct {
+		controller.Controller
+	}
+
+	commentForm struct {
+		Body       string `form:"body" validate:"required"`
+		Name       string `form:"name"`
+		Email      string `form:"email" validate:"omitempty,email"`
+		Parent     int    `form:"parent"`
+		Submission controller.FormSubmission
+	}
+
+	commentFormData struct {
+		Post    *ent.Post
+		Comment *ent.Comment
+	}
+
+	// commentNode is a comment within a thread along with its replies
+	commentNode struct {
+		Comment *ent.Comment
+		Replies []*commentNode
+	}
+)
+
+func (c *commentCreate) Post(ctx echo.Context) error {
+	p, err := c.Container.ORM.Post.
+		Query().
+		Where(
+			post.Slug(ctx.Param("slug")),
+			post.StatusEQ(post.StatusPublished),
+		).
+		WithAuthor().
+		Only(ctx.Request().Context())
+
+	switch err.(type) {
+	case nil:
+	case *ent.NotFoundError:
+		return echo.NewHTTPError(http.StatusNotFound)
+	default:
+		return c.Fail(err, "unable to query post")
+	}
+
+	var form commentForm
+	ctx.Set(context.FormKey, &form)
+
+	// Parse the form values
+	if err := ctx.Bind(&form); err != nil {
+		return c.Fail(err, "unable to parse comment form")
+	}
+
+	if err := form.Submission.Process(ctx, form); err != nil {
+		return c.Fail(err, "unable to process form submission")
+	}
+
+	// Guests must identify themselves
+	u, isAuth := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+	if !isAuth {
+		if form.Name == "" {
+			form.Submission.SetFieldError("Name", "This field is required.")
+		}
+		if form.Email == "" {
+			form.Submission.SetFieldError("Email", "This field is required.")
+		}
+	}
+
+	// Replies can only be made to approved comments on the same post
+	if form.Parent != 0 {
+		exists, err := p.QueryComments().
+			Where(
+				comment.ID(form.Parent),
+				comment.StatusEQ(comment.StatusApproved),
+			).
+			Exist(ctx.Request().Context())
+
+		if err != nil {
+			return c.Fail(err, "unable to query parent comment")
+		}
+
+		if !exists {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid parent comment")
+		}
+	}
+
+	if form.Submission.HasErrors() {
+		return c.render(ctx, p, nil)
+	}
+
+	// Comments made by the author of the post do not require moderation
+	create := c.Container.ORM.Comment.
+		Create().
+		SetPost(p).
+		SetBody(form.Body)
+
+	if isAuth {
+		create.SetAuthor(u)
+		if isPostAuthor(ctx, p) {
+			create.SetStatus(comment.StatusApproved)
+		}
+	} else {
+		create.
+			SetGuestName(form.Name).
+			SetGuestEmail(form.Email)
+	}
+
+	if form.Parent != 0 {
+		create.SetParentID(form.Parent)
+	}
+
+	cm, err := create.Save(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to create comment")
+	}
+
+	ctx.Logger().Infof("comment created: %d", cm.ID)
+
+	// Notify the author of the post that a comment awaits moderation
+	if cm.Status == comment.StatusPending {
+		err = c.Container.Tasks.
+			New(tasks.TypeCommentNotification).
+			Payload(tasks.CommentNotificationPayload{CommentID: cm.ID}).
+			Save()
+
+		if err != nil {
+			ctx.Logger().Errorf("unable to queue comment notification: %v", err)
+		}
+	}
+
+	// Without HTMX, return to the post rather than rendering the form alone
+	if !htmx.GetRequest(ctx).Enabled {
+		if cm.Status == comment.StatusPending {
+			msg.Success(ctx, "Thank you! Your comment will appear once it has been approved.")
+		} else {
+			msg.Success(ctx, "Your comment has been posted.")
+		}
+		return c.Redirect(ctx, routeNamePost, p.Slug)
+	}
+
+	return c.render(ctx, p, cm)
+}
+
+// render renders the comment form, or the outcome of the submission once a comment has been created,
+// which is swapped in place of the submitted form for HTMX requests
+func (c *commentCreate) render(ctx echo.Context, p *ent.Post, cm *ent.Comment) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageCommentForm
+	page.Title = "Leave a comment"
+	page.Form = ctx.Get(context.FormKey)
+	page.Data = commentFormData{
+		Post:    p,
+		Comment: cm,
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+// fetchCommentThread fetches the approved comments of a post arranged in to threads
+func fetchCommentThread(ctx echo.Context, p *ent.Post) ([]*commentNode, error) {
+	comments, err := p.QueryComments().
+		Where(comment.StatusEQ(comment.StatusApproved)).
+		WithAuthor().
+		Order(ent.Asc(comment.FieldCreatedAt)).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCommentThread(comments), nil
+}
+
+// buildCommentThread arranges comments in to threads, keeping their order.
+// Replies whose parent is not among the comments, such as those to a comment later marked as spam, are omitted.
+func buildCommentThread(comments []*ent.Comment) []*commentNode {
+	nodes := make(map[int]*commentNode, len(comments))
+	for _, cm := range comments {
+		nodes[cm.ID] = &commentNode{Comment: cm}
+	}
+
+	thread := make([]*commentNode, 0)
+	for _, cm := range comments {
+		node := nodes[cm.ID]
+		if cm.ParentID == 0 {
+			thread = append(thread, node)
+		} else if parent, ok := nodes[cm.ParentID]; ok {
+			parent.Replies = append(parent.Replies, node)
+		}
+	}
+	return thread
+}
+
+// AuthorName returns the name of the user who made the comment or the name the guest provided
+func (n *commentNode) AuthorName() string {
+	if n.Comment.Edges.Author != nil {
+		return n.Comment.Edges.Author.Name
+	}
+	return n.Comment.GuestName
+}
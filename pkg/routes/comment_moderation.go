@@ -0,0 +1,138 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/comment"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	commentModeration struct {
+		controller.Controller
+	}
+
+	commentModerationData struct {
+		Status   string
+		Statuses []comment.Status
+		Comments []*ent.Comment
+	}
+
+	commentModerationForm struct {
+		Action     string `form:"action" validate:"required,oneof=approve spam delete"`
+		Submission controller.FormSubmission
+	}
+)
+
+func (c *commentModeration) Get(ctx echo.Context) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageComments
+	page.Title = "Comments"
+	page.Pager = controller.NewPager(ctx, controller.DefaultItemsPerPage)
+
+	// Default to the queue of comments awaiting moderation
+	status := comment.Status(ctx.QueryParam("status"))
+	if comment.StatusValidator(status) != nil {
+		status = comment.StatusPending
+	}
+
+	query := c.Container.ORM.Comment.
+		Query().
+		Where(comment.StatusEQ(status))
+
+	// Only comments on the user's own posts, unless they can moderate any comment
+	if u := ctx.Get(context.AuthenticatedUserKey).(*ent.User); !permission.Has(u, permission.ModerateAnyComment) {
+		query.Where(comment.HasPostWith(post.HasAuthorWith(user.ID(u.ID))))
+	}
+
+	count, err := query.Clone().Count(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to count comments")
+	}
+	page.Pager.SetItems(count)
+
+	comments, err := query.
+		WithPost().
+		WithAuthor().
+		Order(ent.Desc(comment.FieldCreatedAt)).
+		Offset(page.Pager.GetOffset()).
+		Limit(page.Pager.ItemsPerPage).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to query comments")
+	}
+
+	page.Data = commentModerationData{
+		Status:   string(status),
+		Statuses: []comment.Status{comment.StatusPending, comment.StatusApproved, comment.StatusSpam},
+		Comments: comments,
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *commentModeration) Post(ctx echo.Context) error {
+	cm := ctx.Get(context.CommentKey).(*ent.Comment)
+	if !canModerateComments(ctx, cm.Edges.Post) {
+		return echo.NewHTTPError(http.StatusForbidden)
+	}
+
+	var form commentModerationForm
+
+	// Parse the form values
+	if err := ctx.Bind(&form); err != nil {
+		return c.Fail(err, "unable to parse comment moderation form")
+	}
+
+	if err := form.Submission.Process(ctx, form); err != nil {
+		return c.Fail(err, "unable to process form submission")
+	}
+
+	if form.Submission.HasErrors() {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid moderation action")
+	}
+
+	var err error
+	switch form.Action {
+	case "approve":
+		err = cm.Update().
+			SetStatus(comment.StatusApproved).
+			Exec(ctx.Request().Context())
+	case "spam":
+		err = cm.Update().
+			SetStatus(comment.StatusSpam).
+			Exec(ctx.Request().Context())
+	case "delete":
+		err = c.Container.ORM.Comment.
+			DeleteOne(cm).
+			Exec(ctx.Request().Context())
+	}
+
+	if err != nil {
+		return c.Fail(err, "unable to moderate comment")
+	}
+
+	ctx.Logger().Infof("comment moderated: %d, %s", cm.ID, form.Action)
+
+	switch form.Action {
+	case "approve":
+		msg.Success(ctx, "The comment has been approved.")
+	case "spam":
+		msg.Warning(ctx, "The comment has been marked as spam.")
+	case "delete":
+		msg.Danger(ctx, "The comment and its replies have been deleted.")
+	}
+
+	return c.Redirect(ctx, routeNameComments)
+}
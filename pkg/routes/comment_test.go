@@ -0,0 +1,295 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/comment"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+	"github.com/mikestefanello/pagoda/pkg/tasks"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCommentThread(t *testing.T) {
+	comments := []*ent.Comment{
+		{ID: 1},
+		{ID: 2, ParentID: 1},
+		{ID: 3},
+		{ID: 4, ParentID: 2},
+		{ID: 5, ParentID: 1},
+		{ID: 6, ParentID: 100},
+	}
+
+	thread := buildCommentThread(comments)
+	require.Len(t, thread, 2)
+	assert.Equal(t, 1, thread[0].Comment.ID)
+	assert.Equal(t, 3, thread[1].Comment.ID)
+	require.Len(t, thread[0].Replies, 2)
+	assert.Equal(t, 2, thread[0].Replies[0].Comment.ID)
+	assert.Equal(t, 5, thread[0].Replies[1].Comment.ID)
+	require.Len(t, thread[0].Replies[0].Replies, 1)
+	assert.Equal(t, 4, thread[0].Replies[0].Replies[0].Comment.ID)
+	assert.Empty(t, thread[1].Replies)
+}
+
+func TestPostView_Comments(t *testing.T) {
+	usr, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+	p, err := tests.CreatePost(c.ORM, usr)
+	require.NoError(t, err)
+
+	approved, err := tests.CreateComment(c.ORM, p)
+	require.NoError(t, err)
+	require.NoError(t, approved.Update().SetStatus(comment.StatusApproved).Exec(permission.SystemContext(context.Background())))
+
+	_, err = c.ORM.Comment.
+		Create().
+		SetPost(p).
+		SetParent(approved).
+		SetAuthor(usr).
+		SetBody("A reply").
+		SetStatus(comment.StatusApproved).
+		Save(permission.SystemContext(context.Background()))
+	require.NoError(t, err)
+
+	_, err = tests.CreateComment(c.ORM, p)
+	require.NoError(t, err)
+
+	doc := request(t).
+		setRoute(routeNamePost, p.Slug).
+		get().
+		assertStatusCode(http.StatusOK).
+		toDoc()
+
+	comments := doc.Find("#comments article.media")
+	assert.Equal(t, 2, comments.Length())
+	assert.Equal(t, 1, doc.Find("#comments article.media article.media").Length())
+	assert.Equal(t, usr.Name, doc.Find("#comments article.media article.media strong").First().Text())
+}
+
+func TestCommentCreate_Guest(t *testing.T) {
+	author := createUserWithRole(t, user.RoleAuthor)
+	p, err := tests.CreatePost(c.ORM, author)
+	require.NoError(t, err)
+
+	req := request(t).
+		noRedirects().
+		setHeader(echo.HeaderXRealIP, "192.0.2.50")
+
+	// Guests must provide their name and email address
+	doc := req.setRoute(routeNameCommentSubmit, p.Slug).
+		setBody(url.Values{"body": []string{"Anonymous"}}).
+		postFrom(routeNamePost, p.Slug).
+		assertStatusCode(http.StatusOK).
+		toDoc()
+	assert.Equal(t, 1, doc.Find(`input[name="name"].is-danger`).Length())
+	assert.Equal(t, 1, doc.Find(`input[name="email"].is-danger`).Length())
+	assert.Zero(t, countComments(t, p))
+
+	body := fmt.Sprintf("Guest comment %d", time.Now().UnixNano())
+	req.setRoute(routeNameCommentSubmit, p.Slug).
+		setBody(url.Values{
+			"body":  []string{body},
+			"name":  []string{"Guest"},
+			"email": []string{"guest@localhost.localhost"},
+		}).
+		postFrom(routeNamePost, p.Slug).
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNamePost, p.Slug)
+
+	cm, err := c.ORM.Comment.
+		Query().
+		Where(comment.Body(body)).
+		Only(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, comment.StatusPending, cm.Status)
+	assert.Equal(t, "Guest", cm.GuestName)
+	assert.Equal(t, "guest@localhost.localhost", cm.GuestEmail)
+
+	// The author is notified by a task rather than being emailed while the comment is submitted
+	assert.Contains(t, queuedCommentNotifications(t), cm.ID)
+	assert.Empty(t, queuedMail(t, author.Email))
+
+	// The comment is hidden until it is approved
+	assert.NotContains(t, postBody(t, p), body)
+
+	request(t).
+		noRedirects().
+		login(author).
+		setRoute(routeNameCommentModerate, cm.ID).
+		setBody(url.Values{"action": []string{"approve"}}).
+		postFrom(routeNamePost, p.Slug).
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameComments)
+
+	assert.Contains(t, postBody(t, p), body)
+}
+
+func TestCommentCreate_Authenticated(t *testing.T) {
+	author := createUserWithRole(t, user.RoleAuthor)
+	p, err := tests.CreatePost(c.ORM, author)
+	require.NoError(t, err)
+
+	// Users do not have to provide their name and email address
+	usr := createUser(t)
+	request(t).
+		noRedirects().
+		login(usr).
+		setRoute(routeNameCommentSubmit, p.Slug).
+		setBody(url.Values{"body": []string{"Reader"}}).
+		postFrom(routeNamePost, p.Slug).
+		assertStatusCode(http.StatusFound)
+
+	cm, err := c.ORM.Comment.
+		Query().
+		Where(comment.HasPostWith(post.ID(p.ID)), comment.HasAuthorWith(user.ID(usr.ID))).
+		Only(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, comment.StatusPending, cm.Status)
+	assert.Contains(t, queuedCommentNotifications(t), cm.ID)
+
+	// Comments by the author of the post are approved without a notification
+	request(t).
+		noRedirects().
+		login(author).
+		setRoute(routeNameCommentSubmit, p.Slug).
+		setBody(url.Values{"body": []string{"Author"}}).
+		postFrom(routeNamePost, p.Slug).
+		assertStatusCode(http.StatusFound)
+
+	cm, err = c.ORM.Comment.
+		Query().
+		Where(comment.HasPostWith(post.ID(p.ID)), comment.HasAuthorWith(user.ID(author.ID))).
+		Only(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, comment.StatusApproved, cm.Status)
+	assert.NotContains(t, queuedCommentNotifications(t), cm.ID)
+}
+
+func TestCommentCreate_ParentOnOtherPost(t *testing.T) {
+	author := createUserWithRole(t, user.RoleAuthor)
+	p, err := tests.CreatePost(c.ORM, author)
+	require.NoError(t, err)
+	other, err := tests.CreatePost(c.ORM, author)
+	require.NoError(t, err)
+
+	parent, err := tests.CreateComment(c.ORM, other)
+	require.NoError(t, err)
+	require.NoError(t, parent.Update().SetStatus(comment.StatusApproved).Exec(permission.SystemContext(context.Background())))
+
+	request(t).
+		login(createUser(t)).
+		setRoute(routeNameCommentSubmit, p.Slug).
+		setBody(url.Values{
+			"body":   []string{"Reply"},
+			"parent": []string{strconv.Itoa(parent.ID)},
+		}).
+		postFrom(routeNamePost, p.Slug).
+		assertStatusCode(http.StatusBadRequest)
+
+	assert.Zero(t, countComments(t, p))
+}
+
+func TestCommentModeration(t *testing.T) {
+	author := createUserWithRole(t, user.RoleAuthor)
+	p, err := tests.CreatePost(c.ORM, author)
+	require.NoError(t, err)
+
+	moderate := func(usr *ent.User, cm *ent.Comment, action string) *httpResponse {
+		return request(t).
+			noRedirects().
+			login(usr).
+			setRoute(routeNameCommentModerate, cm.ID).
+			setBody(url.Values{"action": []string{action}}).
+			postFrom(routeNamePost, p.Slug)
+	}
+
+	// Authors who cannot moderate any comment cannot moderate those on the posts of others
+	cm, err := tests.CreateComment(c.ORM, p)
+	require.NoError(t, err)
+	moderate(createUserWithRole(t, user.RoleAuthor), cm, "approve").
+		assertStatusCode(http.StatusForbidden)
+
+	cm, err = c.ORM.Comment.Get(context.Background(), cm.ID)
+	require.NoError(t, err)
+	assert.Equal(t, comment.StatusPending, cm.Status)
+
+	// But the author of the post can
+	moderate(author, cm, "approve").
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameComments)
+
+	cm, err = c.ORM.Comment.Get(context.Background(), cm.ID)
+	require.NoError(t, err)
+	assert.Equal(t, comment.StatusApproved, cm.Status)
+
+	moderate(author, cm, "spam").
+		assertStatusCode(http.StatusFound)
+
+	cm, err = c.ORM.Comment.Get(context.Background(), cm.ID)
+	require.NoError(t, err)
+	assert.Equal(t, comment.StatusSpam, cm.Status)
+
+	// Deleting a comment deletes its replies
+	reply, err := c.ORM.Comment.
+		Create().
+		SetPost(p).
+		SetParent(cm).
+		SetBody("A reply").
+		SetGuestName("Guest").
+		SetGuestEmail("guest@localhost.localhost").
+		Save(context.Background())
+	require.NoError(t, err)
+
+	moderate(author, cm, "delete").
+		assertStatusCode(http.StatusFound)
+
+	_, err = c.ORM.Comment.Get(context.Background(), cm.ID)
+	assert.True(t, ent.IsNotFound(err))
+	_, err = c.ORM.Comment.Get(context.Background(), reply.ID)
+	assert.True(t, ent.IsNotFound(err))
+}
+
+// countComments returns the amount of comments on a given post
+func countComments(t *testing.T, p *ent.Post) int {
+	count, err := p.QueryComments().Count(context.Background())
+	require.NoError(t, err)
+	return count
+}
+
+// postBody returns the body of the page of a given post
+func postBody(t *testing.T, p *ent.Post) string {
+	body, err := request(t).
+		setRoute(routeNamePost, p.Slug).
+		get().
+		assertStatusCode(http.StatusOK).
+		toDoc().
+		Find("body").
+		Html()
+	require.NoError(t, err)
+	return body
+}
+
+// queuedCommentNotifications returns the IDs of the comments which authors are waiting to be notified of
+func queuedCommentNotifications(t *testing.T) []int {
+	var ids []int
+	for _, task := range pendingTasks(t, tasks.TypeCommentNotification) {
+		var payload tasks.CommentNotificationPayload
+		require.NoError(t, json.Unmarshal(task.Payload, &payload))
+		ids = append(ids, payload.CommentID)
+	}
+	return ids
+}
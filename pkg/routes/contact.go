@@ -55,7 +55,7 @@ func (c *contact) Post(ctx echo.Context) error {
 			To(form.Email).
 			Subject("Contact form submitted").
 			Body(fmt.Sprintf("The message is: %s", form.Message)).
-			Send(ctx)
+			SendAsync()
 
 		if err != nil {
 			return c.Fail(err, "unable to send email")
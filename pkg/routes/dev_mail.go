@@ -0,0 +1,95 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/services"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	devMail struct {
+		controller.Controller
+	}
+
+	devMailView struct {
+		Mail  *services.CaughtMail
+		Links []string
+	}
+)
+
+func (c *devMail) Get(ctx echo.Context) error {
+	mails, err := c.Container.Mail.Catcher.List(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to load caught mail")
+	}
+
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageDevMail
+	page.Title = "Mail inbox"
+	page.Data = mails
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *devMail) View(ctx echo.Context) error {
+	m, err := c.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageDevMailView
+	page.Title = m.Message.Subject
+	page.Data = devMailView{
+		Mail:  m,
+		Links: m.Message.Links(),
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+// HTML renders the HTML body of an email on its own so it can be displayed within a frame and its links
+// followed. The content security policy prevents any scripts within the email from running.
+func (c *devMail) HTML(ctx echo.Context) error {
+	m, err := c.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx.Response().Header().Set(
+		"Content-Security-Policy",
+		"sandbox allow-popups allow-popups-to-escape-sandbox allow-top-navigation-by-user-activation",
+	)
+	return ctx.HTML(http.StatusOK, m.Message.HTML)
+}
+
+func (c *devMail) Clear(ctx echo.Context) error {
+	if err := c.Container.Mail.Catcher.Clear(ctx.Request().Context()); err != nil {
+		return c.Fail(err, "unable to clear caught mail")
+	}
+
+	msg.Success(ctx, "The mail inbox has been cleared.")
+	return c.Redirect(ctx, routeNameDevMail)
+}
+
+// load loads the caught email requested in the route parameters
+func (c *devMail) load(ctx echo.Context) (*services.CaughtMail, error) {
+	m, err := c.Container.Mail.Catcher.Get(ctx.Request().Context(), ctx.Param("mail"))
+
+	switch {
+	case err == nil:
+		return m, nil
+	case errors.Is(err, services.ErrCaughtMailNotFound):
+		return nil, echo.NewHTTPError(http.StatusNotFound)
+	default:
+		return nil, c.Fail(err, "unable to load caught mail")
+	}
+}
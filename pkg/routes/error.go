@@ -35,7 +35,10 @@ func (e *errorHandler) Get(err error, ctx echo.Context) {
 	page.Layout = templates.LayoutMain
 	page.Name = templates.PageError
 	page.StatusCode = code
-	page.HTMX.Request.Enabled = false
+
+	// Rate limited HTMX partial requests render the error in place of the content requested, rather than the
+	// entire page
+	page.HTMX.Request.Enabled = page.HTMX.Request.Enabled && code == http.StatusTooManyRequests
 
 	if err = e.RenderPage(ctx, page); err != nil {
 		ctx.Logger().Error(err)
@@ -0,0 +1,240 @@
+package routes
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/tag"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/middleware"
+	"github.com/mikestefanello/pagoda/pkg/services"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gorilla/feeds"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// feedItems stores the amount of posts included in a feed
+	feedItems = 20
+
+	// cachedFeedGroup stores the cache group for rendered feeds
+	cachedFeedGroup = "feed"
+
+	// headerETag and headerIfNoneMatch are the headers used to validate cached feeds
+	headerETag        = "ETag"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// feedFormat is a format that a feed can be rendered in
+type feedFormat string
+
+const (
+	feedFormatRSS  feedFormat = "application/rss+xml; charset=utf-8"
+	feedFormatAtom feedFormat = "application/atom+xml; charset=utf-8"
+	feedFormatJSON feedFormat = "application/feed+json; charset=utf-8"
+)
+
+type feed struct {
+	controller.Controller
+	format feedFormat
+}
+
+// Get renders the feed of the most recently published posts, which can be limited to a tag via the slug
+// path parameter or to an author via the user path parameter.
+// Rendered feeds are cached by URL, in the same form as cached pages, until a post changes.
+func (c *feed) Get(ctx echo.Context) error {
+	key := c.AbsoluteURL(ctx.Request().URL.String())
+
+	res, err := c.Container.Cache.
+		Get().
+		Group(cachedFeedGroup).
+		Key(key).
+		Type(new(middleware.CachedPage)).
+		Fetch(ctx.Request().Context())
+
+	cached, _ := res.(*middleware.CachedPage)
+
+	switch {
+	case err == nil, err == redis.Nil:
+	case context.IsCanceledError(err):
+		return nil
+	default:
+		ctx.Logger().Errorf("failed getting cached feed: %v", err)
+	}
+
+	if cached == nil {
+		if cached, err = c.render(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	for k, v := range cached.Headers {
+		ctx.Response().Header().Set(k, v)
+	}
+
+	if feedNotModified(ctx.Request(), cached.Headers) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	return ctx.Blob(cached.StatusCode, cached.Headers[echo.HeaderContentType], cached.HTML)
+}
+
+// render builds the feed from the published posts and caches it
+func (c *feed) render(ctx echo.Context, key string) (*middleware.CachedPage, error) {
+	f := &feeds.Feed{
+		Title: c.Container.Config.App.Name,
+		Link:  &feeds.Link{Href: c.AbsoluteURL(ctx.Echo().Reverse(routeNameHome))},
+		Id:    key,
+	}
+
+	query := c.Container.ORM.Post.
+		Query().
+		Where(post.StatusEQ(post.StatusPublished))
+
+	switch {
+	case ctx.Param("slug") != "":
+		t, err := c.Container.ORM.Tag.
+			Query().
+			Where(tag.Slug(ctx.Param("slug"))).
+			Only(ctx.Request().Context())
+
+		switch err.(type) {
+		case nil:
+		case *ent.NotFoundError:
+			return nil, echo.NewHTTPError(http.StatusNotFound)
+		default:
+			return nil, c.Fail(err, "unable to query tag")
+		}
+
+		f.Title = fmt.Sprintf("%s: %s", f.Title, t.Name)
+		f.Link.Href = c.AbsoluteURL(ctx.Echo().Reverse(routeNameTagArchive, t.Slug))
+		query = t.QueryPosts().Where(post.StatusEQ(post.StatusPublished))
+
+	case ctx.Get(context.UserKey) != nil:
+		u := ctx.Get(context.UserKey).(*ent.User)
+		f.Title = fmt.Sprintf("%s: %s", f.Title, u.Name)
+		f.Author = &feeds.Author{Name: u.Name}
+		query = u.QueryPosts().Where(post.StatusEQ(post.StatusPublished))
+	}
+
+	posts, err := query.
+		WithAuthor().
+		Order(ent.Desc(post.FieldPublishedAt)).
+		Limit(feedItems).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return nil, c.Fail(err, "unable to query posts")
+	}
+
+	for _, p := range posts {
+		body, err := c.Container.Markdown.RenderPost(ctx.Request().Context(), p)
+		if err != nil {
+			return nil, c.Fail(err, "unable to render post")
+		}
+
+		link := c.AbsoluteURL(ctx.Echo().Reverse(routeNamePost, p.Slug))
+		item := &feeds.Item{
+			Title:       p.Title,
+			Link:        &feeds.Link{Href: link},
+			Id:          link,
+			Description: p.Excerpt,
+			Content:     string(body.HTML),
+			Updated:     p.UpdatedAt,
+		}
+
+		if p.PublishedAt != nil {
+			item.Created = *p.PublishedAt
+		}
+
+		if p.Edges.Author != nil {
+			item.Author = &feeds.Author{Name: p.Edges.Author.Name}
+		}
+
+		if p.UpdatedAt.After(f.Updated) {
+			f.Updated = p.UpdatedAt
+		}
+
+		f.Add(item)
+	}
+
+	var body string
+	switch c.format {
+	case feedFormatAtom:
+		body, err = f.ToAtom()
+	case feedFormatJSON:
+		body, err = f.ToJSON()
+	default:
+		body, err = f.ToRss()
+	}
+
+	if err != nil {
+		return nil, c.Fail(err, "unable to render feed")
+	}
+
+	cached := &middleware.CachedPage{
+		URL:        key,
+		HTML:       []byte(body),
+		StatusCode: http.StatusOK,
+		Headers: map[string]string{
+			echo.HeaderContentType: string(c.format),
+			headerETag:             fmt.Sprintf(`"%x"`, sha1.Sum([]byte(body))),
+		},
+	}
+
+	if !f.Updated.IsZero() {
+		cached.Headers[echo.HeaderLastModified] = f.Updated.UTC().Format(http.TimeFormat)
+	}
+
+	err = c.Container.Cache.
+		Set().
+		Group(cachedFeedGroup).
+		Key(key).
+		Tags(services.CacheTagFeeds).
+		Expiration(c.Container.Config.Cache.Expiration.Page).
+		Data(cached).
+		Save(ctx.Request().Context())
+
+	switch {
+	case err == nil:
+		ctx.Logger().Info("cached feed")
+	case !context.IsCanceledError(err):
+		ctx.Logger().Errorf("failed to cache feed: %v", err)
+	}
+
+	return cached, nil
+}
+
+// feedNotModified determines if the client already has the current version of a feed, based on the
+// validators it sent and the headers of the feed
+func feedNotModified(r *http.Request, headers map[string]string) bool {
+	// If-None-Match takes precedence over If-Modified-Since when both are sent
+	if match := r.Header.Get(headerIfNoneMatch); match != "" {
+		for _, etag := range strings.Split(match, ",") {
+			etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+			if etag == "*" || etag == headers[headerETag] {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get(echo.HeaderIfModifiedSince))
+	if err != nil {
+		return false
+	}
+
+	modified, err := http.ParseTime(headers[echo.HeaderLastModified])
+	if err != nil {
+		return false
+	}
+
+	return !modified.Truncate(time.Second).After(since)
+}
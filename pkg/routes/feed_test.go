@@ -0,0 +1,108 @@
+package routes
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedNotModified(t *testing.T) {
+	modified := time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC)
+	headers := map[string]string{
+		headerETag:              `"abc"`,
+		echo.HeaderLastModified: modified.Format(http.TimeFormat),
+	}
+
+	check := func(header, value string) bool {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			r.Header.Set(header, value)
+		}
+		return feedNotModified(r, headers)
+	}
+
+	assert.False(t, check("", ""))
+	assert.True(t, check(headerIfNoneMatch, `"abc"`))
+	assert.True(t, check(headerIfNoneMatch, `"xyz", W/"abc"`))
+	assert.True(t, check(headerIfNoneMatch, "*"))
+	assert.False(t, check(headerIfNoneMatch, `"xyz"`))
+	assert.True(t, check(echo.HeaderIfModifiedSince, modified.Format(http.TimeFormat)))
+	assert.True(t, check(echo.HeaderIfModifiedSince, modified.Add(time.Hour).Format(http.TimeFormat)))
+	assert.False(t, check(echo.HeaderIfModifiedSince, modified.Add(-time.Hour).Format(http.TimeFormat)))
+	assert.False(t, check(echo.HeaderIfModifiedSince, "invalid"))
+}
+
+func TestFeed_Get(t *testing.T) {
+	usr, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+	p, err := tests.CreatePost(c.ORM, usr)
+	require.NoError(t, err)
+
+	resp := request(t).
+		setRoute(routeNameAuthorFeedRSS, usr.ID).
+		get().
+		assertStatusCode(http.StatusOK)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, string(feedFormatRSS), resp.Header.Get(echo.HeaderContentType))
+	assert.Contains(t, string(body), p.Title)
+
+	etag := resp.Header.Get(headerETag)
+	require.NotEmpty(t, etag)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+c.Web.Reverse(routeNameAuthorFeedRSS, usr.ID), nil)
+	require.NoError(t, err)
+	req.Header.Set(headerIfNoneMatch, etag)
+	resp2, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp2.Body.Close())
+	assert.Equal(t, http.StatusNotModified, resp2.StatusCode)
+
+	request(t).
+		setRoute(routeNameTagFeedJSON, "missing-tag").
+		get().
+		assertStatusCode(http.StatusNotFound)
+
+	// Users who have not published any posts have no archive or feeds
+	reader, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+	for _, route := range []string{routeNameAuthorArchive, routeNameAuthorFeedRSS} {
+		request(t).
+			setRoute(route, reader.ID).
+			get().
+			assertStatusCode(http.StatusNotFound)
+	}
+}
+
+func TestFeed_LinksIgnoreHost(t *testing.T) {
+	usr, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+	p, err := tests.CreatePost(c.ORM, usr)
+	require.NoError(t, err)
+
+	// The links must not be built from a host header provided by the client, nor must the feed rendered for
+	// that request be cached and served to others
+	for _, host := range []string{"attacker.localhost", ""} {
+		resp := request(t).
+			setRoute(routeNameAuthorFeedAtom, usr.ID).
+			setHost(host).
+			get().
+			assertStatusCode(http.StatusOK)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Contains(t, string(body), c.Config.App.URL+c.Web.Reverse(routeNamePost, p.Slug))
+		assert.NotContains(t, string(body), "attacker.localhost")
+	}
+}
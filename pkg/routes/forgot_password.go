@@ -85,13 +85,17 @@ func (c *forgotPassword) Post(ctx echo.Context) error {
 	ctx.Logger().Infof("generated password reset token for user %d", u.ID)
 
 	// Email the user
-	url := ctx.Echo().Reverse(routeNameResetPassword, u.ID, pt.ID, token)
 	err = c.Container.Mail.
 		Compose().
 		To(u.Email).
-		Subject("Reset your password").
-		Body(fmt.Sprintf("Go here to reset your password: %s", url)).
-		Send(ctx)
+		Template("password-reset").
+		TemplateData(emailLink{
+			Name:       u.Name,
+			URL:        c.AbsoluteURL(ctx.Echo().Reverse(routeNameResetPassword, u.ID, pt.ID, token)),
+			Expiration: fmt.Sprintf("%d minutes", int(c.Container.Config.App.PasswordToken.Expiration.Minutes())),
+		}).
+		Locale(ctx.Request().Header.Get("Accept-Language")).
+		SendAsync()
 
 	if err != nil {
 		return c.Fail(err, "error sending password reset email")
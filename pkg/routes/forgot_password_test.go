@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForgotPassword_LinkIgnoresHost(t *testing.T) {
+	usr, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	// The reset link must not be built from a host header provided by the client
+	request(t).
+		setRoute(routeNameForgotPassword).
+		setHost("attacker.localhost").
+		setBody(url.Values{"email": []string{usr.Email}}).
+		post().
+		assertStatusCode(http.StatusOK)
+
+	messages := queuedMail(t, usr.Email)
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0].Text, c.Config.App.URL+"/password/reset/")
+	assert.Contains(t, messages[0].HTML, c.Config.App.URL+"/password/reset/")
+	assert.NotContains(t, messages[0].Text, "attacker.localhost")
+	assert.NotContains(t, messages[0].HTML, "attacker.localhost")
+}
@@ -1,24 +1,17 @@
 package routes
 
 import (
-	"fmt"
-
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/post"
 	"github.com/mikestefanello/pagoda/pkg/controller"
 	"github.com/mikestefanello/pagoda/templates"
 
 	"github.com/labstack/echo/v4"
 )
 
-type (
-	home struct {
-		controller.Controller
-	}
-
-	post struct {
-		Title string
-		Body  string
-	}
-)
+type home struct {
+	controller.Controller
+}
 
 func (c *home) Get(ctx echo.Context) error {
 	page := controller.NewPage(ctx)
@@ -27,21 +20,32 @@ func (c *home) Get(ctx echo.Context) error {
 	page.Metatags.Description = "Welcome to the homepage."
 	page.Metatags.Keywords = []string{"Go", "MVC", "Web", "Software"}
 	page.Pager = controller.NewPager(ctx, 4)
-	page.Data = c.fetchPosts(&page.Pager)
+
+	posts, err := c.fetchPosts(ctx, &page.Pager)
+	if err != nil {
+		return c.Fail(err, "unable to fetch posts")
+	}
+	page.Data = posts
 
 	return c.RenderPage(ctx, page)
 }
 
-// fetchPosts is an mock example of fetching posts to illustrate how paging works
-func (c *home) fetchPosts(pager *controller.Pager) []post {
-	pager.SetItems(20)
-	posts := make([]post, 20)
+// fetchPosts fetches the published posts for the current page, newest first
+func (c *home) fetchPosts(ctx echo.Context, pager *controller.Pager) ([]*ent.Post, error) {
+	query := c.Container.ORM.Post.
+		Query().
+		Where(post.StatusEQ(post.StatusPublished))
 
-	for k := range posts {
-		posts[k] = post{
-			Title: fmt.Sprintf("Post example #%d", k+1),
-			Body:  fmt.Sprintf("Lorem ipsum example #%d ddolor sit amet, consectetur adipiscing elit. Nam elementum vulputate tristique.", k+1),
-		}
+	count, err := query.Clone().Count(ctx.Request().Context())
+	if err != nil {
+		return nil, err
 	}
-	return posts[pager.GetOffset() : pager.GetOffset()+pager.ItemsPerPage]
+	pager.SetItems(count)
+
+	return query.
+		WithAuthor().
+		Order(ent.Desc(post.FieldPublishedAt)).
+		Offset(pager.GetOffset()).
+		Limit(pager.ItemsPerPage).
+		All(ctx.Request().Context())
 }
@@ -0,0 +1,120 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/credential"
+	"github.com/mikestefanello/pagoda/ent/identity"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	identities struct {
+		controller.Controller
+	}
+
+	identityItem struct {
+		// Provider is the configuration of the provider
+		Provider config.OIDCProviderConfig
+
+		// Identity is the account with the provider linked to the user, if any
+		Identity *ent.Identity
+	}
+)
+
+func (c *identities) Get(ctx echo.Context) error {
+	usr := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+
+	linked, err := c.Container.ORM.Identity.
+		Query().
+		Where(identity.HasUserWith(user.ID(usr.ID))).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to load identities")
+	}
+
+	providers := c.Container.OIDC.Providers()
+	items := make([]identityItem, 0, len(providers))
+	for _, p := range providers {
+		item := identityItem{Provider: p}
+		for _, i := range linked {
+			if i.Provider == p.Name {
+				item.Identity = i
+			}
+		}
+		items = append(items, item)
+	}
+
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageIdentities
+	page.Title = "Linked accounts"
+	page.Data = items
+
+	return c.RenderPage(ctx, page)
+}
+
+// Unlink removes the link between the user and one of their accounts with a provider
+func (c *identities) Unlink(ctx echo.Context) error {
+	usr := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+	provider := ctx.Param("provider")
+
+	// Users who signed up with a provider and have not since set a password must keep another way to log in
+	if !usr.PasswordSet {
+		other, err := c.Container.ORM.Identity.
+			Query().
+			Where(
+				identity.ProviderNEQ(provider),
+				identity.HasUserWith(user.ID(usr.ID)),
+			).
+			Exist(ctx.Request().Context())
+
+		if err != nil {
+			return c.Fail(err, "unable to query identities")
+		}
+
+		if !other {
+			other, err = c.Container.ORM.Credential.
+				Query().
+				Where(credential.HasUserWith(user.ID(usr.ID))).
+				Exist(ctx.Request().Context())
+
+			if err != nil {
+				return c.Fail(err, "unable to query passkeys")
+			}
+		}
+
+		if !other {
+			msg.Danger(ctx, "This is the only way you can log in. Reset your password or add a passkey before unlinking it.")
+			return c.Redirect(ctx, routeNameIdentities)
+		}
+	}
+
+	count, err := c.Container.ORM.Identity.
+		Delete().
+		Where(
+			identity.Provider(provider),
+			identity.HasUserWith(user.ID(usr.ID)),
+		).
+		Exec(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to unlink identity")
+	}
+
+	if count == 0 {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+
+	msg.Success(ctx, "The account has been unlinked.")
+	return c.Redirect(ctx, routeNameIdentities)
+}
@@ -0,0 +1,142 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/ent/identity"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentities_RequiresAuthentication(t *testing.T) {
+	request(t).
+		setRoute(routeNameIdentities).
+		get().
+		assertStatusCode(http.StatusUnauthorized)
+}
+
+func TestLoginOIDC_ProviderNotFound(t *testing.T) {
+	request(t).
+		setRoute(routeNameLoginOIDC, "missing").
+		get().
+		assertStatusCode(http.StatusNotFound)
+}
+
+func TestIdentities_LinkAndUnlink(t *testing.T) {
+	issuer := createOIDCProvider(t)
+	usr := createUser(t)
+
+	req := request(t).
+		noRedirects().
+		login(usr)
+
+	// Linking redirects to the provider which then redirects back to the callback
+	resp := req.setRoute(routeNameIdentityLink, "test").
+		postFrom(routeNameIdentities).
+		assertStatusCode(http.StatusFound)
+	require.NoError(t, resp.Body.Close())
+
+	oidcCallback(t, req, issuer, resp.Header.Get("Location")).
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameIdentities)
+
+	linked, err := c.ORM.Identity.
+		Query().
+		Where(
+			identity.Provider("test"),
+			identity.Subject(issuer.Subject),
+			identity.HasUserWith(user.ID(usr.ID)),
+		).
+		Exist(context.Background())
+	require.NoError(t, err)
+	assert.True(t, linked)
+
+	// The user has a password so the identity can be unlinked
+	req.setRoute(routeNameIdentityUnlink, "test").
+		postFrom(routeNameIdentities).
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameIdentities)
+
+	linked, err = c.ORM.Identity.
+		Query().
+		Where(identity.HasUserWith(user.ID(usr.ID))).
+		Exist(context.Background())
+	require.NoError(t, err)
+	assert.False(t, linked)
+}
+
+func TestIdentities_UnlinkLastLoginMethod(t *testing.T) {
+	issuer := createOIDCProvider(t)
+
+	// Signing in with the provider as someone new creates a user without a password and logs them in
+	req := request(t).noRedirects()
+	resp := req.setRoute(routeNameLoginOIDC, "test").
+		get().
+		assertStatusCode(http.StatusFound)
+	require.NoError(t, resp.Body.Close())
+
+	oidcCallback(t, req, issuer, resp.Header.Get("Location")).
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameHome)
+
+	usr, err := c.ORM.User.
+		Query().
+		Where(user.Email(issuer.Email)).
+		Only(context.Background())
+	require.NoError(t, err)
+	assert.False(t, usr.PasswordSet)
+
+	// The provider is the only way the user can log in so it cannot be unlinked
+	req.setRoute(routeNameIdentityUnlink, "test").
+		postFrom(routeNameIdentities).
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameIdentities)
+
+	linked, err := c.ORM.Identity.
+		Query().
+		Where(identity.HasUserWith(user.ID(usr.ID))).
+		Exist(context.Background())
+	require.NoError(t, err)
+	assert.True(t, linked)
+}
+
+// createOIDCProvider starts a mock issuer for a unique user and configures it as the provider named test
+func createOIDCProvider(t *testing.T) *tests.OIDCIssuer {
+	issuer, err := tests.NewOIDCIssuer()
+	require.NoError(t, err)
+
+	id := time.Now().UnixNano()
+	issuer.Subject = fmt.Sprint(id)
+	issuer.Email = fmt.Sprintf("oidc-%d@localhost.localhost", id)
+
+	providers := c.Config.OIDC.Providers
+	c.Config.OIDC.Providers = []config.OIDCProviderConfig{{
+		Name:         "test",
+		Label:        "Test",
+		Issuer:       issuer.URL,
+		ClientID:     issuer.ClientID,
+		ClientSecret: issuer.ClientSecret,
+	}}
+
+	t.Cleanup(func() {
+		issuer.Close()
+		c.Config.OIDC.Providers = providers
+	})
+
+	return issuer
+}
+
+// oidcCallback authorizes with the mock issuer and follows its redirect back to the callback
+func oidcCallback(t *testing.T, req *httpRequest, issuer *tests.OIDCIssuer, authURL string) *httpResponse {
+	q, err := issuer.Authorize(authURL)
+	require.NoError(t, err)
+	return req.getURL(srv.URL + c.Web.Reverse(routeNameOIDCCallback, "test") + "?" + q.Encode())
+}
@@ -3,9 +3,11 @@ package routes
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/mikestefanello/pagoda/ent"
 	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/audit"
 	"github.com/mikestefanello/pagoda/pkg/context"
 	"github.com/mikestefanello/pagoda/pkg/controller"
 	"github.com/mikestefanello/pagoda/pkg/msg"
@@ -14,6 +16,10 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// loginLockedMessage is the message shown when logging in to an account which is locked
+const loginLockedMessage = "This account has been temporarily locked after too many failed login attempts. " +
+	"Check your email for a link to unlock it, or try again later."
+
 type (
 	login struct {
 		controller.Controller
@@ -32,6 +38,7 @@ func (c *login) Get(ctx echo.Context) error {
 	page.Name = templates.PageLogin
 	page.Title = "Log in"
 	page.Form = loginForm{}
+	page.Data = c.Container.OIDC.Providers()
 
 	if form := ctx.Get(context.FormKey); form != nil {
 		page.Form = form.(*loginForm)
@@ -44,9 +51,34 @@ func (c *login) Post(ctx echo.Context) error {
 	var form loginForm
 	ctx.Set(context.FormKey, &form)
 
-	authFailed := func() error {
+	authFailed := func(u *ent.User) error {
+		var userID int
+		if u != nil {
+			userID = u.ID
+		}
+
+		err := audit.Record(ctx.Request().Context(), c.Container.ORM, audit.Event{
+			Action:  audit.LoginFailed,
+			UserID:  userID,
+			Details: form.Email,
+		})
+		if err != nil {
+			return c.Fail(err, "unable to record failed login")
+		}
+
+		locked, err := failLogin(ctx, &c.Controller, form.Email, u)
+		if err != nil {
+			return c.Fail(err, "unable to record failed login attempt")
+		}
+
 		form.Submission.SetFieldError("Email", "")
 		form.Submission.SetFieldError("Password", "")
+
+		if locked {
+			msg.Danger(ctx, loginLockedMessage)
+			return c.Get(ctx)
+		}
+
 		msg.Danger(ctx, "Invalid credentials. Please try again.")
 		return c.Get(ctx)
 	}
@@ -64,6 +96,15 @@ func (c *login) Post(ctx echo.Context) error {
 		return c.Get(ctx)
 	}
 
+	// Refuse the attempt if there have been too many failed attempts, otherwise slow it down progressively
+	refused, err := throttleLogin(ctx, &c.Controller, form.Email)
+	if err != nil {
+		return c.Fail(err, "unable to check failed login attempts")
+	}
+	if refused {
+		return c.Get(ctx)
+	}
+
 	// Attempt to load the user
 	u, err := c.Container.ORM.User.
 		Query().
@@ -72,7 +113,7 @@ func (c *login) Post(ctx echo.Context) error {
 
 	switch err.(type) {
 	case *ent.NotFoundError:
-		return authFailed()
+		return authFailed(nil)
 	case nil:
 	default:
 		return c.Fail(err, "error querying user during login")
@@ -81,7 +122,21 @@ func (c *login) Post(ctx echo.Context) error {
 	// Check if the password is correct
 	err = c.Container.Auth.CheckPassword(form.Password, u.Password)
 	if err != nil {
-		return authFailed()
+		return authFailed(u)
+	}
+
+	// Banned users cannot log in
+	if u.BannedAt != nil {
+		msg.Danger(ctx, "Your account has been suspended.")
+		return c.Get(ctx)
+	}
+
+	// Users with two-factor authentication enabled must enter a code before they are logged in
+	if u.TotpSecret != nil {
+		if err = c.Container.Auth.StartTwoFactorLogin(ctx, u.ID); err != nil {
+			return c.Fail(err, "unable to start two-factor login")
+		}
+		return c.Redirect(ctx, routeNameLoginTwoFactor)
 	}
 
 	// Log the user in
@@ -90,6 +145,85 @@ func (c *login) Post(ctx echo.Context) error {
 		return c.Fail(err, "unable to log in user")
 	}
 
+	// Failed attempts are only cleared once the login is complete, so knowing the password alone does not reset
+	// the attempts remaining to guess a two-factor code
+	if err = c.Container.Auth.ClearLoginAttempts(ctx.Request().Context(), u.Email); err != nil {
+		return c.Fail(err, "unable to clear failed login attempts")
+	}
+
 	msg.Success(ctx, fmt.Sprintf("Welcome back, <strong>%s</strong>. You are now logged in.", u.Name))
 	return c.Redirect(ctx, routeNameHome)
 }
+
+// throttleLogin refuses or delays an attempt, from either step of logging in, to log in to the account of a given
+// email address, based on the failed attempts which preceded it. If the attempt is refused, a message is set and
+// true is returned.
+func throttleLogin(ctx echo.Context, c *controller.Controller, email string) (bool, error) {
+	throttle, err := c.Container.Auth.LoginThrottle(ctx.Request().Context(), ctx.RealIP(), email)
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case throttle.Blocked:
+		msg.Danger(ctx, "Too many failed login attempts. Please try again later.")
+		return true, nil
+	case throttle.Locked():
+		msg.Danger(ctx, loginLockedMessage)
+		return true, nil
+	}
+
+	if throttle.Delay > 0 {
+		select {
+		case <-time.After(throttle.Delay):
+		case <-ctx.Request().Context().Done():
+			return false, ctx.Request().Context().Err()
+		}
+	}
+
+	return false, nil
+}
+
+// failLogin records a failed attempt, from either step of logging in, to log in to the account of a given email
+// address and returns true if the attempt locked the account. The user, if the account exists, is emailed a link
+// to unlock it. Accounts which do not exist are locked too, so the response does not reveal which do.
+func failLogin(ctx echo.Context, c *controller.Controller, email string, u *ent.User) (bool, error) {
+	locked, err := c.Container.Auth.FailLogin(ctx.Request().Context(), ctx.RealIP(), email)
+	if err != nil || !locked || u == nil {
+		return locked, err
+	}
+
+	return true, notifyLocked(ctx, c, u)
+}
+
+// notifyLocked emails a given user, whose account was just locked, a link to unlock it
+func notifyLocked(ctx echo.Context, c *controller.Controller, u *ent.User) error {
+	token, err := c.Container.Auth.GenerateUnlockToken(u.Email)
+	if err != nil {
+		return err
+	}
+
+	err = audit.Record(ctx.Request().Context(), c.Container.ORM, audit.Event{
+		Action:      audit.AccountLocked,
+		UserID:      u.ID,
+		SubjectType: user.Label,
+		SubjectID:   u.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger().Infof("locked account of user %d after too many failed login attempts", u.ID)
+
+	return c.Container.Mail.
+		Compose().
+		To(u.Email).
+		Template("account-locked").
+		TemplateData(emailLink{
+			Name:       u.Name,
+			URL:        c.AbsoluteURL(ctx.Echo().Reverse(routeNameUnlockAccount, token)),
+			Expiration: fmt.Sprintf("%d minutes", int(c.Container.Config.App.Login.LockoutDuration.Minutes())),
+		}).
+		Locale(ctx.Request().Header.Get("Accept-Language")).
+		SendAsync()
+}
@@ -0,0 +1,65 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	loginPasskey struct {
+		controller.Controller
+	}
+
+	loginPasskeyForm struct {
+		Credential string `form:"credential"`
+	}
+)
+
+// Options starts logging in with a passkey and returns the options for navigator.credentials.get() as JSON
+func (c *loginPasskey) Options(ctx echo.Context) error {
+	assertion, err := c.Container.Auth.BeginPasskeyLogin(ctx)
+	if err != nil {
+		return c.Fail(err, "unable to begin passkey login")
+	}
+
+	return ctx.JSON(http.StatusOK, assertion)
+}
+
+// Post logs the user in with the passkey returned by navigator.credentials.get()
+func (c *loginPasskey) Post(ctx echo.Context) error {
+	var form loginPasskeyForm
+	if err := ctx.Bind(&form); err != nil {
+		return c.Fail(err, "unable to parse passkey login form")
+	}
+
+	u, err := c.Container.Auth.FinishPasskeyLogin(ctx, form.Credential)
+	switch err.(type) {
+	case nil:
+	case services.InvalidPasskeyError:
+		msg.Danger(ctx, "Unable to log in with that passkey. Please try again.")
+		return c.Redirect(ctx, routeNameLogin)
+	default:
+		return c.Fail(err, "unable to verify passkey")
+	}
+
+	// Banned users cannot log in
+	if u.BannedAt != nil {
+		msg.Danger(ctx, "Your account has been suspended.")
+		return c.Redirect(ctx, routeNameLogin)
+	}
+
+	// Passkeys require user verification, such as a fingerprint or PIN, so two-factor authentication is not needed
+	err = c.Container.Auth.Login(ctx, u.ID)
+	if err != nil {
+		return c.Fail(err, "unable to log in user")
+	}
+
+	msg.Success(ctx, fmt.Sprintf("Welcome back, <strong>%s</strong>. You are now logged in.", u.Name))
+	return c.Redirect(ctx, routeNameHome)
+}
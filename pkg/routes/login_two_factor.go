@@ -0,0 +1,160 @@
+package routes
+
+import (
+	"fmt"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/recoverycode"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/audit"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/services"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	loginTwoFactor struct {
+		controller.Controller
+	}
+
+	loginTwoFactorForm struct {
+		Code       string `form:"code" validate:"required"`
+		Submission controller.FormSubmission
+	}
+)
+
+func (c *loginTwoFactor) Get(ctx echo.Context) error {
+	if _, err := c.Container.Auth.GetTwoFactorUserID(ctx); err != nil {
+		return c.restart(ctx)
+	}
+
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutAuth
+	page.Name = templates.PageLoginTwoFactor
+	page.Title = "Two-factor authentication"
+	page.Form = loginTwoFactorForm{}
+
+	if form := ctx.Get(context.FormKey); form != nil {
+		page.Form = form.(*loginTwoFactorForm)
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *loginTwoFactor) Post(ctx echo.Context) error {
+	var form loginTwoFactorForm
+	ctx.Set(context.FormKey, &form)
+
+	userID, err := c.Container.Auth.GetTwoFactorUserID(ctx)
+	if err != nil {
+		return c.restart(ctx)
+	}
+
+	// Parse the form values
+	if err = ctx.Bind(&form); err != nil {
+		return c.Fail(err, "unable to parse two-factor login form")
+	}
+
+	if err = form.Submission.Process(ctx, form); err != nil {
+		return c.Fail(err, "unable to process form submission")
+	}
+
+	if form.Submission.HasErrors() {
+		return c.Get(ctx)
+	}
+
+	u, err := c.Container.ORM.User.Get(ctx.Request().Context(), userID)
+	switch err.(type) {
+	case *ent.NotFoundError:
+		return c.restart(ctx)
+	case nil:
+	default:
+		return c.Fail(err, "error querying user during two-factor login")
+	}
+
+	// Users banned since the first step cannot log in, as the pending login is not a session which is revoked
+	if u.BannedAt != nil {
+		msg.Danger(ctx, "Your account has been suspended.")
+		return c.Redirect(ctx, routeNameLogin)
+	}
+
+	// The second step is throttled along with the first so the attempts to guess a code are limited by the lockout
+	refused, err := throttleLogin(ctx, &c.Controller, u.Email)
+	if err != nil {
+		return c.Fail(err, "unable to check failed login attempts")
+	}
+	if refused {
+		return c.Redirect(ctx, routeNameLogin)
+	}
+
+	// Check the TOTP or recovery code
+	recovery, err := c.Container.Auth.ValidateTwoFactorCode(ctx.Request().Context(), u, form.Code)
+	switch err.(type) {
+	case nil:
+	case services.InvalidTwoFactorCodeError:
+		if err = c.Container.Auth.FailTwoFactorLogin(ctx.Request().Context(), u.ID); err != nil {
+			return c.Fail(err, "unable to record failed two-factor login")
+		}
+
+		err = audit.Record(ctx.Request().Context(), c.Container.ORM, audit.Event{
+			Action:  audit.LoginFailed,
+			UserID:  u.ID,
+			Details: "Invalid two-factor code",
+		})
+		if err != nil {
+			return c.Fail(err, "unable to record failed login")
+		}
+
+		locked, err := failLogin(ctx, &c.Controller, u.Email, u)
+		if err != nil {
+			return c.Fail(err, "unable to record failed login attempt")
+		}
+		if locked {
+			msg.Danger(ctx, loginLockedMessage)
+			return c.Redirect(ctx, routeNameLogin)
+		}
+
+		form.Submission.SetFieldError("Code", "")
+		msg.Danger(ctx, "Invalid code. Please try again.")
+		return c.Get(ctx)
+	default:
+		return c.Fail(err, "unable to validate two-factor code")
+	}
+
+	// Log the user in
+	err = c.Container.Auth.Login(ctx, u.ID)
+	if err != nil {
+		return c.Fail(err, "unable to log in user")
+	}
+
+	if err = c.Container.Auth.ClearLoginAttempts(ctx.Request().Context(), u.Email); err != nil {
+		return c.Fail(err, "unable to clear failed login attempts")
+	}
+
+	if recovery {
+		remaining, err := c.Container.ORM.RecoveryCode.
+			Query().
+			Where(recoverycode.HasUserWith(user.ID(u.ID))).
+			Count(ctx.Request().Context())
+
+		if err != nil {
+			return c.Fail(err, "unable to count recovery codes")
+		}
+
+		msg.Warning(ctx, fmt.Sprintf("You logged in with a recovery code and have %d remaining.", remaining))
+	}
+
+	msg.Success(ctx, fmt.Sprintf("Welcome back, <strong>%s</strong>. You are now logged in.", u.Name))
+	return c.Redirect(ctx, routeNameHome)
+}
+
+// restart sends the user back to the login form when the second step was never started, has expired or had too
+// many incorrect codes entered
+func (c *loginTwoFactor) restart(ctx echo.Context) error {
+	msg.Warning(ctx, "Your login has expired or too many incorrect codes were entered. Please try logging in again later.")
+	return c.Redirect(ctx, routeNameLogin)
+}
@@ -0,0 +1,325 @@
+package routes
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/media"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/funcmap"
+	"github.com/mikestefanello/pagoda/pkg/imaging"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/storage"
+	"github.com/mikestefanello/pagoda/pkg/tasks"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+// mediaExtensions stores the preferred file extensions of common content types since the standard library
+// does not have a preference when a content type has multiple extensions
+var mediaExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+type (
+	mediaLibrary struct {
+		controller.Controller
+	}
+
+	mediaDelete struct {
+		controller.Controller
+	}
+
+	mediaForm struct {
+		Post       int `form:"post"`
+		Submission controller.FormSubmission
+	}
+
+	mediaLibraryData struct {
+		Items []mediaItem
+		Posts []*ent.Post
+	}
+
+	// mediaItem is uploaded media along with the URLs it and its variants can be accessed at
+	mediaItem struct {
+		*ent.Media
+		URL     string
+		Sources []funcmap.ImageSource
+	}
+)
+
+func (c *mediaLibrary) Get(ctx echo.Context) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageMedia
+	page.Title = "Media"
+	page.Pager = controller.NewPager(ctx, controller.DefaultItemsPerPage)
+
+	u := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+	query := c.Container.ORM.Media.
+		Query().
+		Where(media.HasOwnerWith(user.ID(u.ID)))
+
+	count, err := query.Clone().Count(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to count media")
+	}
+	page.Pager.SetItems(count)
+
+	items, err := query.
+		WithPost().
+		Order(ent.Desc(media.FieldCreatedAt), ent.Desc(media.FieldID)).
+		Offset(page.Pager.GetOffset()).
+		Limit(page.Pager.ItemsPerPage).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to query media")
+	}
+
+	posts, err := u.QueryPosts().
+		Order(ent.Desc(post.FieldUpdatedAt)).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to query posts")
+	}
+
+	data := mediaLibraryData{
+		Items: make([]mediaItem, 0, len(items)),
+		Posts: posts,
+	}
+	for _, m := range items {
+		data.Items = append(data.Items, newMediaItem(c.Container.Storage, m))
+	}
+	page.Data = data
+
+	// Preselect the post when coming from the post edit page
+	form := mediaForm{}
+	form.Post, _ = strconv.Atoi(ctx.QueryParam("post"))
+	page.Form = form
+
+	if form := ctx.Get(context.FormKey); form != nil {
+		page.Form = form.(*mediaForm)
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *mediaLibrary) Post(ctx echo.Context) error {
+	var form mediaForm
+	ctx.Set(context.FormKey, &form)
+
+	// Parse the form values
+	if err := ctx.Bind(&form); err != nil {
+		return c.Fail(err, "unable to parse media form")
+	}
+
+	if err := form.Submission.Process(ctx, form); err != nil {
+		return c.Fail(err, "unable to process form submission")
+	}
+
+	file, err := form.Submission.ProcessFile(ctx, controller.FileField{
+		Name:         "File",
+		Key:          "file",
+		Required:     true,
+		MaxSize:      c.Container.Config.Storage.MaxUploadSize,
+		AllowedTypes: c.Container.Config.Storage.AllowedTypes,
+	})
+	if err != nil {
+		return c.Fail(err, "unable to process uploaded file")
+	}
+
+	if form.Submission.HasErrors() {
+		return c.Get(ctx)
+	}
+
+	// Media can only be attached to posts of the uploader
+	u := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+	var p *ent.Post
+	if form.Post != 0 {
+		p, err = u.QueryPosts().
+			Where(post.ID(form.Post)).
+			Only(ctx.Request().Context())
+
+		switch err.(type) {
+		case nil:
+		case *ent.NotFoundError:
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid post")
+		default:
+			return c.Fail(err, "unable to query post")
+		}
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return c.Fail(err, "unable to open uploaded file")
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return c.Fail(err, "unable to read uploaded file")
+	}
+
+	// Stored files are public so the metadata of images, which can include the location a photo was taken at,
+	// is removed before they are stored
+	contentType := file.ContentType
+	if imaging.IsSupported(contentType) {
+		data, contentType, err = imaging.Reencode(data, contentType)
+		if err != nil {
+			form.Submission.SetFieldError("File", "The image could not be read.")
+			return c.Get(ctx)
+		}
+	}
+
+	key, err := mediaKey(contentType)
+	if err != nil {
+		return c.Fail(err, "unable to generate media key")
+	}
+
+	err = c.Container.Storage.Put(ctx.Request().Context(), key, bytes.NewReader(data), int64(len(data)), contentType)
+	if err != nil {
+		return c.Fail(err, "unable to store uploaded file")
+	}
+
+	create := c.Container.ORM.Media.
+		Create().
+		SetKey(key).
+		SetFilename(path.Base(file.Header.Filename)).
+		SetContentType(contentType).
+		SetSize(int64(len(data))).
+		SetOwner(u)
+
+	if p != nil {
+		create.SetPost(p)
+	}
+
+	m, err := create.Save(ctx.Request().Context())
+	if err != nil {
+		// Do not leave the file behind without a record of it
+		if err := c.Container.Storage.Delete(ctx.Request().Context(), key); err != nil {
+			ctx.Logger().Errorf("unable to delete stored file: %v", err)
+		}
+		return c.Fail(err, "unable to create media")
+	}
+
+	ctx.Logger().Infof("media uploaded: %d", m.ID)
+
+	// Generate the variants of images in the background
+	if imaging.IsSupported(m.ContentType) {
+		err = c.Container.Tasks.
+			New(tasks.TypeProcessMedia).
+			Payload(tasks.ProcessMediaPayload{MediaID: m.ID}).
+			Save()
+
+		if err != nil {
+			ctx.Logger().Errorf("unable to queue media processing: %v", err)
+		}
+	}
+
+	msg.Success(ctx, fmt.Sprintf("%s has been uploaded.", m.Filename))
+
+	if p != nil {
+		return c.Redirect(ctx, routeNamePostEdit, p.ID)
+	}
+	return c.Redirect(ctx, routeNameMedia)
+}
+
+func (c *mediaDelete) Post(ctx echo.Context) error {
+	m := ctx.Get(context.MediaKey).(*ent.Media)
+	u := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+	if m.Edges.Owner == nil || m.Edges.Owner.ID != u.ID {
+		return echo.NewHTTPError(http.StatusForbidden)
+	}
+
+	err := c.Container.ORM.Media.
+		DeleteOne(m).
+		Exec(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to delete media")
+	}
+
+	keys := []string{m.Key}
+	for _, v := range m.Variants {
+		keys = append(keys, v.Key)
+	}
+	for _, key := range keys {
+		if err = c.Container.Storage.Delete(ctx.Request().Context(), key); err != nil {
+			ctx.Logger().Errorf("unable to delete stored file: %v", err)
+		}
+	}
+
+	ctx.Logger().Infof("media deleted: %d", m.ID)
+	msg.Success(ctx, fmt.Sprintf("%s has been deleted.", m.Filename))
+	return c.Redirect(ctx, routeNameMedia)
+}
+
+// newMediaItem creates a mediaItem for media, resolving the URLs from where it is stored
+func newMediaItem(s storage.Storage, m *ent.Media) mediaItem {
+	item := mediaItem{
+		Media:   m,
+		URL:     s.URL(m.Key),
+		Sources: make([]funcmap.ImageSource, 0, len(m.Variants)),
+	}
+	for _, v := range m.Variants {
+		item.Sources = append(item.Sources, funcmap.ImageSource{
+			URL:         s.URL(v.Key),
+			ContentType: v.ContentType,
+			Width:       v.Width,
+			Height:      v.Height,
+		})
+	}
+	return item
+}
+
+// IsImage determines if the media is an image which can be displayed inline
+func (m mediaItem) IsImage() bool {
+	return strings.HasPrefix(m.ContentType, "image/")
+}
+
+// Markdown returns the Markdown used to reference the media within the body of a post
+func (m mediaItem) Markdown() string {
+	if m.IsImage() {
+		return fmt.Sprintf("![%s](%s)", m.Filename, m.URL)
+	}
+	return fmt.Sprintf("[%s](%s)", m.Filename, m.URL)
+}
+
+// mediaKey generates a unique storage key for an uploaded file, grouped by the month it was uploaded in.
+// The extension is based on the detected content type, rather than the filename, so the file can never be
+// served as a different type than what it contains.
+func mediaKey(contentType string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	key := path.Join(time.Now().Format("2006/01"), hex.EncodeToString(b))
+	if ext, ok := mediaExtensions[contentType]; ok {
+		return key + ext, nil
+	}
+	if exts, err := mime.ExtensionsByType(contentType); err == nil && len(exts) > 0 {
+		return key + exts[0], nil
+	}
+	return key, nil
+}
@@ -0,0 +1,70 @@
+package routes
+
+import (
+	"bytes"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/pkg/funcmap"
+	"github.com/mikestefanello/pagoda/pkg/imaging"
+	"github.com/mikestefanello/pagoda/pkg/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMediaKey(t *testing.T) {
+	key, err := mediaKey("image/jpeg")
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^\d{4}/\d{2}/[a-f0-9]{32}\.jpg$`), key)
+
+	other, err := mediaKey("image/jpeg")
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+
+	key, err = mediaKey("application/x-unknown")
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^\d{4}/\d{2}/[a-f0-9]{32}$`), key)
+}
+
+func TestMediaItem_Markdown(t *testing.T) {
+	item := mediaItem{
+		Media: &ent.Media{Filename: "photo.png", ContentType: "image/png"},
+		URL:   "/uploads/photo.png",
+	}
+	assert.True(t, item.IsImage())
+	assert.Equal(t, "![photo.png](/uploads/photo.png)", item.Markdown())
+
+	item.ContentType = "application/pdf"
+	assert.False(t, item.IsImage())
+	assert.Equal(t, "[photo.png](/uploads/photo.png)", item.Markdown())
+}
+
+func TestNewMediaItem(t *testing.T) {
+	s, err := storage.NewLocalStorage(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+
+	m := &ent.Media{
+		Key:         "2024/01/abc.png",
+		ContentType: "image/png",
+		Variants: []imaging.Variant{
+			{Name: "thumbnail", Key: "2024/01/abc_thumbnail.jpg", ContentType: "image/jpeg", Width: 150, Height: 100},
+		},
+	}
+	item := newMediaItem(s, m)
+	assert.Equal(t, "/uploads/2024/01/abc.png", item.URL)
+	assert.Equal(t, []funcmap.ImageSource{
+		{URL: "/uploads/2024/01/abc_thumbnail.jpg", ContentType: "image/jpeg", Width: 150, Height: 100},
+	}, item.Sources)
+}
+
+func TestMediaUpload_BodyLimit(t *testing.T) {
+	// Bodies larger than an upload are rejected before they are read, even before authentication
+	body := bytes.Repeat([]byte("a"), int(c.Config.Storage.MaxUploadSize+maxBodyOverhead+1))
+	resp, err := http.Post(srv.URL+c.Web.Reverse(routeNameMediaUpload), "multipart/form-data; boundary=x", bytes.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+}
@@ -0,0 +1,209 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/subscriber"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/paths"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	newsletter struct {
+		controller.Controller
+	}
+
+	newsletterForm struct {
+		Email      string `form:"email" validate:"required,email"`
+		Submission controller.FormSubmission
+	}
+
+	newsletterConfirm struct {
+		controller.Controller
+	}
+
+	newsletterUnsubscribe struct {
+		controller.Controller
+	}
+)
+
+func (c *newsletter) Get(ctx echo.Context) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageNewsletter
+	page.Title = "Newsletter"
+	page.Form = newsletterForm{}
+
+	if form := ctx.Get(context.FormKey); form != nil {
+		page.Form = form.(*newsletterForm)
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *newsletter) Post(ctx echo.Context) error {
+	var form newsletterForm
+	ctx.Set(context.FormKey, &form)
+
+	// Parse the form values
+	if err := ctx.Bind(&form); err != nil {
+		return c.Fail(err, "unable to bind form")
+	}
+
+	if err := form.Submission.Process(ctx, form); err != nil {
+		return c.Fail(err, "unable to process form submission")
+	}
+
+	if form.Submission.HasErrors() {
+		return c.Get(ctx)
+	}
+
+	// Load the subscriber, if they have subscribed before, otherwise create them
+	sub, err := c.Container.ORM.Subscriber.
+		Query().
+		Where(subscriber.Email(strings.ToLower(form.Email))).
+		Only(ctx.Request().Context())
+
+	switch err.(type) {
+	case nil:
+	case *ent.NotFoundError:
+		sub, err = c.Container.ORM.Subscriber.
+			Create().
+			SetEmail(form.Email).
+			Save(ctx.Request().Context())
+
+		if err != nil {
+			return c.Fail(err, "unable to create subscriber")
+		}
+		ctx.Logger().Infof("subscriber created: %d", sub.ID)
+	default:
+		return c.Fail(err, "unable to query subscriber")
+	}
+
+	// Send the confirmation email, unless the subscription is already confirmed, in which case the same
+	// message is shown so the form does not reveal who is subscribed
+	if sub.ConfirmedAt == nil {
+		token, err := c.Container.Auth.GenerateSubscriptionToken(sub.Email)
+		if err != nil {
+			return c.Fail(err, "unable to generate subscription token")
+		}
+
+		err = c.Container.Mail.
+			Compose().
+			To(sub.Email).
+			Template("newsletter-confirm").
+			TemplateData(emailLink{
+				URL:        c.AbsoluteURL(ctx.Echo().Reverse(routeNameNewsletterConfirm, token)),
+				Expiration: fmt.Sprintf("%d hours", int(c.Container.Config.Newsletter.ConfirmationTokenExpiration.Hours())),
+			}).
+			Locale(ctx.Request().Header.Get("Accept-Language")).
+			SendAsync()
+
+		if err != nil {
+			return c.Fail(err, "unable to send subscription confirmation email")
+		}
+	}
+
+	ctx.Set(context.FormKey, nil)
+	msg.Success(ctx, "Thanks! Check your inbox for an email to confirm your subscription.")
+	return c.Get(ctx)
+}
+
+func (c *newsletterConfirm) Get(ctx echo.Context) error {
+	email, err := c.Container.Auth.ValidateSubscriptionToken(ctx.Param("token"))
+	if err != nil {
+		msg.Warning(ctx, "The link is either invalid or has expired.")
+		return c.Redirect(ctx, routeNameNewsletter)
+	}
+
+	sub, err := c.Container.ORM.Subscriber.
+		Query().
+		Where(subscriber.Email(email)).
+		Only(ctx.Request().Context())
+
+	switch err.(type) {
+	case nil:
+	case *ent.NotFoundError:
+		// The subscriber unsubscribed before confirming
+		msg.Warning(ctx, "The link is either invalid or has expired.")
+		return c.Redirect(ctx, routeNameNewsletter)
+	default:
+		return c.Fail(err, "unable to query subscriber")
+	}
+
+	if sub.ConfirmedAt == nil {
+		err = sub.Update().
+			SetConfirmedAt(time.Now()).
+			Exec(ctx.Request().Context())
+
+		if err != nil {
+			return c.Fail(err, "unable to confirm subscriber")
+		}
+		ctx.Logger().Infof("subscriber confirmed: %d", sub.ID)
+	}
+
+	msg.Success(ctx, "Your subscription has been confirmed.")
+	return c.Redirect(ctx, routeNameHome)
+}
+
+func (c *newsletterUnsubscribe) Get(ctx echo.Context) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageNewsletterUnsubscribe
+	page.Title = "Unsubscribe"
+
+	email, err := c.Container.Auth.ValidateUnsubscribeToken(ctx.Param("token"))
+	if err != nil {
+		msg.Warning(ctx, "The link is invalid.")
+		return c.Redirect(ctx, routeNameHome)
+	}
+	page.Data = email
+
+	return c.RenderPage(ctx, page)
+}
+
+// Post unsubscribes from the newsletter. This also handles one-click unsubscribe requests, as per RFC 8058,
+// which mail clients send to the URL in the List-Unsubscribe header.
+func (c *newsletterUnsubscribe) Post(ctx echo.Context) error {
+	email, err := c.Container.Auth.ValidateUnsubscribeToken(ctx.Param("token"))
+	if err != nil {
+		if isOneClickUnsubscribe(ctx) {
+			return echo.NewHTTPError(http.StatusBadRequest)
+		}
+		msg.Warning(ctx, "The link is invalid.")
+		return c.Redirect(ctx, routeNameHome)
+	}
+
+	_, err = c.Container.ORM.Subscriber.
+		Delete().
+		Where(subscriber.Email(email)).
+		Exec(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to delete subscriber")
+	}
+
+	if isOneClickUnsubscribe(ctx) {
+		return ctx.NoContent(http.StatusOK)
+	}
+
+	msg.Success(ctx, "You have been unsubscribed from the newsletter.")
+	return c.Redirect(ctx, routeNameHome)
+}
+
+// isOneClickUnsubscribe determines if the request is a one-click unsubscribe request sent by a mail client.
+// These requests do not contain a CSRF token and are instead authorized by the signed token within the URL.
+func isOneClickUnsubscribe(ctx echo.Context) bool {
+	return ctx.Request().Method == http.MethodPost &&
+		ctx.Path() == paths.NewsletterUnsubscribe &&
+		ctx.FormValue("List-Unsubscribe") == "One-Click"
+}
@@ -0,0 +1,90 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/ent/subscriber"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewsletter_Subscribe(t *testing.T) {
+	request(t).
+		setRoute(routeNameNewsletter).
+		setBody(url.Values{"email": []string{"Reader@Localhost.dev"}}).
+		post().
+		assertStatusCode(http.StatusOK)
+
+	sub, err := c.ORM.Subscriber.
+		Query().
+		Where(subscriber.Email("reader@localhost.dev")).
+		Only(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, sub.ConfirmedAt)
+}
+
+func TestNewsletter_Confirm(t *testing.T) {
+	sub, err := c.ORM.Subscriber.
+		Create().
+		SetEmail("confirm@localhost.dev").
+		Save(context.Background())
+	require.NoError(t, err)
+
+	token, err := c.Auth.GenerateSubscriptionToken(sub.Email)
+	require.NoError(t, err)
+
+	request(t).
+		setClient(http.Client{
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}).
+		setRoute(routeNameNewsletterConfirm, token).
+		get().
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameHome)
+
+	sub, err = c.ORM.Subscriber.Get(context.Background(), sub.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, sub.ConfirmedAt)
+}
+
+func TestNewsletter_OneClickUnsubscribe(t *testing.T) {
+	sub, err := c.ORM.Subscriber.
+		Create().
+		SetEmail("unsubscribe@localhost.dev").
+		Save(context.Background())
+	require.NoError(t, err)
+
+	token, err := c.Auth.GenerateUnsubscribeToken(sub.Email)
+	require.NoError(t, err)
+
+	// Mail clients send the request without a CSRF token
+	resp, err := http.PostForm(
+		srv.URL+c.Web.Reverse(routeNameNewsletterUnsubscribeSubmit, token),
+		url.Values{"List-Unsubscribe": []string{"One-Click"}},
+	)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	exists, err := c.ORM.Subscriber.
+		Query().
+		Where(subscriber.ID(sub.ID)).
+		Exist(context.Background())
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// An invalid token is rejected
+	resp, err = http.PostForm(
+		srv.URL+c.Web.Reverse(routeNameNewsletterUnsubscribeSubmit, "invalid"),
+		url.Values{"List-Unsubscribe": []string{"One-Click"}},
+	)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,142 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/htmx"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type oidcLogin struct {
+	controller.Controller
+}
+
+// Get redirects to the provider in order to log in with it
+func (c *oidcLogin) Get(ctx echo.Context) error {
+	return c.start(ctx, false)
+}
+
+// Link redirects to the provider in order to link it to the authenticated user
+func (c *oidcLogin) Link(ctx echo.Context) error {
+	return c.start(ctx, true)
+}
+
+// Callback completes signing in once the provider redirects back, either logging the user in or linking the
+// provider to the authenticated user
+func (c *oidcLogin) Callback(ctx echo.Context) error {
+	provider := ctx.Param("provider")
+
+	claims, link, err := c.Container.OIDC.Exchange(ctx, provider, c.redirectURL(ctx, provider))
+	switch err.(type) {
+	case nil:
+	case services.OIDCProviderNotFoundError:
+		return echo.NewHTTPError(http.StatusNotFound)
+	case services.InvalidOIDCLoginError:
+		msg.Danger(ctx, "Signing in was cancelled or could not be verified. Please try again.")
+		if link {
+			return c.Redirect(ctx, routeNameIdentities)
+		}
+		return c.Redirect(ctx, routeNameLogin)
+	default:
+		return c.Fail(err, "unable to complete oidc login")
+	}
+
+	if link {
+		return c.link(ctx, provider, claims)
+	}
+
+	// Users who are already logged in are not logged in as someone else
+	if ctx.Get(context.AuthenticatedUserKey) != nil {
+		return c.Redirect(ctx, routeNameHome)
+	}
+
+	u, err := c.Container.OIDC.GetUser(ctx.Request().Context(), provider, claims)
+	switch err.(type) {
+	case nil:
+	case services.IdentityNotLinkedError:
+		msg.Warning(ctx, "An account with your email address already exists. Log in and link it from your account to sign in this way.")
+		return c.Redirect(ctx, routeNameLogin)
+	case services.IdentityLinkedError:
+		msg.Danger(ctx, "Your account with this provider could not be linked. Please log in another way.")
+		return c.Redirect(ctx, routeNameLogin)
+	default:
+		return c.Fail(err, "unable to get oidc user")
+	}
+
+	// Banned users cannot log in
+	if u.BannedAt != nil {
+		msg.Danger(ctx, "Your account has been suspended.")
+		return c.Redirect(ctx, routeNameLogin)
+	}
+
+	// Users with two-factor authentication enabled must enter a code before they are logged in
+	if u.TotpSecret != nil {
+		if err = c.Container.Auth.StartTwoFactorLogin(ctx, u.ID); err != nil {
+			return c.Fail(err, "unable to start two-factor login")
+		}
+		return c.Redirect(ctx, routeNameLoginTwoFactor)
+	}
+
+	if err = c.Container.Auth.Login(ctx, u.ID); err != nil {
+		return c.Fail(err, "unable to log in user")
+	}
+
+	msg.Success(ctx, fmt.Sprintf("Welcome back, <strong>%s</strong>. You are now logged in.", u.Name))
+	return c.Redirect(ctx, routeNameHome)
+}
+
+// link links the provider to the authenticated user
+func (c *oidcLogin) link(ctx echo.Context, provider string, claims *services.OIDCClaims) error {
+	usr, ok := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized)
+	}
+
+	err := c.Container.OIDC.LinkIdentity(ctx.Request().Context(), usr.ID, provider, claims)
+	switch err.(type) {
+	case nil:
+		msg.Success(ctx, "Your account has been linked. You can now use it to log in.")
+	case services.IdentityLinkedError:
+		msg.Danger(ctx, "This account is already linked, either to you or to someone else.")
+	default:
+		return c.Fail(err, "unable to link identity")
+	}
+
+	return c.Redirect(ctx, routeNameIdentities)
+}
+
+// start redirects to the provider in order to sign in with it
+func (c *oidcLogin) start(ctx echo.Context, link bool) error {
+	provider := ctx.Param("provider")
+
+	url, err := c.Container.OIDC.AuthCodeURL(ctx, provider, c.redirectURL(ctx, provider), link)
+	switch err.(type) {
+	case nil:
+	case services.OIDCProviderNotFoundError:
+		return echo.NewHTTPError(http.StatusNotFound)
+	default:
+		return c.Fail(err, "unable to start oidc login")
+	}
+
+	if htmx.GetRequest(ctx).Boosted {
+		htmx.Response{
+			Redirect: url,
+		}.Apply(ctx)
+
+		return nil
+	}
+
+	return ctx.Redirect(http.StatusFound, url)
+}
+
+// redirectURL returns the absolute URL a given provider redirects back to, which must be registered with the provider
+func (c *oidcLogin) redirectURL(ctx echo.Context, provider string) string {
+	return c.AbsoluteURL(ctx.Echo().Reverse(routeNameOIDCCallback, provider))
+}
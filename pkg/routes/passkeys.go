@@ -0,0 +1,131 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/credential"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/services"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	passkeys struct {
+		controller.Controller
+	}
+
+	passkeyForm struct {
+		Name       string `form:"name" validate:"required"`
+		Credential string `form:"credential" validate:"required"`
+		Submission controller.FormSubmission
+	}
+)
+
+func (c *passkeys) Get(ctx echo.Context) error {
+	usr := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+
+	list, err := c.Container.ORM.Credential.
+		Query().
+		Where(credential.HasUserWith(user.ID(usr.ID))).
+		Order(ent.Desc(credential.FieldCreatedAt)).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to load passkeys")
+	}
+
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PagePasskeys
+	page.Title = "Passkeys"
+	page.Data = list
+	page.Form = passkeyForm{}
+
+	if form := ctx.Get(context.FormKey); form != nil {
+		page.Form = form.(*passkeyForm)
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+// Options starts registering a passkey and returns the options for navigator.credentials.create() as JSON
+func (c *passkeys) Options(ctx echo.Context) error {
+	usr := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+
+	creation, err := c.Container.Auth.BeginPasskeyRegistration(ctx, usr)
+	if err != nil {
+		return c.Fail(err, "unable to begin passkey registration")
+	}
+
+	return ctx.JSON(http.StatusOK, creation)
+}
+
+// Post registers the passkey returned by navigator.credentials.create()
+func (c *passkeys) Post(ctx echo.Context) error {
+	usr := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+
+	var form passkeyForm
+	ctx.Set(context.FormKey, &form)
+
+	// Parse the form values
+	if err := ctx.Bind(&form); err != nil {
+		return c.Fail(err, "unable to parse passkey form")
+	}
+
+	if err := form.Submission.Process(ctx, form); err != nil {
+		return c.Fail(err, "unable to process form submission")
+	}
+
+	if form.Submission.HasErrors() {
+		return c.Get(ctx)
+	}
+
+	_, err := c.Container.Auth.FinishPasskeyRegistration(ctx, usr, form.Name, form.Credential)
+	switch err.(type) {
+	case nil:
+	case services.InvalidPasskeyError:
+		msg.Danger(ctx, "The passkey could not be added. It may already be added, or took too long. Please try again.")
+		return c.Get(ctx)
+	default:
+		return c.Fail(err, "unable to register passkey")
+	}
+
+	msg.Success(ctx, "Your passkey has been added. You can now use it to log in.")
+	return c.Redirect(ctx, routeNamePasskeys)
+}
+
+// Delete removes one of the user's passkeys
+func (c *passkeys) Delete(ctx echo.Context) error {
+	usr := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+
+	id, err := strconv.Atoi(ctx.Param("passkey"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+
+	count, err := c.Container.ORM.Credential.
+		Delete().
+		Where(
+			credential.ID(id),
+			credential.HasUserWith(user.ID(usr.ID)),
+		).
+		Exec(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to delete passkey")
+	}
+
+	if count == 0 {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+
+	msg.Success(ctx, "The passkey has been removed.")
+	return c.Redirect(ctx, routeNamePasskeys)
+}
@@ -0,0 +1,129 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/credential"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasskeys_RequiresAuthentication(t *testing.T) {
+	request(t).
+		setRoute(routeNamePasskeys).
+		get().
+		assertStatusCode(http.StatusUnauthorized)
+}
+
+func TestPasskeys_RegisterAndDelete(t *testing.T) {
+	usr := createUser(t)
+	req := request(t).login(usr)
+	cred := registerPasskey(t, req, usr, tests.NewAuthenticator(c.Config.App.URL))
+	assert.Equal(t, "Laptop", cred.Name)
+
+	// Passkeys of other users cannot be deleted
+	request(t).
+		login(createUser(t)).
+		setRoute(routeNamePasskeyDelete, cred.ID).
+		postFrom(routeNamePasskeys).
+		assertStatusCode(http.StatusNotFound)
+
+	req.setRoute(routeNamePasskeyDelete, cred.ID).
+		postFrom(routeNamePasskeys).
+		assertStatusCode(http.StatusOK)
+
+	count, err := c.ORM.Credential.
+		Query().
+		Where(credential.HasUserWith(user.ID(usr.ID))).
+		Count(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestLoginPasskey(t *testing.T) {
+	usr := createUser(t)
+	authenticator := tests.NewAuthenticator(c.Config.App.URL)
+	registerPasskey(t, request(t).login(usr), usr, authenticator)
+
+	login := func(redirect string) *httpRequest {
+		req := request(t).noRedirects()
+
+		var assertion protocol.CredentialAssertion
+		resp := req.setRoute(routeNameLoginPasskeyOptions).
+			postFrom(routeNameLogin).
+			assertStatusCode(http.StatusOK)
+		decodeJSON(t, resp, &assertion)
+
+		response, err := authenticator.Get(&assertion)
+		require.NoError(t, err)
+
+		resp = req.setRoute(routeNameLoginPasskey).
+			setBody(url.Values{"credential": []string{response}}).
+			postFrom(routeNameLogin).
+			assertStatusCode(http.StatusFound).
+			assertRedirect(t, redirect)
+		require.NoError(t, resp.Body.Close())
+		return req
+	}
+
+	req := login(routeNameHome)
+	req.setRoute(routeNamePasskeys).
+		get().
+		assertStatusCode(http.StatusOK)
+
+	// Banned users cannot log in with a passkey
+	_, err := usr.Update().
+		SetBannedAt(time.Now()).
+		Save(context.Background())
+	require.NoError(t, err)
+
+	req = login(routeNameLogin)
+	req.setRoute(routeNamePasskeys).
+		get().
+		assertStatusCode(http.StatusUnauthorized)
+}
+
+// registerPasskey registers a passkey created by a given authenticator for a given user, who is logged in with a
+// given request
+func registerPasskey(t *testing.T, req *httpRequest, usr *ent.User, authenticator *tests.Authenticator) *ent.Credential {
+	var creation protocol.CredentialCreation
+	resp := req.setRoute(routeNamePasskeysOptions).
+		postFrom(routeNamePasskeys).
+		assertStatusCode(http.StatusOK)
+	decodeJSON(t, resp, &creation)
+
+	response, err := authenticator.Create(&creation)
+	require.NoError(t, err)
+
+	req.setRoute(routeNamePasskeysSubmit).
+		setBody(url.Values{
+			"name":       []string{"Laptop"},
+			"credential": []string{response},
+		}).
+		post().
+		assertStatusCode(http.StatusOK)
+
+	cred, err := c.ORM.Credential.
+		Query().
+		Where(credential.HasUserWith(user.ID(usr.ID))).
+		Order(ent.Desc(credential.FieldID)).
+		First(context.Background())
+	require.NoError(t, err)
+	return cred
+}
+
+// decodeJSON decodes the JSON body of a response
+func decodeJSON(t *testing.T, resp *httpResponse, v any) {
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	require.NoError(t, resp.Body.Close())
+}
@@ -0,0 +1,186 @@
+package routes
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/markdown"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+// postPublishAtLayout is the layout of the publish time submitted by the post form, which is entered in
+// the local time of the server
+const postPublishAtLayout = "2006-01-02T15:04"
+
+type (
+	postView struct {
+		controller.Controller
+	}
+
+	postViewData struct {
+		Post     *ent.Post
+		Body     *markdown.Document
+		Comments []*commentNode
+		CanEdit  bool
+	}
+
+	postForm struct {
+		Title      string `form:"title" validate:"required"`
+		Slug       string `form:"slug"`
+		Excerpt    string `form:"excerpt"`
+		Body       string `form:"body" validate:"required"`
+		Status     string `form:"status" validate:"required,oneof=draft in_review scheduled published archived"`
+		PublishAt  string `form:"publish_at"`
+		Tags       string `form:"tags"`
+		Categories string `form:"categories"`
+		Submission controller.FormSubmission
+	}
+)
+
+func (c *postView) Get(ctx echo.Context) error {
+	p, err := c.Container.ORM.Post.
+		Query().
+		Where(post.Slug(ctx.Param("slug"))).
+		WithAuthor().
+		WithTags().
+		WithCategories().
+		Only(ctx.Request().Context())
+
+	switch err.(type) {
+	case nil:
+	case *ent.NotFoundError:
+		return echo.NewHTTPError(http.StatusNotFound)
+	default:
+		return c.Fail(err, "unable to query post")
+	}
+
+	// Unpublished posts are only visible to those who can edit them
+	if p.Status != post.StatusPublished && !canEditPost(ctx, p) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+
+	body, err := c.Container.Markdown.RenderPost(ctx.Request().Context(), p)
+	if err != nil {
+		return c.Fail(err, "unable to render post")
+	}
+
+	comments, err := fetchCommentThread(ctx, p)
+	if err != nil {
+		return c.Fail(err, "unable to fetch comments")
+	}
+
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PagePost
+	page.Title = p.Title
+	page.Metatags.Description = p.Excerpt
+	page.Data = postViewData{
+		Post:     p,
+		Body:     body,
+		Comments: comments,
+		CanEdit:  canEditPost(ctx, p),
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+// isPostAuthor determines if the authenticated user is the author of a given post.
+// The post must have been loaded with its author edge.
+func isPostAuthor(ctx echo.Context, p *ent.Post) bool {
+	u, ok := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+	if !ok || p.Edges.Author == nil {
+		return false
+	}
+	return u.ID == p.Edges.Author.ID
+}
+
+// canEditPost determines if the authenticated user can edit a given post, which requires being its author unless
+// they can edit any post. The post must have been loaded with its author edge.
+func canEditPost(ctx echo.Context, p *ent.Post) bool {
+	u, _ := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+	if permission.Has(u, permission.EditAnyPost) {
+		return true
+	}
+	return permission.Has(u, permission.CreatePost) && isPostAuthor(ctx, p)
+}
+
+// canModerateComments determines if the authenticated user can moderate the comments on a given post, which
+// requires being its author unless they can moderate any comment. The post must have been loaded with its
+// author edge.
+func canModerateComments(ctx echo.Context, p *ent.Post) bool {
+	u, _ := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+	if permission.Has(u, permission.ModerateAnyComment) {
+		return true
+	}
+	return permission.Has(u, permission.ModerateComments) && isPostAuthor(ctx, p)
+}
+
+// savePost runs a given function, which saves a post, within a transaction so the revision which the hook of the
+// Post entity creates is committed along with it. Errors returned by the function are returned as they are so their
+// type can be checked.
+func savePost(ctx echo.Context, orm *ent.Client, save func(tx *ent.Tx) (*ent.Post, error)) (*ent.Post, error) {
+	tx, err := orm.Tx(ctx.Request().Context())
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := save(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// The post is detached from the transaction so it can still be queried once it is committed
+	return p.Unwrap(), nil
+}
+
+// validateStatus sets a field error if the status is changed from the current status, which is empty for new posts,
+// to published or scheduled and a given user is not permitted to publish posts
+func (f *postForm) validateStatus(u *ent.User, current post.Status) {
+	if post.Status(f.Status) == current {
+		return
+	}
+
+	switch post.Status(f.Status) {
+	case post.StatusPublished, post.StatusScheduled:
+		if !permission.Has(u, permission.PublishPost) {
+			f.Submission.SetFieldError("Status", "You can only save drafts or submit posts for review.")
+		}
+	}
+}
+
+// validatePublishAt parses the publish time of a scheduled post, setting a field error if it is missing,
+// invalid or not in the future. Nil is returned if the post is not scheduled.
+func (f *postForm) validatePublishAt() *time.Time {
+	if f.Status != string(post.StatusScheduled) {
+		return nil
+	}
+
+	if f.PublishAt == "" {
+		f.Submission.SetFieldError("PublishAt", "A publish time is required for scheduled posts.")
+		return nil
+	}
+
+	publishAt, err := time.ParseInLocation(postPublishAtLayout, f.PublishAt, time.Local)
+	switch {
+	case err != nil:
+		f.Submission.SetFieldError("PublishAt", "Invalid value.")
+		return nil
+	case !publishAt.After(time.Now()):
+		f.Submission.SetFieldError("PublishAt", "The publish time must be in the future.")
+		return nil
+	}
+
+	return &publishAt
+}
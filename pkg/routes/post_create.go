@@ -0,0 +1,117 @@
+package routes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/tasks"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type postCreate struct {
+	controller.Controller
+}
+
+func (c *postCreate) Get(ctx echo.Context) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PagePostEdit
+	page.Title = "New post"
+	page.Form = postForm{Status: string(post.StatusDraft)}
+
+	if form := ctx.Get(context.FormKey); form != nil {
+		page.Form = form.(*postForm)
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *postCreate) Post(ctx echo.Context) error {
+	var form postForm
+	ctx.Set(context.FormKey, &form)
+
+	// Parse the form values
+	if err := ctx.Bind(&form); err != nil {
+		return c.Fail(err, "unable to parse post form")
+	}
+
+	if err := form.Submission.Process(ctx, form); err != nil {
+		return c.Fail(err, "unable to process form submission")
+	}
+
+	publishAt := form.validatePublishAt()
+	form.validateStatus(ctx.Get(context.AuthenticatedUserKey).(*ent.User), "")
+
+	if form.Submission.HasErrors() {
+		return c.Get(ctx)
+	}
+
+	// Attempt creating the post, along with any new tags and categories
+	var tags []*ent.Tag
+	var categories []*ent.Category
+	p, err := savePost(ctx, c.Container.ORM, func(tx *ent.Tx) (*ent.Post, error) {
+		var err error
+		if tags, err = loadTags(ctx.Request().Context(), tx.Client(), parseTaxonomyNames(form.Tags)); err != nil {
+			return nil, fmt.Errorf("unable to load tags: %w", err)
+		}
+
+		if categories, err = loadCategories(ctx.Request().Context(), tx.Client(), parseTaxonomyNames(form.Categories)); err != nil {
+			return nil, fmt.Errorf("unable to load categories: %w", err)
+		}
+
+		create := tx.Post.
+			Create().
+			SetTitle(form.Title).
+			SetExcerpt(form.Excerpt).
+			SetBody(form.Body).
+			SetStatus(post.Status(form.Status)).
+			SetNillablePublishAt(publishAt).
+			SetAuthor(ctx.Get(context.AuthenticatedUserKey).(*ent.User)).
+			AddTags(tags...).
+			AddCategories(categories...)
+
+		if form.Slug != "" {
+			create.SetSlug(form.Slug)
+		}
+
+		if form.Status == string(post.StatusPublished) {
+			create.SetPublishedAt(time.Now())
+		}
+
+		return create.Save(ctx.Request().Context())
+	})
+
+	switch err.(type) {
+	case nil:
+		ctx.Logger().Infof("post created: %d", p.ID)
+	case *ent.ConstraintError:
+		form.Submission.SetFieldError("Slug", "This slug is already in use.")
+		return c.Get(ctx)
+	case *ent.ValidationError:
+		form.Submission.SetFieldError("Slug", "Invalid value.")
+		return c.Get(ctx)
+	default:
+		return c.Fail(err, "unable to create post")
+	}
+
+	if err = c.Container.Cache.FlushPostArchives(ctx.Request().Context(), tags, categories); err != nil {
+		ctx.Logger().Errorf("unable to flush post archives: %v", err)
+	}
+
+	if p.Status == post.StatusScheduled {
+		if err = tasks.SchedulePostPublish(c.Container.Tasks, p); err != nil {
+			ctx.Logger().Errorf("unable to schedule post: %v", err)
+			msg.Danger(ctx, "Your post could not be scheduled for publishing. Please save it again.")
+		}
+	}
+
+	msg.Success(ctx, "Your post has been created.")
+	return c.Redirect(ctx, routeNamePostEdit, p.ID)
+}
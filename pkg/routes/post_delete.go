@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/tasks"
+
+	"github.com/labstack/echo/v4"
+)
+
+type postDelete struct {
+	controller.Controller
+}
+
+func (c *postDelete) Post(ctx echo.Context) error {
+	p := ctx.Get(context.PostKey).(*ent.Post)
+	if !canEditPost(ctx, p) {
+		return echo.NewHTTPError(http.StatusForbidden)
+	}
+
+	// Load the taxonomy so the archives the post belongs to can be flushed
+	tags, err := p.QueryTags().All(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to query post tags")
+	}
+
+	categories, err := p.QueryCategories().All(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to query post categories")
+	}
+
+	err = c.Container.ORM.Post.
+		DeleteOne(p).
+		Exec(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to delete post")
+	}
+
+	if err = c.Container.Cache.FlushPostArchives(ctx.Request().Context(), tags, categories); err != nil {
+		ctx.Logger().Errorf("unable to flush post archives: %v", err)
+	}
+
+	if p.Status == post.StatusScheduled {
+		if err = tasks.CancelPostPublish(c.Container.Tasks, p.ID); err != nil {
+			ctx.Logger().Errorf("unable to cancel scheduled post: %v", err)
+		}
+	}
+
+	ctx.Logger().Infof("post deleted: %d", p.ID)
+	msg.Success(ctx, "Your post has been deleted.")
+	return c.Redirect(ctx, routeNameHome)
+}
@@ -0,0 +1,188 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+	"github.com/mikestefanello/pagoda/pkg/tasks"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type postEdit struct {
+	controller.Controller
+}
+
+func (c *postEdit) Get(ctx echo.Context) error {
+	p := ctx.Get(context.PostKey).(*ent.Post)
+	if !canEditPost(ctx, p) {
+		return echo.NewHTTPError(http.StatusForbidden)
+	}
+
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PagePostEdit
+	page.Title = "Edit post"
+	page.Data = p
+
+	tags, err := p.QueryTags().All(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to query post tags")
+	}
+
+	categories, err := p.QueryCategories().All(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to query post categories")
+	}
+
+	form := postForm{
+		Title:      p.Title,
+		Slug:       p.Slug,
+		Excerpt:    p.Excerpt,
+		Body:       p.Body,
+		Status:     string(p.Status),
+		Tags:       joinTags(tags),
+		Categories: joinCategories(categories),
+	}
+
+	if p.PublishAt != nil {
+		form.PublishAt = p.PublishAt.Local().Format(postPublishAtLayout)
+	}
+	page.Form = form
+
+	if form := ctx.Get(context.FormKey); form != nil {
+		page.Form = form.(*postForm)
+	}
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *postEdit) Post(ctx echo.Context) error {
+	p := ctx.Get(context.PostKey).(*ent.Post)
+	if !canEditPost(ctx, p) {
+		return echo.NewHTTPError(http.StatusForbidden)
+	}
+
+	var form postForm
+	ctx.Set(context.FormKey, &form)
+
+	// Parse the form values
+	if err := ctx.Bind(&form); err != nil {
+		return c.Fail(err, "unable to parse post form")
+	}
+
+	if err := form.Submission.Process(ctx, form); err != nil {
+		return c.Fail(err, "unable to process form submission")
+	}
+
+	u := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+	publishAt := form.validatePublishAt()
+	form.validateStatus(u, p.Status)
+
+	// Only those who can publish posts can change when scheduled posts are published
+	if publishAt != nil && p.PublishAt != nil && !publishAt.Equal(*p.PublishAt) && !permission.Has(u, permission.PublishPost) {
+		form.Submission.SetFieldError("PublishAt", "You cannot change when posts are published.")
+	}
+
+	if form.Submission.HasErrors() {
+		return c.Get(ctx)
+	}
+
+	// Load the current taxonomy so the archives it belongs to can be flushed
+	oldTags, err := p.QueryTags().All(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to query post tags")
+	}
+
+	oldCategories, err := p.QueryCategories().All(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to query post categories")
+	}
+
+	// Update the post, along with any new tags and categories
+	var tags []*ent.Tag
+	var categories []*ent.Category
+	wasScheduled := p.Status == post.StatusScheduled
+	p, err = savePost(ctx, c.Container.ORM, func(tx *ent.Tx) (*ent.Post, error) {
+		var err error
+		if tags, err = loadTags(ctx.Request().Context(), tx.Client(), parseTaxonomyNames(form.Tags)); err != nil {
+			return nil, fmt.Errorf("unable to load tags: %w", err)
+		}
+
+		if categories, err = loadCategories(ctx.Request().Context(), tx.Client(), parseTaxonomyNames(form.Categories)); err != nil {
+			return nil, fmt.Errorf("unable to load categories: %w", err)
+		}
+
+		update := tx.Post.
+			UpdateOne(p).
+			SetTitle(form.Title).
+			SetExcerpt(form.Excerpt).
+			SetBody(form.Body).
+			SetStatus(post.Status(form.Status)).
+			ClearTags().
+			AddTags(tags...).
+			ClearCategories().
+			AddCategories(categories...)
+
+		if form.Slug != "" {
+			update.SetSlug(form.Slug)
+		}
+
+		if publishAt != nil {
+			update.SetPublishAt(*publishAt)
+		} else {
+			update.ClearPublishAt()
+		}
+
+		if form.Status == string(post.StatusPublished) && p.PublishedAt == nil {
+			update.SetPublishedAt(time.Now())
+		}
+
+		return update.Save(ctx.Request().Context())
+	})
+
+	switch err.(type) {
+	case nil:
+		ctx.Logger().Infof("post updated: %d", p.ID)
+	case *ent.ConstraintError:
+		form.Submission.SetFieldError("Slug", "This slug is already in use.")
+		return c.Get(ctx)
+	case *ent.ValidationError:
+		form.Submission.SetFieldError("Slug", "Invalid value.")
+		return c.Get(ctx)
+	default:
+		return c.Fail(err, "unable to update post")
+	}
+
+	// Replace the task which publishes the post, or remove it if the post is no longer scheduled
+	switch {
+	case p.Status == post.StatusScheduled:
+		err = tasks.SchedulePostPublish(c.Container.Tasks, p)
+	case wasScheduled:
+		err = tasks.CancelPostPublish(c.Container.Tasks, p.ID)
+	}
+	if err != nil {
+		ctx.Logger().Errorf("unable to schedule post: %v", err)
+		msg.Danger(ctx, "The publishing schedule of your post could not be updated. Please save it again.")
+	}
+
+	err = c.Container.Cache.FlushPostArchives(
+		ctx.Request().Context(),
+		append(oldTags, tags...),
+		append(oldCategories, categories...),
+	)
+	if err != nil {
+		ctx.Logger().Errorf("unable to flush post archives: %v", err)
+	}
+
+	msg.Success(ctx, "Your post has been updated.")
+	return c.Redirect(ctx, routeNamePostEdit, p.ID)
+}
@@ -0,0 +1,145 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/postrevision"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/diff"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	postRevisions struct {
+		controller.Controller
+	}
+
+	postRevisionsData struct {
+		Post      *ent.Post
+		Revisions []*ent.PostRevision
+		From      *ent.PostRevision
+		To        *ent.PostRevision
+		Diff      []diff.Line
+	}
+)
+
+func (c *postRevisions) Get(ctx echo.Context) error {
+	p := ctx.Get(context.PostKey).(*ent.Post)
+	if !canEditPost(ctx, p) {
+		return echo.NewHTTPError(http.StatusForbidden)
+	}
+
+	revisions, err := p.QueryRevisions().
+		Order(ent.Desc(postrevision.FieldCreatedAt), ent.Desc(postrevision.FieldID)).
+		All(ctx.Request().Context())
+
+	if err != nil {
+		return c.Fail(err, "unable to query post revisions")
+	}
+
+	data := postRevisionsData{
+		Post:      p,
+		Revisions: revisions,
+	}
+
+	// Default to comparing the latest revision with the one before it
+	if len(revisions) > 0 {
+		data.To = revisions[0]
+		if len(revisions) > 1 {
+			data.From = revisions[1]
+		}
+	}
+
+	for _, r := range revisions {
+		if id, err := strconv.Atoi(ctx.QueryParam("to")); err == nil && r.ID == id {
+			data.To = r
+		}
+		if id, err := strconv.Atoi(ctx.QueryParam("from")); err == nil && r.ID == id {
+			data.From = r
+		}
+	}
+
+	if data.To != nil {
+		data.Diff = diff.Lines(revisionText(data.From), revisionText(data.To))
+	}
+
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PagePostRevisions
+	page.Title = "Revisions"
+	page.Data = data
+
+	return c.RenderPage(ctx, page)
+}
+
+func (c *postRevisions) Post(ctx echo.Context) error {
+	p := ctx.Get(context.PostKey).(*ent.Post)
+	if !canEditPost(ctx, p) {
+		return echo.NewHTTPError(http.StatusForbidden)
+	}
+
+	revisionID, err := strconv.Atoi(ctx.Param("revision"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+
+	r, err := p.QueryRevisions().
+		Where(postrevision.ID(revisionID)).
+		Only(ctx.Request().Context())
+
+	switch err.(type) {
+	case nil:
+	case *ent.NotFoundError:
+		return echo.NewHTTPError(http.StatusNotFound)
+	default:
+		return c.Fail(err, "unable to query post revision")
+	}
+
+	// Restoring the content creates a new revision, so the history is kept intact
+	p, err = savePost(ctx, c.Container.ORM, func(tx *ent.Tx) (*ent.Post, error) {
+		return tx.Post.
+			UpdateOne(p).
+			SetTitle(r.Title).
+			SetExcerpt(r.Excerpt).
+			SetBody(r.Body).
+			Save(ctx.Request().Context())
+	})
+
+	if err != nil {
+		return c.Fail(err, "unable to restore post revision")
+	}
+
+	// The title of the post is listed within its archives
+	tags, err := p.QueryTags().All(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to query post tags")
+	}
+
+	categories, err := p.QueryCategories().All(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to query post categories")
+	}
+
+	if err = c.Container.Cache.FlushPostArchives(ctx.Request().Context(), tags, categories); err != nil {
+		ctx.Logger().Errorf("unable to flush post archives: %v", err)
+	}
+
+	ctx.Logger().Infof("post revision restored: %d, %d", p.ID, r.ID)
+	msg.Success(ctx, fmt.Sprintf("The revision from %s has been restored.", r.CreatedAt.Format("Jan 2, 2006 3:04 PM")))
+	return c.Redirect(ctx, routeNamePostRevisions, p.ID)
+}
+
+// revisionText returns the content of a revision as text which can be compared line by line
+func revisionText(r *ent.PostRevision) string {
+	if r == nil {
+		return ""
+	}
+	return fmt.Sprintf("Title: %s\nExcerpt: %s\n\n%s", r.Title, r.Excerpt, r.Body)
+}
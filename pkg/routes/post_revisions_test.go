@@ -0,0 +1,80 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostRevisionHook(t *testing.T) {
+	usr, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+	p, err := tests.CreatePost(c.ORM, usr)
+	require.NoError(t, err)
+
+	count := func() int {
+		n, err := p.QueryRevisions().Count(context.Background())
+		require.NoError(t, err)
+		return n
+	}
+	assert.Equal(t, 1, count())
+
+	// Changes to the content create a revision
+	p, err = p.Update().SetBody("Updated body").Save(permission.SystemContext(context.Background()))
+	require.NoError(t, err)
+	assert.Equal(t, 2, count())
+
+	// Other changes do not
+	err = p.Update().SetStatus(post.StatusArchived).Exec(permission.SystemContext(context.Background()))
+	require.NoError(t, err)
+	assert.Equal(t, 2, count())
+}
+
+func TestSavePost(t *testing.T) {
+	usr, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+	p, err := tests.CreatePost(c.ORM, usr)
+	require.NoError(t, err)
+
+	ctx, _ := tests.NewContext(c.Web, "/")
+	ctx.SetRequest(ctx.Request().WithContext(permission.SystemContext(context.Background())))
+
+	// The post and its revision are saved together
+	p, err = savePost(ctx, c.ORM, func(tx *ent.Tx) (*ent.Post, error) {
+		return tx.Post.UpdateOne(p).SetBody("Saved body").Save(ctx.Request().Context())
+	})
+	require.NoError(t, err)
+	revisions, err := p.QueryRevisions().Count(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, revisions)
+
+	// Neither are saved if saving fails
+	_, err = savePost(ctx, c.ORM, func(tx *ent.Tx) (*ent.Post, error) {
+		if _, err := tx.Post.UpdateOne(p).SetBody("Failed body").Save(ctx.Request().Context()); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("failed")
+	})
+	require.Error(t, err)
+	revisions, err = p.QueryRevisions().Count(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, revisions)
+	p, err = c.ORM.Post.Get(context.Background(), p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Saved body", p.Body)
+}
+
+func TestRevisionText(t *testing.T) {
+	assert.Equal(t, "", revisionText(nil))
+
+	r := &ent.PostRevision{Title: "Title", Excerpt: "Excerpt", Body: "Body"}
+	assert.Equal(t, "Title: Title\nExcerpt: Excerpt\n\nBody", revisionText(r))
+}
@@ -0,0 +1,89 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostForm_ValidatePublishAt(t *testing.T) {
+	form := postForm{Status: "draft", PublishAt: "invalid"}
+	assert.Nil(t, form.validatePublishAt())
+	assert.False(t, form.Submission.HasErrors())
+
+	form = postForm{Status: "scheduled"}
+	assert.Nil(t, form.validatePublishAt())
+	assert.True(t, form.Submission.FieldHasErrors("PublishAt"))
+
+	form = postForm{Status: "scheduled", PublishAt: "invalid"}
+	assert.Nil(t, form.validatePublishAt())
+	assert.True(t, form.Submission.FieldHasErrors("PublishAt"))
+
+	form = postForm{Status: "scheduled", PublishAt: time.Now().Add(-time.Hour).Format(postPublishAtLayout)}
+	assert.Nil(t, form.validatePublishAt())
+	assert.True(t, form.Submission.FieldHasErrors("PublishAt"))
+
+	future := time.Now().Add(time.Hour).Truncate(time.Minute)
+	form = postForm{Status: "scheduled", PublishAt: future.Format(postPublishAtLayout)}
+	publishAt := form.validatePublishAt()
+	require.NotNil(t, publishAt)
+	assert.True(t, future.Equal(*publishAt))
+	assert.False(t, form.Submission.HasErrors())
+}
+
+func TestPostEdit_ContributorKeepsStatus(t *testing.T) {
+	usr := createUserWithRole(t, user.RoleContributor)
+
+	// A post of the contributor which an editor published
+	p, err := tests.CreatePost(c.ORM, usr)
+	require.NoError(t, err)
+
+	req := request(t).
+		noRedirects().
+		login(usr)
+
+	form := func(status post.Status) url.Values {
+		return url.Values{
+			"title":  []string{p.Title + " edited"},
+			"body":   []string{p.Body},
+			"status": []string{string(status)},
+		}
+	}
+
+	// The post can be edited without unpublishing it
+	req.setRoute(routeNamePostEditSubmit, p.ID).
+		setBody(form(post.StatusPublished)).
+		postFrom(routeNamePostEdit, p.ID).
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNamePostEdit, p.ID)
+
+	p, err = c.ORM.Post.Get(context.Background(), p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, post.StatusPublished, p.Status)
+	assert.True(t, strings.HasSuffix(p.Title, " edited"))
+
+	// But once unpublished, it cannot be published again
+	req.setRoute(routeNamePostEditSubmit, p.ID).
+		setBody(form(post.StatusDraft)).
+		postFrom(routeNamePostEdit, p.ID).
+		assertStatusCode(http.StatusFound)
+
+	req.setRoute(routeNamePostEditSubmit, p.ID).
+		setBody(form(post.StatusPublished)).
+		postFrom(routeNamePostEdit, p.ID).
+		assertStatusCode(http.StatusOK)
+
+	p, err = c.ORM.Post.Get(context.Background(), p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, post.StatusDraft, p.Status)
+}
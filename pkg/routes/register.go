@@ -1,8 +1,6 @@
 package routes
 
 import (
-	"fmt"
-
 	"github.com/mikestefanello/pagoda/ent"
 	"github.com/mikestefanello/pagoda/pkg/context"
 	"github.com/mikestefanello/pagoda/pkg/controller"
@@ -24,6 +22,13 @@ type (
 		ConfirmPassword string `form:"password-confirm" validate:"required,eqfield=Password"`
 		Submission      controller.FormSubmission
 	}
+
+	// emailLink is the template data of account emails which link the user back to the application
+	emailLink struct {
+		Name       string
+		URL        string
+		Expiration string
+	}
 )
 
 func (c *register) Get(ctx echo.Context) error {
@@ -106,13 +111,16 @@ func (c *register) sendVerificationEmail(ctx echo.Context, usr *ent.User) {
 	}
 
 	// Send the email
-	url := ctx.Echo().Reverse(routeNameVerifyEmail, token)
 	err = c.Container.Mail.
 		Compose().
 		To(usr.Email).
-		Subject("Confirm your email address").
-		Body(fmt.Sprintf("Click here to confirm your email address: %s", url)).
-		Send(ctx)
+		Template("verify-email").
+		TemplateData(emailLink{
+			Name: usr.Name,
+			URL:  c.AbsoluteURL(ctx.Echo().Reverse(routeNameVerifyEmail, token)),
+		}).
+		Locale(ctx.Request().Header.Get("Accept-Language")).
+		SendAsync()
 
 	if err != nil {
 		ctx.Logger().Errorf("unable to send email verification link: %v", err)
@@ -66,6 +66,7 @@ func (c *resetPassword) Post(ctx echo.Context) error {
 	_, err = usr.
 		Update().
 		SetPassword(hash).
+		SetPasswordSet(true).
 		Save(ctx.Request().Context())
 
 	if err != nil {
@@ -78,6 +79,12 @@ func (c *resetPassword) Post(ctx echo.Context) error {
 		return c.Fail(err, "unable to delete password tokens")
 	}
 
+	// Log the user out everywhere, in case the password was changed because the account was compromised
+	err = c.Container.Auth.RevokeSessions(ctx.Request().Context(), usr.ID)
+	if err != nil {
+		return c.Fail(err, "unable to revoke sessions")
+	}
+
 	msg.Success(ctx, "Your password has been updated.")
 	return c.Redirect(ctx, routeNameLogin)
 }
@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+
+	"github.com/labstack/echo/v4"
+)
+
+type robots struct {
+	controller.Controller
+}
+
+// Get renders robots.txt from the configured paths to disallow, referencing the sitemap index
+func (c *robots) Get(ctx echo.Context) error {
+	// Non-production environments such as staging and qa disallow everything so they never get indexed
+	env := c.Container.Config.App.Environment
+	body := robotsTxt(
+		env == config.EnvStaging || env == config.EnvQA,
+		c.Container.Config.Robots.Disallow,
+		c.AbsoluteURL(ctx.Echo().Reverse(routeNameSitemap)),
+	)
+
+	return ctx.String(http.StatusOK, body)
+}
+
+// robotsTxt builds the contents of robots.txt which either disallows everything or only the given paths
+func robotsTxt(disallowAll bool, disallow []string, sitemapURL string) string {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+
+	switch {
+	case disallowAll:
+		b.WriteString("Disallow: /\n")
+	case len(disallow) == 0:
+		b.WriteString("Disallow:\n")
+	default:
+		for _, path := range disallow {
+			fmt.Fprintf(&b, "Disallow: %s\n", path)
+		}
+	}
+
+	fmt.Fprintf(&b, "\nSitemap: %s\n", sitemapURL)
+	return b.String()
+}
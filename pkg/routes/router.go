@@ -2,13 +2,16 @@ package routes
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/mikestefanello/pagoda/config"
 	"github.com/mikestefanello/pagoda/pkg/controller"
 	"github.com/mikestefanello/pagoda/pkg/middleware"
+	"github.com/mikestefanello/pagoda/pkg/paths"
+	"github.com/mikestefanello/pagoda/pkg/permission"
 	"github.com/mikestefanello/pagoda/pkg/services"
+	"github.com/mikestefanello/pagoda/pkg/storage"
 
-	"github.com/gorilla/sessions"
 	"github.com/labstack/echo-contrib/session"
 
 	"github.com/labstack/echo/v4"
@@ -16,23 +19,114 @@ import (
 )
 
 const (
-	routeNameForgotPassword       = "forgot_password"
-	routeNameForgotPasswordSubmit = "forgot_password.submit"
-	routeNameLogin                = "login"
-	routeNameLoginSubmit          = "login.submit"
-	routeNameLogout               = "logout"
-	routeNameRegister             = "register"
-	routeNameRegisterSubmit       = "register.submit"
-	routeNameResetPassword        = "reset_password"
-	routeNameResetPasswordSubmit  = "reset_password.submit"
-	routeNameVerifyEmail          = "verify_email"
-	routeNameContact              = "contact"
-	routeNameContactSubmit        = "contact.submit"
-	routeNameAbout                = "about"
-	routeNameHome                 = "home"
-	routeNameSearch               = "search"
+	routeNameForgotPassword              = "forgot_password"
+	routeNameForgotPasswordSubmit        = "forgot_password.submit"
+	routeNameLogin                       = "login"
+	routeNameLoginSubmit                 = "login.submit"
+	routeNameLoginTwoFactor              = "login.two_factor"
+	routeNameLoginTwoFactorSubmit        = "login.two_factor.submit"
+	routeNameLoginPasskeyOptions         = "login.passkey.options"
+	routeNameLoginPasskey                = "login.passkey"
+	routeNameLoginOIDC                   = "login.oidc"
+	routeNameOIDCCallback                = "oidc.callback"
+	routeNameLogout                      = "logout"
+	routeNameRegister                    = "register"
+	routeNameRegisterSubmit              = "register.submit"
+	routeNameResetPassword               = "reset_password"
+	routeNameResetPasswordSubmit         = "reset_password.submit"
+	routeNameVerifyEmail                 = "verify_email"
+	routeNameUnlockAccount               = "unlock_account"
+	routeNameSessions                    = "sessions"
+	routeNameSessionRevoke               = "sessions.revoke"
+	routeNameSessionsRevokeAll           = "sessions.revoke_all"
+	routeNamePasskeys                    = "passkeys"
+	routeNamePasskeysOptions             = "passkeys.options"
+	routeNamePasskeysSubmit              = "passkeys.submit"
+	routeNamePasskeyDelete               = "passkeys.delete"
+	routeNameIdentities                  = "identities"
+	routeNameIdentityLink                = "identities.link"
+	routeNameIdentityUnlink              = "identities.unlink"
+	routeNameTwoFactor                   = "two_factor"
+	routeNameTwoFactorEnable             = "two_factor.enable"
+	routeNameTwoFactorDisable            = "two_factor.disable"
+	routeNameTwoFactorRecovery           = "two_factor.recovery"
+	routeNameContact                     = "contact"
+	routeNameContactSubmit               = "contact.submit"
+	routeNameAbout                       = "about"
+	routeNameHome                        = "home"
+	routeNameSearch                      = "search"
+	routeNamePost                        = "post"
+	routeNamePostCreate                  = "post.create"
+	routeNamePostCreateSubmit            = "post.create.submit"
+	routeNamePostEdit                    = "post.edit"
+	routeNamePostEditSubmit              = "post.edit.submit"
+	routeNamePostDelete                  = "post.delete"
+	routeNamePostRevisions               = "post.revisions"
+	routeNamePostRevisionRestore         = "post.revisions.restore"
+	routeNameTags                        = "tags"
+	routeNameTagArchive                  = "tag"
+	routeNameCategoryArchive             = "category"
+	routeNameAuthorArchive               = "author"
+	routeNameFeedRSS                     = "feed.rss"
+	routeNameFeedAtom                    = "feed.atom"
+	routeNameFeedJSON                    = "feed.json"
+	routeNameTagFeedRSS                  = "tag.feed.rss"
+	routeNameTagFeedAtom                 = "tag.feed.atom"
+	routeNameTagFeedJSON                 = "tag.feed.json"
+	routeNameAuthorFeedRSS               = "author.feed.rss"
+	routeNameAuthorFeedAtom              = "author.feed.atom"
+	routeNameAuthorFeedJSON              = "author.feed.json"
+	routeNameCommentSubmit               = "comment.submit"
+	routeNameComments                    = "comments"
+	routeNameCommentModerate             = "comment.moderate"
+	routeNameMedia                       = "media"
+	routeNameMediaUpload                 = "media.upload"
+	routeNameMediaDelete                 = "media.delete"
+	routeNameSitemap                     = "sitemap"
+	routeNameSitemapChunk                = "sitemap.chunk"
+	routeNameRobots                      = "robots"
+	routeNameAdmin                       = "admin"
+	routeNameAdminUsers                  = "admin.users"
+	routeNameAdminUserEdit               = "admin.users.edit"
+	routeNameAdminUserEditSubmit         = "admin.users.edit.submit"
+	routeNameAdminUserBan                = "admin.users.ban"
+	routeNameAdminUserUnban              = "admin.users.unban"
+	routeNameAdminPosts                  = "admin.posts"
+	routeNameAdminComments               = "admin.comments"
+	routeNameAdminCommentsSubmit         = "admin.comments.submit"
+	routeNameAdminTasks                  = "admin.tasks"
+	routeNameAdminCache                  = "admin.cache"
+	routeNameAdminCacheSubmit            = "admin.cache.submit"
+	routeNameAdminAudit                  = "admin.audit"
+	routeNameAdminAuditExport            = "admin.audit.export"
+	routeNameAdminMail                   = "admin.mail"
+	routeNameAdminMailView               = "admin.mail.view"
+	routeNameAdminMailRetry              = "admin.mail.retry"
+	routeNameAdminMailDelete             = "admin.mail.delete"
+	routeNameDevMail                     = "dev.mail"
+	routeNameDevMailView                 = "dev.mail.view"
+	routeNameDevMailHTML                 = "dev.mail.html"
+	routeNameDevMailClear                = "dev.mail.clear"
+	routeNameNewsletter                  = "newsletter"
+	routeNameNewsletterSubmit            = "newsletter.submit"
+	routeNameNewsletterConfirm           = "newsletter.confirm"
+	routeNameNewsletterUnsubscribe       = "newsletter.unsubscribe"
+	routeNameNewsletterUnsubscribeSubmit = "newsletter.unsubscribe.submit"
 )
 
+// The names of the rate limit policies declared in configuration which routes are limited by
+const (
+	rateLimitComment    = "comment"
+	rateLimitContact    = "contact"
+	rateLimitNewsletter = "newsletter"
+	rateLimitRegister   = "register"
+	rateLimitSearch     = "search"
+)
+
+// maxBodyOverhead is the amount of bytes request bodies can exceed the maximum upload size by, to allow for the
+// other fields of multipart forms and their encoding
+const maxBodyOverhead = 1 << 20
+
 // BuildRouter builds the router
 func BuildRouter(c *services.Container) {
 	// Static files with proper cache control
@@ -41,6 +135,12 @@ func BuildRouter(c *services.Container) {
 	c.Web.Group("", middleware.CacheControl(c.Config.Cache.Expiration.StaticFile)).
 		Static(config.StaticPrefix, config.StaticDir)
 
+	// Uploaded media, if it is stored on the local filesystem
+	if local, ok := c.Storage.(*storage.LocalStorage); ok {
+		c.Web.Group("", middleware.CacheControl(c.Config.Cache.Expiration.StaticFile)).
+			Static(c.Config.Storage.Local.URLPrefix, local.Directory())
+	}
+
 	// Non static file route group
 	g := c.Web.Group("")
 
@@ -59,14 +159,21 @@ func BuildRouter(c *services.Container) {
 		echomw.Gzip(),
 		echomw.Logger(),
 		middleware.LogRequestID(),
+		// The largest bodies accepted are uploads, so bodies are limited to the size of an upload before any
+		// are read, which includes the CSRF middleware parsing multipart forms
+		echomw.BodyLimitWithConfig(echomw.BodyLimitConfig{
+			Limit: strconv.FormatInt(c.Config.Storage.MaxUploadSize+maxBodyOverhead, 10),
+		}),
 		echomw.TimeoutWithConfig(echomw.TimeoutConfig{
 			Timeout: c.Config.App.Timeout,
 		}),
-		session.Middleware(sessions.NewCookieStore([]byte(c.Config.App.EncryptionKey))),
+		session.Middleware(c.Sessions),
 		middleware.LoadAuthenticatedUser(c.Auth),
 		middleware.ServeCachedPage(c.Cache),
 		echomw.CSRFWithConfig(echomw.CSRFConfig{
 			TokenLookup: "form:csrf",
+			// One-click unsubscribe requests are sent by mail clients without a token
+			Skipper: isOneClickUnsubscribe,
 		}),
 	)
 
@@ -80,6 +187,12 @@ func BuildRouter(c *services.Container) {
 	// Example routes
 	navRoutes(c, g, ctr)
 	userRoutes(c, g, ctr)
+	postRoutes(c, g, ctr)
+	feedRoutes(c, g, ctr)
+	seoRoutes(c, g, ctr)
+	newsletterRoutes(c, g, ctr)
+	adminRoutes(c, g, ctr)
+	devRoutes(c, g, ctr)
 }
 
 func navRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
@@ -87,20 +200,49 @@ func navRoutes(c *services.Container, g *echo.Group, ctr controller.Controller)
 	g.GET("/", home.Get).Name = routeNameHome
 
 	search := search{Controller: ctr}
-	g.GET("/search", search.Get).Name = routeNameSearch
+	g.GET("/search", search.Get, middleware.RateLimit(c.Config.RateLimit, c.Cache, rateLimitSearch)).Name = routeNameSearch
 
 	about := about{Controller: ctr}
 	g.GET("/about", about.Get).Name = routeNameAbout
 
 	contact := contact{Controller: ctr}
 	g.GET("/contact", contact.Get).Name = routeNameContact
-	g.POST("/contact", contact.Post).Name = routeNameContactSubmit
+	g.POST("/contact", contact.Post, middleware.RateLimit(c.Config.RateLimit, c.Cache, rateLimitContact)).Name = routeNameContactSubmit
 }
 
 func userRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
 	logout := logout{Controller: ctr}
 	g.GET("/logout", logout.Get, middleware.RequireAuthentication()).Name = routeNameLogout
 
+	sessions := sessions{Controller: ctr}
+	auth := g.Group("/user/sessions", middleware.RequireAuthentication())
+	auth.GET("", sessions.Get).Name = routeNameSessions
+	auth.POST("/revoke", sessions.RevokeAll).Name = routeNameSessionsRevokeAll
+	auth.POST("/:session/revoke", sessions.Revoke).Name = routeNameSessionRevoke
+
+	passkeys := passkeys{Controller: ctr}
+	passkeyGroup := g.Group("/user/passkeys", middleware.RequireAuthentication())
+	passkeyGroup.GET("", passkeys.Get).Name = routeNamePasskeys
+	passkeyGroup.POST("", passkeys.Post).Name = routeNamePasskeysSubmit
+	passkeyGroup.POST("/options", passkeys.Options).Name = routeNamePasskeysOptions
+	passkeyGroup.POST("/:passkey/delete", passkeys.Delete).Name = routeNamePasskeyDelete
+
+	twoFactor := twoFactor{Controller: ctr}
+	twoFactorGroup := g.Group("/user/2fa", middleware.RequireAuthentication())
+	twoFactorGroup.GET("", twoFactor.Get).Name = routeNameTwoFactor
+	twoFactorGroup.POST("/enable", twoFactor.Enable).Name = routeNameTwoFactorEnable
+	twoFactorGroup.POST("/disable", twoFactor.Disable).Name = routeNameTwoFactorDisable
+	twoFactorGroup.POST("/recovery", twoFactor.Recovery).Name = routeNameTwoFactorRecovery
+
+	oidcLogin := oidcLogin{Controller: ctr}
+	g.GET("/user/oidc/:provider/callback", oidcLogin.Callback).Name = routeNameOIDCCallback
+
+	identities := identities{Controller: ctr}
+	identityGroup := g.Group("/user/identities", middleware.RequireAuthentication())
+	identityGroup.GET("", identities.Get).Name = routeNameIdentities
+	identityGroup.POST("/:provider/link", oidcLogin.Link).Name = routeNameIdentityLink
+	identityGroup.POST("/:provider/unlink", identities.Unlink).Name = routeNameIdentityUnlink
+
 	verifyEmail := verifyEmail{Controller: ctr}
 	g.GET("/email/verify/:token", verifyEmail.Get).Name = routeNameVerifyEmail
 
@@ -109,14 +251,26 @@ func userRoutes(c *services.Container, g *echo.Group, ctr controller.Controller)
 	noAuth.GET("/login", login.Get).Name = routeNameLogin
 	noAuth.POST("/login", login.Post).Name = routeNameLoginSubmit
 
+	loginPasskey := loginPasskey{Controller: ctr}
+	noAuth.POST("/login/passkey", loginPasskey.Post).Name = routeNameLoginPasskey
+	noAuth.POST("/login/passkey/options", loginPasskey.Options).Name = routeNameLoginPasskeyOptions
+	noAuth.GET("/login/oidc/:provider", oidcLogin.Get).Name = routeNameLoginOIDC
+
+	loginTwoFactor := loginTwoFactor{Controller: ctr}
+	noAuth.GET("/login/2fa", loginTwoFactor.Get).Name = routeNameLoginTwoFactor
+	noAuth.POST("/login/2fa", loginTwoFactor.Post).Name = routeNameLoginTwoFactorSubmit
+
 	register := register{Controller: ctr}
 	noAuth.GET("/register", register.Get).Name = routeNameRegister
-	noAuth.POST("/register", register.Post).Name = routeNameRegisterSubmit
+	noAuth.POST("/register", register.Post, middleware.RateLimit(c.Config.RateLimit, c.Cache, rateLimitRegister)).Name = routeNameRegisterSubmit
 
 	forgot := forgotPassword{Controller: ctr}
 	noAuth.GET("/password", forgot.Get).Name = routeNameForgotPassword
 	noAuth.POST("/password", forgot.Post).Name = routeNameForgotPasswordSubmit
 
+	unlock := unlockAccount{Controller: ctr}
+	noAuth.GET("/unlock/:token", unlock.Get).Name = routeNameUnlockAccount
+
 	resetGroup := noAuth.Group("/password/reset",
 		middleware.LoadUser(c.ORM),
 		middleware.LoadValidPasswordToken(c.Auth),
@@ -125,3 +279,167 @@ func userRoutes(c *services.Container, g *echo.Group, ctr controller.Controller)
 	resetGroup.GET("/token/:user/:password_token/:token", reset.Get).Name = routeNameResetPassword
 	resetGroup.POST("/token/:user/:password_token/:token", reset.Post).Name = routeNameResetPasswordSubmit
 }
+
+func postRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
+	view := postView{Controller: ctr}
+	g.GET(paths.Post, view.Get).Name = routeNamePost
+
+	comment := commentCreate{Controller: ctr}
+	g.POST("/post/:slug/comments", comment.Post, middleware.RateLimit(c.Config.RateLimit, c.Cache, rateLimitComment)).Name = routeNameCommentSubmit
+
+	tags := tags{Controller: ctr}
+	g.GET("/tags", tags.Get).Name = routeNameTags
+
+	tagArchive := tagArchive{Controller: ctr}
+	g.GET("/tag/:slug", tagArchive.Get).Name = routeNameTagArchive
+
+	categoryArchive := categoryArchive{Controller: ctr}
+	g.GET("/category/:slug", categoryArchive.Get).Name = routeNameCategoryArchive
+
+	auth := g.Group("/posts", middleware.RequireAuthentication())
+	create := postCreate{Controller: ctr}
+	auth.GET("/new", create.Get, middleware.RequirePermission(permission.CreatePost)).Name = routeNamePostCreate
+	auth.POST("/new", create.Post, middleware.RequirePermission(permission.CreatePost)).Name = routeNamePostCreateSubmit
+
+	mediaGroup := auth.Group("/media", middleware.RequirePermission(permission.UploadMedia))
+	library := mediaLibrary{Controller: ctr}
+	mediaGroup.GET("", library.Get).Name = routeNameMedia
+	mediaGroup.POST("", library.Post).Name = routeNameMediaUpload
+
+	mediaDelete := mediaDelete{Controller: ctr}
+	mediaGroup.POST("/:media/delete", mediaDelete.Post, middleware.LoadMedia(c.ORM)).Name = routeNameMediaDelete
+
+	commentGroup := g.Group(
+		paths.CommentModeration,
+		middleware.RequireAuthentication(),
+		middleware.RequirePermission(permission.ModerateComments),
+	)
+	moderation := commentModeration{Controller: ctr}
+	commentGroup.GET("", moderation.Get).Name = routeNameComments
+	commentGroup.POST("/:comment", moderation.Post, middleware.LoadComment(c.ORM)).Name = routeNameCommentModerate
+
+	postGroup := auth.Group("/:post", middleware.RequirePermission(permission.CreatePost), middleware.LoadPost(c.ORM))
+	edit := postEdit{Controller: ctr}
+	postGroup.GET("/edit", edit.Get).Name = routeNamePostEdit
+	postGroup.POST("/edit", edit.Post).Name = routeNamePostEditSubmit
+
+	del := postDelete{Controller: ctr}
+	postGroup.POST("/delete", del.Post).Name = routeNamePostDelete
+
+	revisions := postRevisions{Controller: ctr}
+	postGroup.GET("/revisions", revisions.Get).Name = routeNamePostRevisions
+	postGroup.POST("/revisions/:revision/restore", revisions.Post).Name = routeNamePostRevisionRestore
+}
+
+func feedRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
+	rss := feed{Controller: ctr, format: feedFormatRSS}
+	atom := feed{Controller: ctr, format: feedFormatAtom}
+	json := feed{Controller: ctr, format: feedFormatJSON}
+
+	g.GET("/feed.xml", rss.Get).Name = routeNameFeedRSS
+	g.GET("/atom.xml", atom.Get).Name = routeNameFeedAtom
+	g.GET("/feed.json", json.Get).Name = routeNameFeedJSON
+
+	g.GET("/tag/:slug/feed.xml", rss.Get).Name = routeNameTagFeedRSS
+	g.GET("/tag/:slug/atom.xml", atom.Get).Name = routeNameTagFeedAtom
+	g.GET("/tag/:slug/feed.json", json.Get).Name = routeNameTagFeedJSON
+
+	author := g.Group("/author/:user", middleware.LoadAuthor(c.ORM))
+	authorArchive := authorArchive{Controller: ctr}
+	author.GET("", authorArchive.Get).Name = routeNameAuthorArchive
+
+	author.GET("/feed.xml", rss.Get).Name = routeNameAuthorFeedRSS
+	author.GET("/atom.xml", atom.Get).Name = routeNameAuthorFeedAtom
+	author.GET("/feed.json", json.Get).Name = routeNameAuthorFeedJSON
+}
+
+func seoRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
+	index := sitemapIndex{Controller: ctr}
+	g.GET("/sitemap.xml", index.Get).Name = routeNameSitemap
+
+	sitemap := sitemap{Controller: ctr}
+	g.GET("/sitemaps/:type/:chunk", sitemap.Get).Name = routeNameSitemapChunk
+
+	robots := robots{Controller: ctr}
+	g.GET("/robots.txt", robots.Get).Name = routeNameRobots
+}
+
+func newsletterRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
+	newsletter := newsletter{Controller: ctr}
+	g.GET("/newsletter", newsletter.Get).Name = routeNameNewsletter
+	g.POST("/newsletter", newsletter.Post, middleware.RateLimit(c.Config.RateLimit, c.Cache, rateLimitNewsletter)).Name = routeNameNewsletterSubmit
+
+	confirm := newsletterConfirm{Controller: ctr}
+	g.GET("/newsletter/confirm/:token", confirm.Get).Name = routeNameNewsletterConfirm
+
+	unsubscribe := newsletterUnsubscribe{Controller: ctr}
+	g.GET(paths.NewsletterUnsubscribe, unsubscribe.Get).Name = routeNameNewsletterUnsubscribe
+	g.POST(paths.NewsletterUnsubscribe, unsubscribe.Post).Name = routeNameNewsletterUnsubscribeSubmit
+}
+
+func adminRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
+	admin := g.Group("/admin", middleware.RequirePermission(permission.ManageSite))
+
+	dashboard := adminDashboard{Controller: ctr}
+	admin.GET("", dashboard.Get).Name = routeNameAdmin
+
+	users := adminUsers{Controller: ctr}
+	admin.GET("/users", users.Get).Name = routeNameAdminUsers
+
+	userGroup := admin.Group("/users/:user", middleware.LoadUser(c.ORM))
+
+	userEdit := adminUserEdit{Controller: ctr}
+	userGroup.GET("", userEdit.Get).Name = routeNameAdminUserEdit
+	userGroup.POST("", userEdit.Post).Name = routeNameAdminUserEditSubmit
+
+	ban := adminUserBan{Controller: ctr}
+	userGroup.POST("/ban", ban.Post).Name = routeNameAdminUserBan
+
+	unban := adminUserUnban{Controller: ctr}
+	userGroup.POST("/unban", unban.Post).Name = routeNameAdminUserUnban
+
+	posts := adminPosts{Controller: ctr}
+	admin.GET("/posts", posts.Get).Name = routeNameAdminPosts
+
+	comments := adminComments{Controller: ctr}
+	admin.GET("/comments", comments.Get).Name = routeNameAdminComments
+	admin.POST("/comments", comments.Post).Name = routeNameAdminCommentsSubmit
+
+	tasks := adminTasks{Controller: ctr}
+	admin.GET("/tasks", tasks.Get).Name = routeNameAdminTasks
+
+	cache := adminCache{Controller: ctr}
+	admin.GET("/cache", cache.Get).Name = routeNameAdminCache
+	admin.POST("/cache", cache.Post).Name = routeNameAdminCacheSubmit
+
+	auditLog := adminAudit{Controller: ctr}
+	admin.GET("/audit", auditLog.Get).Name = routeNameAdminAudit
+	admin.GET("/audit/export", auditLog.Export).Name = routeNameAdminAuditExport
+
+	mail := adminMail{Controller: ctr}
+	admin.GET("/mail", mail.Get).Name = routeNameAdminMail
+
+	mailGroup := admin.Group("/mail/:mail", middleware.LoadFailedMail(c.ORM))
+	mailGroup.GET("", mail.View).Name = routeNameAdminMailView
+
+	retry := adminMailRetry{Controller: ctr}
+	mailGroup.POST("/retry", retry.Post).Name = routeNameAdminMailRetry
+
+	del := adminMailDelete{Controller: ctr}
+	mailGroup.POST("/delete", del.Post).Name = routeNameAdminMailDelete
+}
+
+func devRoutes(c *services.Container, g *echo.Group, ctr controller.Controller) {
+	// The mail inbox is only available when email is being caught, in the local and development environments
+	if c.Mail.Catcher == nil {
+		return
+	}
+
+	dev := g.Group("/dev")
+
+	mail := devMail{Controller: ctr}
+	dev.GET("/mail", mail.Get).Name = routeNameDevMail
+	dev.POST("/mail/clear", mail.Clear).Name = routeNameDevMailClear
+	dev.GET("/mail/:mail", mail.View).Name = routeNameDevMailView
+	dev.GET("/mail/:mail/html", mail.HTML).Name = routeNameDevMailHTML
+}
@@ -1,22 +1,35 @@
 package routes
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/cookiejar"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/mailer"
 	"github.com/mikestefanello/pagoda/pkg/services"
+	"github.com/mikestefanello/pagoda/pkg/tests"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/hibiken/asynq"
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/stretchr/testify/require"
 )
 
+// testPassword is the password of users created by createUser
+const testPassword = "password"
+
 var (
 	srv *httptest.Server
 	c   *services.Container
@@ -47,6 +60,8 @@ func TestMain(m *testing.M) {
 
 type httpRequest struct {
 	route  string
+	host   string
+	header http.Header
 	client http.Client
 	body   url.Values
 	t      *testing.T
@@ -56,8 +71,9 @@ func request(t *testing.T) *httpRequest {
 	jar, err := cookiejar.New(nil)
 	require.NoError(t, err)
 	r := httpRequest{
-		t:    t,
-		body: url.Values{},
+		t:      t,
+		body:   url.Values{},
+		header: http.Header{},
 		client: http.Client{
 			Jar: jar,
 		},
@@ -70,8 +86,26 @@ func (h *httpRequest) setClient(client http.Client) *httpRequest {
 	return h
 }
 
+// noRedirects stops the client from following redirects, so they can be asserted, while keeping its cookies
+func (h *httpRequest) noRedirects() *httpRequest {
+	h.client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return h
+}
+
 func (h *httpRequest) setRoute(route string, params ...any) *httpRequest {
-	h.route = srv.URL + c.Web.Reverse(route, params)
+	h.route = srv.URL + c.Web.Reverse(route, params...)
+	return h
+}
+
+func (h *httpRequest) setHost(host string) *httpRequest {
+	h.host = host
+	return h
+}
+
+func (h *httpRequest) setHeader(key, value string) *httpRequest {
+	h.header.Set(key, value)
 	return h
 }
 
@@ -81,18 +115,28 @@ func (h *httpRequest) setBody(body url.Values) *httpRequest {
 }
 
 func (h *httpRequest) get() *httpResponse {
-	resp, err := h.client.Get(h.route)
+	return h.getURL(h.route)
+}
+
+func (h *httpRequest) getURL(url string) *httpResponse {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
 	require.NoError(h.t, err)
-	r := httpResponse{
-		t:        h.t,
-		Response: resp,
-	}
-	return &r
+	return h.do(req)
 }
 
 func (h *httpRequest) post() *httpResponse {
+	return h.submit(h.route)
+}
+
+// postFrom makes a POST request with the CSRF token of the form on a given page, for routes which only
+// handle form submissions
+func (h *httpRequest) postFrom(route string, params ...any) *httpResponse {
+	return h.submit(srv.URL + c.Web.Reverse(route, params...))
+}
+
+func (h *httpRequest) submit(page string) *httpResponse {
 	// Make a get request to get the CSRF token
-	doc := h.get().
+	doc := h.getURL(page).
 		assertStatusCode(http.StatusOK).
 		toDoc()
 
@@ -103,7 +147,20 @@ func (h *httpRequest) post() *httpResponse {
 	h.body["csrf"] = []string{token}
 
 	// Make the POST requests
-	resp, err := h.client.PostForm(h.route, h.body)
+	req, err := http.NewRequest(http.MethodPost, h.route, strings.NewReader(h.body.Encode()))
+	require.NoError(h.t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	return h.do(req)
+}
+
+func (h *httpRequest) do(req *http.Request) *httpResponse {
+	if h.host != "" {
+		req.Host = h.host
+	}
+	for k := range h.header {
+		req.Header.Set(k, h.header.Get(k))
+	}
+	resp, err := h.client.Do(req)
 	require.NoError(h.t, err)
 	r := httpResponse{
 		t:        h.t,
@@ -112,6 +169,24 @@ func (h *httpRequest) post() *httpResponse {
 	return &r
 }
 
+// login logs in a given user, created by createUser, with the client of the request so later requests are
+// authenticated
+func (h *httpRequest) login(usr *ent.User) *httpRequest {
+	route, body := h.route, h.body
+
+	resp := h.setRoute(routeNameLogin).
+		setBody(url.Values{
+			"email":    []string{usr.Email},
+			"password": []string{testPassword},
+		}).
+		post()
+	require.NoError(h.t, resp.Body.Close())
+	require.Less(h.t, resp.StatusCode, http.StatusBadRequest)
+
+	h.route, h.body = route, body
+	return h
+}
+
 type httpResponse struct {
 	*http.Response
 	t *testing.T
@@ -123,10 +198,69 @@ func (h *httpResponse) assertStatusCode(code int) *httpResponse {
 }
 
 func (h *httpResponse) assertRedirect(t *testing.T, route string, params ...any) *httpResponse {
-	assert.Equal(t, c.Web.Reverse(route, params), h.Header.Get("Location"))
+	assert.Equal(t, c.Web.Reverse(route, params...), h.Header.Get("Location"))
 	return h
 }
 
+// createUser creates a random user entity with testPassword as the password, so it can log in
+func createUser(t *testing.T) *ent.User {
+	usr, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	hash, err := c.Auth.HashPassword(testPassword)
+	require.NoError(t, err)
+
+	usr, err = usr.Update().
+		SetPassword(hash).
+		Save(context.Background())
+	require.NoError(t, err)
+	return usr
+}
+
+// createUserWithRole creates a random user entity, which can log in, with a given role
+func createUserWithRole(t *testing.T, role user.Role) *ent.User {
+	usr, err := createUser(t).
+		Update().
+		SetRole(role).
+		Save(context.Background())
+	require.NoError(t, err)
+	return usr
+}
+
+// pendingTasks returns the tasks of a given type which are waiting in the default queue
+func pendingTasks(t *testing.T, typ string) []*asynq.TaskInfo {
+	inspector := asynq.NewInspector(asynq.RedisClientOpt{
+		Addr:     fmt.Sprintf("%s:%d", c.Config.Cache.Hostname, c.Config.Cache.Port),
+		Password: c.Config.Cache.Password,
+		DB:       c.Config.Cache.TestDatabase,
+	})
+	defer inspector.Close()
+
+	tasks, err := inspector.ListPendingTasks("default", asynq.PageSize(1000))
+	require.NoError(t, err)
+
+	pending := make([]*asynq.TaskInfo, 0)
+	for _, task := range tasks {
+		if task.Type == typ {
+			pending = append(pending, task)
+		}
+	}
+	return pending
+}
+
+// queuedMail returns the messages which have been queued to be sent to a given email address
+func queuedMail(t *testing.T, to string) []*mailer.Message {
+	var messages []*mailer.Message
+	for _, task := range pendingTasks(t, services.TypeSendMail) {
+		var payload services.SendMailPayload
+		require.NoError(t, json.Unmarshal(task.Payload, &payload))
+		if len(payload.Message.To) > 0 && payload.Message.To[0] == to {
+			messages = append(messages, payload.Message)
+		}
+	}
+	return messages
+}
+
 func (h *httpResponse) toDoc() *goquery.Document {
 	doc, err := goquery.NewDocumentFromReader(h.Body)
 	require.NoError(h.t, err)
@@ -1,23 +1,39 @@
 package routes
 
 import (
-	"fmt"
-	"math/rand"
+	"html/template"
+	"strings"
 
+	"github.com/mikestefanello/pagoda/ent/post"
 	"github.com/mikestefanello/pagoda/pkg/controller"
 	"github.com/mikestefanello/pagoda/templates"
 
+	"entgo.io/ent/dialect/sql"
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	// searchHighlightStart and searchHighlightStop are the delimiters Postgres places around matches
+	// within search headlines, which are replaced with markup once the headline has been escaped
+	searchHighlightStart = "[[[hl]]]"
+	searchHighlightStop  = "[[[/hl]]]"
+)
+
 type (
 	search struct {
 		controller.Controller
 	}
 
+	searchData struct {
+		Query   string
+		Results []searchResult
+	}
+
 	searchResult struct {
-		Title string
-		URL   string
+		Title    string  `json:"title"`
+		Slug     string  `json:"slug"`
+		Headline string  `json:"headline"`
+		Rank     float64 `json:"rank"`
 	}
 )
 
@@ -25,21 +41,86 @@ func (c *search) Get(ctx echo.Context) error {
 	page := controller.NewPage(ctx)
 	page.Layout = templates.LayoutMain
 	page.Name = templates.PageSearch
+	page.Title = "Search"
+	page.Pager = controller.NewPager(ctx, 10)
 
-	// Fake search results
-	var results []searchResult
-	if search := ctx.QueryParam("query"); search != "" {
-		for i := 0; i < 5; i++ {
-			title := "Lorem ipsum example ddolor sit amet"
-			index := rand.Intn(len(title))
-			title = title[:index] + search + title[index:]
-			results = append(results, searchResult{
-				Title: title,
-				URL:   fmt.Sprintf("https://www.%s.com", search),
-			})
+	data := searchData{
+		Query: strings.TrimSpace(ctx.QueryParam("query")),
+	}
+
+	if data.Query != "" {
+		results, err := c.searchPosts(ctx, data.Query, &page.Pager)
+		if err != nil {
+			return c.Fail(err, "unable to search posts")
 		}
+		data.Results = results
 	}
-	page.Data = results
+	page.Data = data
 
 	return c.RenderPage(ctx, page)
 }
+
+// searchPosts performs a full-text search of published posts, ranked by relevance.
+// Matches in the title are weighted highest, followed by the excerpt and then the body.
+// See services.Container.initORM() for the search_vector column.
+func (c *search) searchPosts(ctx echo.Context, query string, pager *controller.Pager) ([]searchResult, error) {
+	tsQuery := func(b *sql.Builder) {
+		b.WriteString("websearch_to_tsquery('english', ").Arg(query).WriteString(")")
+	}
+
+	q := c.Container.ORM.Post.
+		Query().
+		Where(
+			post.StatusEQ(post.StatusPublished),
+			func(s *sql.Selector) {
+				s.Where(sql.P(func(b *sql.Builder) {
+					b.Ident(s.C("search_vector")).WriteString(" @@ ")
+					tsQuery(b)
+				}))
+			},
+		)
+
+	count, err := q.Clone().Count(ctx.Request().Context())
+	if err != nil {
+		return nil, err
+	}
+	pager.SetItems(count)
+
+	var results []searchResult
+	err = q.
+		Offset(pager.GetOffset()).
+		Limit(pager.ItemsPerPage).
+		Modify(func(s *sql.Selector) {
+			s.Select(s.C(post.FieldTitle), s.C(post.FieldSlug)).
+				AppendSelectExprAs(sql.ExprFunc(func(b *sql.Builder) {
+					// The headline is built from the body without its Markdown syntax, falling back to the body
+					// for posts which have not been saved since the plain text was stored
+					b.WriteString("ts_headline('english', coalesce(").
+						Ident(s.C(post.FieldBodyText)).
+						WriteString(", ").
+						Ident(s.C(post.FieldBody)).
+						WriteString("), ")
+					tsQuery(b)
+					b.WriteString(", ").
+						Arg("StartSel=" + searchHighlightStart + ", StopSel=" + searchHighlightStop + ", MaxFragments=2").
+						WriteString(")")
+				}), "headline").
+				AppendSelectExprAs(sql.ExprFunc(func(b *sql.Builder) {
+					b.WriteString("ts_rank(").Ident(s.C("search_vector")).WriteString(", ")
+					tsQuery(b)
+					b.WriteString(")")
+				}), "rank").
+				OrderBy(sql.Desc("rank"))
+		}).
+		Scan(ctx.Request().Context(), &results)
+
+	return results, err
+}
+
+// HighlightedHeadline returns the headline as HTML with the matched terms marked
+func (r searchResult) HighlightedHeadline() template.HTML {
+	h := template.HTMLEscapeString(r.Headline)
+	h = strings.ReplaceAll(h, template.HTMLEscapeString(searchHighlightStart), "<mark>")
+	h = strings.ReplaceAll(h, template.HTMLEscapeString(searchHighlightStop), "</mark>")
+	return template.HTML(h)
+}
@@ -0,0 +1,158 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/pkg/htmx"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlightedHeadline(t *testing.T) {
+	r := searchResult{
+		Headline: "<script>alert(1)</script> " + searchHighlightStart + "term" + searchHighlightStop,
+	}
+	assert.Equal(t, "&lt;script&gt;alert(1)&lt;/script&gt; <mark>term</mark>", string(r.HighlightedHeadline()))
+}
+
+func TestSearch(t *testing.T) {
+	term := searchTerm()
+	author := createUser(t)
+
+	body := createSearchPost(t, author, "Body match", "<script>alert(1)</script> "+term, post.StatusPublished)
+	title := createSearchPost(t, author, "Title "+term, "Lorem ipsum", post.StatusPublished)
+	createSearchPost(t, author, "Draft "+term, term, post.StatusDraft)
+
+	doc := request(t).
+		getURL(searchURL(term, 1)).
+		assertStatusCode(http.StatusOK).
+		toDoc()
+
+	// Drafts are excluded and matches in the title rank above matches in the body
+	assert.Equal(t, []string{title.Title, body.Title}, searchTitles(doc))
+
+	// Markup in the body is escaped while the matches are highlighted
+	headline, err := doc.Find(".search-results .block p").Last().Html()
+	require.NoError(t, err)
+	assert.NotContains(t, headline, "<script>")
+	assert.Contains(t, headline, "<mark>"+term+"</mark>")
+
+	// The headline does not contain Markdown syntax
+	markdown := createSearchPost(t, author, "Markdown", "## Heading\n\nSome **"+term+"** and [a link](https://example.com)", post.StatusPublished)
+	doc = request(t).
+		getURL(searchURL(term, 1)).
+		assertStatusCode(http.StatusOK).
+		toDoc()
+	require.Contains(t, searchTitles(doc), markdown.Title)
+	doc.Find(".search-results .block").Each(func(_ int, s *goquery.Selection) {
+		if s.Find("strong").Text() == markdown.Title {
+			headline := s.Find("p").Last().Text()
+			assert.Contains(t, headline, "Some "+term+" and a link")
+			for _, syntax := range []string{"#", "*", "[", "](", "https://example.com"} {
+				assert.NotContains(t, headline, syntax)
+			}
+		}
+	})
+
+	// The entire page, including the search form, is rendered
+	assert.Equal(t, 1, doc.Find(`input[name="query"]`).Length())
+}
+
+func TestSearch_Pager(t *testing.T) {
+	term := searchTerm()
+	author := createUser(t)
+
+	for i := 0; i < 12; i++ {
+		createSearchPost(t, author, fmt.Sprintf("Post %d", i), term, post.StatusPublished)
+	}
+
+	doc := request(t).
+		getURL(searchURL(term, 1)).
+		assertStatusCode(http.StatusOK).
+		toDoc()
+	first := searchTitles(doc)
+	assert.Len(t, first, 10)
+	assert.Equal(t, 1, doc.Find(`button:contains("Next")`).Length())
+	assert.Zero(t, doc.Find(`button:contains("Previous")`).Length())
+
+	doc = request(t).
+		getURL(searchURL(term, 2)).
+		assertStatusCode(http.StatusOK).
+		toDoc()
+	second := searchTitles(doc)
+	assert.Len(t, second, 2)
+	assert.Zero(t, doc.Find(`button:contains("Next")`).Length())
+	assert.Equal(t, 1, doc.Find(`button:contains("Previous")`).Length())
+
+	for _, title := range second {
+		assert.NotContains(t, first, title)
+	}
+}
+
+func TestSearch_HTMX(t *testing.T) {
+	term := searchTerm()
+	p := createSearchPost(t, createUser(t), "Title "+term, "Lorem ipsum", post.StatusPublished)
+
+	// Only the results are rendered for search-as-you-type
+	doc := request(t).
+		setHeader(htmx.HeaderRequest, "true").
+		getURL(searchURL(term, 1)).
+		assertStatusCode(http.StatusOK).
+		toDoc()
+
+	assert.Equal(t, []string{p.Title}, searchTitles(doc))
+	assert.Zero(t, doc.Find(`input[name="query"]`).Length())
+	assert.Zero(t, doc.Find("nav").Length())
+}
+
+// searchTerm returns a random word which no other posts contain
+func searchTerm() string {
+	return "zq" + strings.Map(func(r rune) rune {
+		return 'a' + r - '0'
+	}, fmt.Sprint(time.Now().UnixNano()))
+}
+
+// searchURL returns the URL of a given page of the search results for a given query
+func searchURL(query string, page int) string {
+	return fmt.Sprintf("%s%s?query=%s&page=%d", srv.URL, c.Web.Reverse(routeNameSearch), url.QueryEscape(query), page)
+}
+
+// searchTitles returns the titles of the search results, in order
+func searchTitles(doc *goquery.Document) []string {
+	titles := make([]string, 0)
+	doc.Find(".search-results .block strong").Each(func(_ int, s *goquery.Selection) {
+		titles = append(titles, s.Text())
+	})
+	return titles
+}
+
+// createSearchPost creates a post with a given title, body and status, and a random slug
+func createSearchPost(t *testing.T, author *ent.User, title, body string, status post.Status) *ent.Post {
+	create := c.ORM.Post.
+		Create().
+		SetTitle(title).
+		SetSlug(fmt.Sprintf("search-%d-%d", time.Now().UnixNano(), rand.Intn(1000000))).
+		SetBody(body).
+		SetStatus(status).
+		SetAuthor(author)
+
+	if status == post.StatusPublished {
+		create.SetPublishedAt(time.Now())
+	}
+
+	p, err := create.Save(permission.SystemContext(context.Background()))
+	require.NoError(t, err)
+	return p
+}
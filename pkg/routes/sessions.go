@@ -0,0 +1,84 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/services"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type sessions struct {
+	controller.Controller
+}
+
+func (c *sessions) Get(ctx echo.Context) error {
+	usr := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+
+	list, err := c.Container.Auth.Sessions(ctx, usr.ID)
+	if err != nil {
+		return c.Fail(err, "unable to load sessions")
+	}
+
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageSessions
+	page.Title = "Sessions"
+	page.Data = list
+
+	return c.RenderPage(ctx, page)
+}
+
+// Revoke logs the user out of a single session, which may be the current one
+func (c *sessions) Revoke(ctx echo.Context) error {
+	usr := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+	key := ctx.Param("session")
+
+	// Determine if this is the current session before it is removed
+	list, err := c.Container.Auth.Sessions(ctx, usr.ID)
+	if err != nil {
+		return c.Fail(err, "unable to load sessions")
+	}
+
+	current := false
+	for _, s := range list {
+		if s.Key == key {
+			current = s.Current
+		}
+	}
+
+	err = c.Container.Auth.RevokeSession(ctx.Request().Context(), usr.ID, key)
+	switch {
+	case err == nil:
+	case errors.Is(err, services.ErrAuthSessionNotFound):
+		return echo.NewHTTPError(http.StatusNotFound)
+	default:
+		return c.Fail(err, "unable to revoke session")
+	}
+
+	if current {
+		msg.Success(ctx, "You have been logged out.")
+		return c.Redirect(ctx, routeNameLogin)
+	}
+
+	msg.Success(ctx, "The session has been logged out.")
+	return c.Redirect(ctx, routeNameSessions)
+}
+
+// RevokeAll logs the user out of every session, including the current one
+func (c *sessions) RevokeAll(ctx echo.Context) error {
+	usr := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+
+	if err := c.Container.Auth.RevokeSessions(ctx.Request().Context(), usr.ID); err != nil {
+		return c.Fail(err, "unable to revoke sessions")
+	}
+
+	msg.Success(ctx, "You have been logged out everywhere.")
+	return c.Redirect(ctx, routeNameLogin)
+}
@@ -0,0 +1,80 @@
+package routes
+
+import (
+	"net/http"
+	"path"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessions_RequiresAuthentication(t *testing.T) {
+	request(t).
+		setRoute(routeNameSessions).
+		get().
+		assertStatusCode(http.StatusUnauthorized)
+}
+
+func TestSessions_Revoke(t *testing.T) {
+	usr := createUser(t)
+	req := request(t).login(usr)
+	other := request(t).login(usr)
+
+	// Both sessions are listed, with the one making the request marked as the current session
+	current, others := sessionKeys(t, req)
+	require.NotEmpty(t, current)
+	require.Len(t, others, 1)
+
+	// Revoking the other session logs it out without affecting the current one
+	req.setRoute(routeNameSessionRevoke, others[0]).
+		postFrom(routeNameSessions).
+		assertStatusCode(http.StatusOK)
+
+	other.setRoute(routeNameSessions).
+		get().
+		assertStatusCode(http.StatusUnauthorized)
+
+	current, others = sessionKeys(t, req)
+	assert.NotEmpty(t, current)
+	assert.Empty(t, others)
+}
+
+func TestSessions_RevokeOtherUser(t *testing.T) {
+	req := request(t).login(createUser(t))
+	victim := request(t).login(createUser(t))
+	key, _ := sessionKeys(t, victim)
+	require.NotEmpty(t, key)
+
+	// Sessions of other users cannot be revoked
+	req.setRoute(routeNameSessionRevoke, key).
+		postFrom(routeNameSessions).
+		assertStatusCode(http.StatusNotFound)
+
+	victim.setRoute(routeNameSessions).
+		get().
+		assertStatusCode(http.StatusOK)
+}
+
+// sessionKeys returns the keys of the current session and the other sessions listed on the sessions page of the
+// user logged in with a given request
+func sessionKeys(t *testing.T, req *httpRequest) (current string, others []string) {
+	doc := req.setRoute(routeNameSessions).
+		get().
+		assertStatusCode(http.StatusOK).
+		toDoc()
+
+	doc.Find("article.media").Each(func(_ int, s *goquery.Selection) {
+		action, exists := s.Find("form").Attr("action")
+		require.True(t, exists)
+		key := path.Base(path.Dir(action))
+
+		if s.Find(".tag.is-success").Length() > 0 {
+			current = key
+		} else {
+			others = append(others, key)
+		}
+	})
+	return current, others
+}
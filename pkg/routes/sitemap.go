@@ -0,0 +1,281 @@
+package routes
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/tag"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// sitemapURLLimit stores the maximum amount of URLs a single sitemap can contain, as defined by the protocol
+	sitemapURLLimit = 50000
+
+	// sitemapNamespace stores the XML namespace of the sitemap protocol
+	sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+)
+
+// sitemapType is a type of entity that the sitemaps are split by
+type sitemapType string
+
+const (
+	sitemapTypePosts   sitemapType = "posts"
+	sitemapTypeTags    sitemapType = "tags"
+	sitemapTypeAuthors sitemapType = "authors"
+)
+
+// sitemapTypes stores all sitemap types in the order they are listed within the index
+var sitemapTypes = []sitemapType{sitemapTypePosts, sitemapTypeTags, sitemapTypeAuthors}
+
+type (
+	sitemapIndex struct {
+		controller.Controller
+	}
+
+	sitemap struct {
+		controller.Controller
+	}
+
+	sitemapIndexXML struct {
+		XMLName  xml.Name     `xml:"sitemapindex"`
+		Xmlns    string       `xml:"xmlns,attr"`
+		Sitemaps []sitemapLoc `xml:"sitemap"`
+	}
+
+	sitemapURLSetXML struct {
+		XMLName xml.Name     `xml:"urlset"`
+		Xmlns   string       `xml:"xmlns,attr"`
+		URLs    []sitemapLoc `xml:"url"`
+	}
+
+	sitemapLoc struct {
+		Loc     string `xml:"loc"`
+		Lastmod string `xml:"lastmod,omitempty"`
+	}
+
+	// sitemapEntry is a single entity included in a sitemap along with when it was last modified
+	sitemapEntry struct {
+		ID      int       `json:"id"`
+		Slug    string    `json:"slug"`
+		Lastmod time.Time `json:"lastmod"`
+	}
+)
+
+// Get renders the sitemap index which lists a sitemap for every chunk of each sitemap type
+func (c *sitemapIndex) Get(ctx echo.Context) error {
+	index := sitemapIndexXML{
+		Xmlns:    sitemapNamespace,
+		Sitemaps: make([]sitemapLoc, 0),
+	}
+
+	for _, typ := range sitemapTypes {
+		count, lastmod, err := countSitemapEntries(ctx.Request().Context(), c.Container.ORM, typ)
+		if err != nil {
+			return c.Fail(err, "unable to count sitemap entries")
+		}
+
+		for i := 1; i <= sitemapChunks(count); i++ {
+			index.Sitemaps = append(index.Sitemaps, sitemapLoc{
+				Loc:     c.AbsoluteURL(ctx.Echo().Reverse(routeNameSitemapChunk, typ, i)),
+				Lastmod: formatSitemapTime(lastmod),
+			})
+		}
+	}
+
+	return ctx.XML(http.StatusOK, index)
+}
+
+// Get renders a single chunk of URLs of a given sitemap type
+func (c *sitemap) Get(ctx echo.Context) error {
+	typ, chunk, ok := parseSitemapChunk(ctx.Param("type"), ctx.Param("chunk"))
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+
+	entries, err := fetchSitemapEntries(ctx.Request().Context(), c.Container.ORM, typ, chunk)
+	if err != nil {
+		return c.Fail(err, "unable to fetch sitemap entries")
+	}
+
+	if len(entries) == 0 {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+
+	set := sitemapURLSetXML{
+		Xmlns: sitemapNamespace,
+		URLs:  make([]sitemapLoc, 0, len(entries)),
+	}
+
+	for _, e := range entries {
+		var path string
+		switch typ {
+		case sitemapTypePosts:
+			path = ctx.Echo().Reverse(routeNamePost, e.Slug)
+		case sitemapTypeTags:
+			path = ctx.Echo().Reverse(routeNameTagArchive, e.Slug)
+		case sitemapTypeAuthors:
+			path = ctx.Echo().Reverse(routeNameAuthorArchive, e.ID)
+		}
+
+		set.URLs = append(set.URLs, sitemapLoc{
+			Loc:     c.AbsoluteURL(path),
+			Lastmod: formatSitemapTime(e.Lastmod),
+		})
+	}
+
+	return ctx.XML(http.StatusOK, set)
+}
+
+// parseSitemapChunk parses the sitemap type and chunk number of a sitemap URL
+func parseSitemapChunk(typ, chunk string) (sitemapType, int, bool) {
+	n, err := strconv.Atoi(chunk)
+	if err != nil || n < 1 {
+		return "", 0, false
+	}
+
+	for _, t := range sitemapTypes {
+		if string(t) == typ {
+			return t, n, true
+		}
+	}
+
+	return "", 0, false
+}
+
+// sitemapChunks returns the amount of sitemaps needed to include a given amount of URLs
+func sitemapChunks(count int) int {
+	return (count + sitemapURLLimit - 1) / sitemapURLLimit
+}
+
+// formatSitemapTime formats a time in the W3C datetime format used by the sitemap protocol
+func formatSitemapTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// countSitemapEntries counts the entities of a sitemap type which have published posts along with when the
+// most recently modified of those posts was last updated
+func countSitemapEntries(ctx context.Context, orm *ent.Client, typ sitemapType) (int, time.Time, error) {
+	var (
+		count int
+		err   error
+	)
+
+	published := post.StatusEQ(post.StatusPublished)
+
+	switch typ {
+	case sitemapTypePosts:
+		count, err = orm.Post.Query().Where(published).Count(ctx)
+	case sitemapTypeTags:
+		count, err = orm.Tag.Query().Where(tag.HasPostsWith(published)).Count(ctx)
+	case sitemapTypeAuthors:
+		count, err = orm.User.Query().Where(user.HasPostsWith(published)).Count(ctx)
+	default:
+		return 0, time.Time{}, fmt.Errorf("unknown sitemap type: %s", typ)
+	}
+
+	if err != nil || count == 0 {
+		return count, time.Time{}, err
+	}
+
+	latest, err := orm.Post.
+		Query().
+		Where(published).
+		Order(ent.Desc(post.FieldUpdatedAt)).
+		First(ctx)
+
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return count, latest.UpdatedAt, nil
+}
+
+// fetchSitemapEntries fetches the entities of a sitemap type which belong to a given chunk, ordered by ID.
+// Tags and authors are only included if they have published posts and are last modified when the most
+// recently updated of those posts was.
+func fetchSitemapEntries(ctx context.Context, orm *ent.Client, typ sitemapType, chunk int) ([]sitemapEntry, error) {
+	var entries []sitemapEntry
+	offset := (chunk - 1) * sitemapURLLimit
+
+	switch typ {
+	case sitemapTypePosts:
+		posts, err := orm.Post.
+			Query().
+			Where(post.StatusEQ(post.StatusPublished)).
+			Order(ent.Asc(post.FieldID)).
+			Offset(offset).
+			Limit(sitemapURLLimit).
+			All(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range posts {
+			entries = append(entries, sitemapEntry{
+				ID:      p.ID,
+				Slug:    p.Slug,
+				Lastmod: p.UpdatedAt,
+			})
+		}
+
+		return entries, nil
+
+	case sitemapTypeTags:
+		err := orm.Tag.
+			Query().
+			Modify(func(s *sql.Selector) {
+				pt := sql.Table(post.TagsTable)
+				p := sql.Table(post.Table)
+				s.Join(pt).
+					On(s.C(tag.FieldID), pt.C(post.TagsPrimaryKey[1])).
+					Join(p).
+					On(pt.C(post.TagsPrimaryKey[0]), p.C(post.FieldID)).
+					Where(sql.EQ(p.C(post.FieldStatus), post.StatusPublished)).
+					GroupBy(s.C(tag.FieldID), s.C(tag.FieldSlug)).
+					Select(s.C(tag.FieldID), s.C(tag.FieldSlug)).
+					AppendSelectAs(sql.Max(p.C(post.FieldUpdatedAt)), "lastmod").
+					OrderBy(s.C(tag.FieldID)).
+					Offset(offset).
+					Limit(sitemapURLLimit)
+			}).
+			Scan(ctx, &entries)
+
+		return entries, err
+
+	case sitemapTypeAuthors:
+		err := orm.User.
+			Query().
+			Modify(func(s *sql.Selector) {
+				p := sql.Table(post.Table)
+				s.Join(p).
+					On(s.C(user.FieldID), p.C(post.AuthorColumn)).
+					Where(sql.EQ(p.C(post.FieldStatus), post.StatusPublished)).
+					GroupBy(s.C(user.FieldID)).
+					Select(s.C(user.FieldID)).
+					AppendSelectAs(sql.Max(p.C(post.FieldUpdatedAt)), "lastmod").
+					OrderBy(s.C(user.FieldID)).
+					Offset(offset).
+					Limit(sitemapURLLimit)
+			}).
+			Scan(ctx, &entries)
+
+		return entries, err
+	}
+
+	return nil, fmt.Errorf("unknown sitemap type: %s", typ)
+}
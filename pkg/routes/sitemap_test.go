@@ -0,0 +1,118 @@
+package routes
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSitemapChunks(t *testing.T) {
+	assert.Equal(t, 0, sitemapChunks(0))
+	assert.Equal(t, 1, sitemapChunks(1))
+	assert.Equal(t, 1, sitemapChunks(sitemapURLLimit))
+	assert.Equal(t, 2, sitemapChunks(sitemapURLLimit+1))
+	assert.Equal(t, 3, sitemapChunks(sitemapURLLimit*3))
+}
+
+func TestParseSitemapChunk(t *testing.T) {
+	typ, chunk, ok := parseSitemapChunk("tags", "2")
+	assert.True(t, ok)
+	assert.Equal(t, sitemapTypeTags, typ)
+	assert.Equal(t, 2, chunk)
+
+	_, _, ok = parseSitemapChunk("tags", "0")
+	assert.False(t, ok)
+	_, _, ok = parseSitemapChunk("tags", "abc")
+	assert.False(t, ok)
+	_, _, ok = parseSitemapChunk("comments", "1")
+	assert.False(t, ok)
+}
+
+func TestRobotsTxt(t *testing.T) {
+	sitemapURL := "http://localhost/sitemap.xml"
+
+	assert.Equal(t,
+		"User-agent: *\nDisallow: /user\nDisallow: /posts\n\nSitemap: http://localhost/sitemap.xml\n",
+		robotsTxt(false, []string{"/user", "/posts"}, sitemapURL),
+	)
+	assert.Equal(t,
+		"User-agent: *\nDisallow:\n\nSitemap: http://localhost/sitemap.xml\n",
+		robotsTxt(false, nil, sitemapURL),
+	)
+	assert.Equal(t,
+		"User-agent: *\nDisallow: /\n\nSitemap: http://localhost/sitemap.xml\n",
+		robotsTxt(true, []string{"/user"}, sitemapURL),
+	)
+}
+
+func TestSitemap_Get(t *testing.T) {
+	usr, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+	p, err := tests.CreatePost(c.ORM, usr)
+	require.NoError(t, err)
+
+	resp := request(t).
+		setRoute(routeNameSitemap).
+		get().
+		assertStatusCode(http.StatusOK)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Contains(t, string(body), "<sitemapindex")
+	assert.Contains(t, string(body), c.Web.Reverse(routeNameSitemapChunk, sitemapTypePosts, 1))
+	assert.Contains(t, string(body), c.Web.Reverse(routeNameSitemapChunk, sitemapTypeAuthors, 1))
+
+	resp = request(t).
+		setRoute(routeNameSitemapChunk, sitemapTypePosts, 1).
+		get().
+		assertStatusCode(http.StatusOK)
+
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Contains(t, string(body), "<urlset")
+	assert.Contains(t, string(body), c.Web.Reverse(routeNamePost, p.Slug))
+	assert.Contains(t, string(body), "<lastmod>")
+
+	request(t).
+		setRoute(routeNameSitemapChunk, sitemapTypePosts, 2).
+		get().
+		assertStatusCode(http.StatusNotFound)
+
+	request(t).
+		setRoute(routeNameSitemapChunk, "comments", 1).
+		get().
+		assertStatusCode(http.StatusNotFound)
+}
+
+func TestSitemap_LinksIgnoreHost(t *testing.T) {
+	usr, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+	p, err := tests.CreatePost(c.ORM, usr)
+	require.NoError(t, err)
+
+	// The links must not be built from a host header provided by the client
+	get := func(route string, params ...any) string {
+		resp := request(t).
+			setRoute(route, params...).
+			setHost("attacker.localhost").
+			get().
+			assertStatusCode(http.StatusOK)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.NotContains(t, string(body), "attacker.localhost")
+		return string(body)
+	}
+
+	assert.Contains(t, get(routeNameSitemap), c.Config.App.URL+c.Web.Reverse(routeNameSitemapChunk, sitemapTypePosts, 1))
+	assert.Contains(t, get(routeNameSitemapChunk, sitemapTypePosts, 1), c.Config.App.URL+c.Web.Reverse(routeNamePost, p.Slug))
+	assert.Contains(t, get(routeNameRobots), "Sitemap: "+c.Config.App.URL+c.Web.Reverse(routeNameSitemap))
+}
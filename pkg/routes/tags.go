@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/category"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/services"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	tags struct {
+		controller.Controller
+	}
+
+	tagsData struct {
+		Tags       []tagCloudItem
+		Categories []*ent.Category
+	}
+)
+
+func (c *tags) Get(ctx echo.Context) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageTags
+	page.Title = "Tags"
+	page.Cache.Enabled = true
+	page.Cache.Tags = []string{services.CacheTagTags}
+
+	cloud, err := fetchTagCloud(ctx.Request().Context(), c.Container.ORM)
+	if err != nil {
+		return c.Fail(err, "unable to fetch tag cloud")
+	}
+
+	categories, err := c.Container.ORM.Category.
+		Query().
+		Order(ent.Asc(category.FieldName)).
+		All(ctx.Request().Context())
+	if err != nil {
+		return c.Fail(err, "unable to fetch categories")
+	}
+
+	page.Data = tagsData{
+		Tags:       cloud,
+		Categories: categories,
+	}
+
+	return c.RenderPage(ctx, page)
+}
@@ -0,0 +1,148 @@
+package routes
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/category"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/schema"
+	"github.com/mikestefanello/pagoda/ent/tag"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// tagCloudWeights stores the amount of distinct weights that tags in the tag cloud can have
+const tagCloudWeights = 5
+
+// tagCloudItem is a tag within the tag cloud
+type tagCloudItem struct {
+	Name   string `json:"name"`
+	Slug   string `json:"slug"`
+	Count  int    `json:"count"`
+	Weight int    `json:"-"`
+}
+
+// parseTaxonomyNames splits a comma-separated list of names, removing blanks and duplicates
+func parseTaxonomyNames(list string) []string {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		slug := schema.Slugify(name)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// joinTags joins the names of tags in to a comma-separated list
+func joinTags(tags []*ent.Tag) string {
+	names := make([]string, len(tags))
+	for k, v := range tags {
+		names[k] = v.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// joinCategories joins the names of categories in to a comma-separated list
+func joinCategories(categories []*ent.Category) string {
+	names := make([]string, len(categories))
+	for k, v := range categories {
+		names[k] = v.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// loadTags loads the tags matching a list of names, creating any which do not yet exist. When saving a post, it
+// should be given the client of the transaction so new tags are only kept if the post is saved.
+func loadTags(ctx context.Context, orm *ent.Client, names []string) ([]*ent.Tag, error) {
+	tags := make([]*ent.Tag, 0, len(names))
+	for _, name := range names {
+		t, err := orm.Tag.
+			Query().
+			Where(tag.Slug(schema.Slugify(name))).
+			Only(ctx)
+
+		if ent.IsNotFound(err) {
+			t, err = orm.Tag.
+				Create().
+				SetName(name).
+				Save(ctx)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	return tags, nil
+}
+
+// loadCategories loads the categories matching a list of names, creating any which do not yet exist. When saving a
+// post, it should be given the client of the transaction so new categories are only kept if the post is saved.
+func loadCategories(ctx context.Context, orm *ent.Client, names []string) ([]*ent.Category, error) {
+	categories := make([]*ent.Category, 0, len(names))
+	for _, name := range names {
+		cat, err := orm.Category.
+			Query().
+			Where(category.Slug(schema.Slugify(name))).
+			Only(ctx)
+
+		if ent.IsNotFound(err) {
+			cat, err = orm.Category.
+				Create().
+				SetName(name).
+				Save(ctx)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, cat)
+	}
+	return categories, nil
+}
+
+// fetchTagCloud fetches all tags which have published posts along with the amount of published posts
+// for each, weighted relative to the most used tag
+func fetchTagCloud(ctx context.Context, orm *ent.Client) ([]tagCloudItem, error) {
+	var items []tagCloudItem
+
+	err := orm.Tag.
+		Query().
+		Modify(func(s *sql.Selector) {
+			pt := sql.Table(post.TagsTable)
+			p := sql.Table(post.Table)
+			s.Join(pt).
+				On(s.C(tag.FieldID), pt.C(post.TagsPrimaryKey[1])).
+				Join(p).
+				On(pt.C(post.TagsPrimaryKey[0]), p.C(post.FieldID)).
+				Where(sql.EQ(p.C(post.FieldStatus), post.StatusPublished)).
+				GroupBy(s.C(tag.FieldID), s.C(tag.FieldName), s.C(tag.FieldSlug)).
+				Select(s.C(tag.FieldName), s.C(tag.FieldSlug)).
+				AppendSelectAs(sql.Count(pt.C(post.TagsPrimaryKey[0])), "count").
+				OrderBy(s.C(tag.FieldName))
+		}).
+		Scan(ctx, &items)
+
+	if err != nil {
+		return nil, err
+	}
+
+	max := 0
+	for _, item := range items {
+		if item.Count > max {
+			max = item.Count
+		}
+	}
+	for k, item := range items {
+		items[k].Weight = 1 + (item.Count*(tagCloudWeights-1))/max
+	}
+
+	return items, nil
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/pkg/permission"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTaxonomyNames(t *testing.T) {
+	names := parseTaxonomyNames(" Go, web ,, go, Web Dev,!!,")
+	assert.Equal(t, []string{"Go", "web", "Web Dev"}, names)
+}
+
+func TestTagArchive_Get(t *testing.T) {
+	usr, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+	p, err := tests.CreatePost(c.ORM, usr)
+	require.NoError(t, err)
+	tags, err := loadTags(context.Background(), c.ORM, []string{"Archive Test"})
+	require.NoError(t, err)
+	require.NoError(t, p.Update().AddTags(tags...).Exec(permission.SystemContext(context.Background())))
+
+	doc := request(t).
+		setRoute(routeNameTagArchive, tags[0].Slug).
+		get().
+		assertStatusCode(http.StatusOK).
+		toDoc()
+
+	assert.Equal(t, "Tagged: Archive Test", doc.Find("h1.title").Text())
+	assert.Equal(t, p.Title, doc.Find(".block a strong").First().Text())
+
+	request(t).
+		setRoute(routeNameTagArchive, "missing-tag").
+		get().
+		assertStatusCode(http.StatusNotFound)
+}
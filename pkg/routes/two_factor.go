@@ -0,0 +1,234 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/base64"
+	"html/template"
+	"image/png"
+	"strings"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/recoverycode"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/context"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+	"github.com/mikestefanello/pagoda/pkg/services"
+	"github.com/mikestefanello/pagoda/templates"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pquerna/otp"
+)
+
+// qrCodeSize is the width and height, in pixels, of the QR code used to enroll in two-factor authentication
+const qrCodeSize = 200
+
+type (
+	twoFactor struct {
+		controller.Controller
+	}
+
+	twoFactorForm struct {
+		Secret     string `form:"secret"`
+		Code       string `form:"code" validate:"required"`
+		Submission controller.FormSubmission
+	}
+
+	twoFactorData struct {
+		// Enabled indicates if the user has two-factor authentication enabled
+		Enabled bool
+
+		// Secret and QRCode are of the TOTP key being enrolled, if not enabled
+		Secret string
+		QRCode template.URL
+
+		// RecoveryCodes are only set right after they are generated, since only their hashes are stored
+		RecoveryCodes []string
+
+		// RecoveryCodesRemaining is the amount of recovery codes which have not been used
+		RecoveryCodesRemaining int
+	}
+)
+
+func (c *twoFactor) Get(ctx echo.Context) error {
+	return c.render(ctx, ctx.Get(context.AuthenticatedUserKey).(*ent.User), nil)
+}
+
+// Enable enables two-factor authentication once the user enters a code generated from the key being enrolled
+func (c *twoFactor) Enable(ctx echo.Context) error {
+	usr := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+
+	form, err := c.bind(ctx)
+	if err != nil || form.Submission.HasErrors() {
+		return err
+	}
+
+	if usr.TotpSecret != nil {
+		return c.Redirect(ctx, routeNameTwoFactor)
+	}
+
+	if !c.Container.Auth.ValidateTOTPCode(form.Secret, form.Code) {
+		form.Submission.SetFieldError("Code", "The code is invalid. Make sure the time on your device is correct.")
+		return c.Get(ctx)
+	}
+
+	codes, err := c.Container.Auth.EnableTOTP(ctx.Request().Context(), usr.ID, strings.ToUpper(form.Secret))
+	if err != nil {
+		return c.Fail(err, "unable to enable two-factor authentication")
+	}
+
+	usr, err = c.Container.ORM.User.Get(ctx.Request().Context(), usr.ID)
+	if err != nil {
+		return c.Fail(err, "unable to reload user")
+	}
+
+	ctx.Set(context.FormKey, nil)
+	msg.Success(ctx, "Two-factor authentication has been enabled.")
+	return c.render(ctx, usr, codes)
+}
+
+// Disable disables two-factor authentication once the user enters a TOTP or recovery code
+func (c *twoFactor) Disable(ctx echo.Context) error {
+	usr := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+
+	form, err := c.bind(ctx)
+	if err != nil || form.Submission.HasErrors() {
+		return err
+	}
+
+	if ok, err := c.verify(ctx, usr, form); !ok {
+		return err
+	}
+
+	if err = c.Container.Auth.DisableTOTP(ctx.Request().Context(), usr.ID); err != nil {
+		return c.Fail(err, "unable to disable two-factor authentication")
+	}
+
+	msg.Success(ctx, "Two-factor authentication has been disabled.")
+	return c.Redirect(ctx, routeNameTwoFactor)
+}
+
+// Recovery replaces the user's recovery codes once they enter a TOTP or recovery code
+func (c *twoFactor) Recovery(ctx echo.Context) error {
+	usr := ctx.Get(context.AuthenticatedUserKey).(*ent.User)
+
+	form, err := c.bind(ctx)
+	if err != nil || form.Submission.HasErrors() {
+		return err
+	}
+
+	if ok, err := c.verify(ctx, usr, form); !ok {
+		return err
+	}
+
+	codes, err := c.Container.Auth.GenerateRecoveryCodes(ctx.Request().Context(), usr.ID)
+	if err != nil {
+		return c.Fail(err, "unable to generate recovery codes")
+	}
+
+	ctx.Set(context.FormKey, nil)
+	msg.Success(ctx, "New recovery codes have been generated and the previous ones can no longer be used.")
+	return c.render(ctx, usr, codes)
+}
+
+// bind binds and validates the submitted form. If the form has errors, the page is rendered and returned as
+// the error, which is nil if it rendered successfully.
+func (c *twoFactor) bind(ctx echo.Context) (*twoFactorForm, error) {
+	var form twoFactorForm
+	ctx.Set(context.FormKey, &form)
+
+	if err := ctx.Bind(&form); err != nil {
+		return nil, c.Fail(err, "unable to parse two-factor form")
+	}
+
+	if err := form.Submission.Process(ctx, form); err != nil {
+		return nil, c.Fail(err, "unable to process form submission")
+	}
+
+	if form.Submission.HasErrors() {
+		return &form, c.Get(ctx)
+	}
+
+	return &form, nil
+}
+
+// verify validates the TOTP or recovery code submitted by a user with two-factor authentication enabled.
+// If the code is invalid, the page is rendered and returned as the error.
+func (c *twoFactor) verify(ctx echo.Context, usr *ent.User, form *twoFactorForm) (bool, error) {
+	_, err := c.Container.Auth.ValidateTwoFactorCode(ctx.Request().Context(), usr, form.Code)
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case services.InvalidTwoFactorCodeError:
+		form.Submission.SetFieldError("Code", "The code is invalid.")
+		return false, c.Get(ctx)
+	default:
+		return false, c.Fail(err, "unable to validate two-factor code")
+	}
+}
+
+// render renders the two-factor authentication page for a given user, including recovery codes if they were
+// just generated
+func (c *twoFactor) render(ctx echo.Context, usr *ent.User, recoveryCodes []string) error {
+	page := controller.NewPage(ctx)
+	page.Layout = templates.LayoutMain
+	page.Name = templates.PageTwoFactor
+	page.Title = "Two-factor authentication"
+	page.Form = twoFactorForm{}
+
+	if form := ctx.Get(context.FormKey); form != nil {
+		page.Form = form.(*twoFactorForm)
+	}
+
+	data := twoFactorData{
+		Enabled:       usr.TotpSecret != nil,
+		RecoveryCodes: recoveryCodes,
+	}
+
+	if data.Enabled {
+		count, err := c.Container.ORM.RecoveryCode.
+			Query().
+			Where(recoverycode.HasUserWith(user.ID(usr.ID))).
+			Count(ctx.Request().Context())
+
+		if err != nil {
+			return c.Fail(err, "unable to count recovery codes")
+		}
+		data.RecoveryCodesRemaining = count
+	} else {
+		// Keep the key being enrolled when the form is redisplayed so the QR code does not have to be scanned again
+		var key *otp.Key
+		var err error
+		if form, ok := page.Form.(*twoFactorForm); ok && form.Secret != "" {
+			key, err = c.Container.Auth.GetTOTPKey(usr.Email, form.Secret)
+		}
+		if key == nil || err != nil {
+			if key, err = c.Container.Auth.GenerateTOTPKey(usr.Email); err != nil {
+				return c.Fail(err, "unable to generate TOTP key")
+			}
+		}
+
+		data.Secret = key.Secret()
+		if data.QRCode, err = qrCode(key); err != nil {
+			return c.Fail(err, "unable to render QR code")
+		}
+	}
+	page.Data = data
+
+	return c.RenderPage(ctx, page)
+}
+
+// qrCode renders the QR code of a given TOTP key, which authenticator apps scan to enroll, as a data URL
+func qrCode(key *otp.Key) (template.URL, error) {
+	img, err := key.Image(qrCodeSize, qrCodeSize)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err = png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+
+	return template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())), nil
+}
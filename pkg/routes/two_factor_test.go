@@ -0,0 +1,120 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoFactor_RequiresAuthentication(t *testing.T) {
+	request(t).
+		setRoute(routeNameTwoFactor).
+		get().
+		assertStatusCode(http.StatusUnauthorized)
+}
+
+func TestLoginTwoFactor_RequiresPendingLogin(t *testing.T) {
+	request(t).
+		setClient(http.Client{
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}).
+		setRoute(routeNameLoginTwoFactor).
+		get().
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameLogin)
+}
+
+func TestLoginTwoFactor_FailuresLockAccount(t *testing.T) {
+	usr := createUser(t)
+	key, err := c.Auth.GenerateTOTPKey(usr.Email)
+	require.NoError(t, err)
+	_, err = c.Auth.EnableTOTP(context.Background(), usr.ID, key.Secret())
+	require.NoError(t, err)
+
+	// Disable the delays so the test does not have to wait
+	delayAfter := c.Config.App.Login.DelayAfter
+	c.Config.App.Login.DelayAfter = 0
+	defer func() {
+		c.Config.App.Login.DelayAfter = delayAfter
+	}()
+
+	// Fail all but one of the attempts allowed before the account is locked
+	for i := 0; i < c.Config.App.Login.MaxAttemptsPerEmail-1; i++ {
+		_, err = c.Auth.FailLogin(context.Background(), "192.0.2.30", usr.Email)
+		require.NoError(t, err)
+	}
+
+	// The session must be kept between requests so the pending login can be completed
+	req := request(t).
+		noRedirects().
+		setHeader(echo.HeaderXRealIP, "192.0.2.31")
+
+	// Entering the correct password does not clear the failed attempts
+	req.setRoute(routeNameLogin).
+		setBody(url.Values{
+			"email":    []string{usr.Email},
+			"password": []string{testPassword},
+		}).
+		post().
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameLoginTwoFactor)
+
+	// An incorrect code counts towards the lockout
+	req.setRoute(routeNameLoginTwoFactor).
+		setBody(url.Values{"code": []string{"invalid"}}).
+		post().
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameLogin)
+
+	throttle, err := c.Auth.LoginThrottle(context.Background(), "192.0.2.32", usr.Email)
+	require.NoError(t, err)
+	assert.True(t, throttle.Locked())
+}
+
+func TestLoginTwoFactor_Banned(t *testing.T) {
+	usr := createUser(t)
+	key, err := c.Auth.GenerateTOTPKey(usr.Email)
+	require.NoError(t, err)
+	_, err = c.Auth.EnableTOTP(context.Background(), usr.ID, key.Secret())
+	require.NoError(t, err)
+
+	req := request(t).
+		noRedirects().
+		setHeader(echo.HeaderXRealIP, "192.0.2.33")
+
+	req.setRoute(routeNameLogin).
+		setBody(url.Values{
+			"email":    []string{usr.Email},
+			"password": []string{testPassword},
+		}).
+		post().
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameLoginTwoFactor)
+
+	// Users banned between the two steps are not logged in, even with a correct code
+	_, err = usr.Update().
+		SetBannedAt(time.Now()).
+		Save(context.Background())
+	require.NoError(t, err)
+
+	code, err := totp.GenerateCode(key.Secret(), time.Now())
+	require.NoError(t, err)
+	req.setRoute(routeNameLoginTwoFactor).
+		setBody(url.Values{"code": []string{code}}).
+		post().
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameLogin)
+
+	req.setRoute(routeNameSessions).
+		get().
+		assertStatusCode(http.StatusUnauthorized)
+}
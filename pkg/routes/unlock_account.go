@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/audit"
+	"github.com/mikestefanello/pagoda/pkg/controller"
+	"github.com/mikestefanello/pagoda/pkg/msg"
+
+	"github.com/labstack/echo/v4"
+)
+
+type unlockAccount struct {
+	controller.Controller
+}
+
+func (c *unlockAccount) Get(ctx echo.Context) error {
+	// Validate the token
+	email, err := c.Container.Auth.ValidateUnlockToken(ctx.Param("token"))
+	if err != nil {
+		msg.Warning(ctx, "The link is either invalid or has expired.")
+		return c.Redirect(ctx, routeNameLogin)
+	}
+
+	u, err := c.Container.ORM.User.
+		Query().
+		Where(user.Email(email)).
+		Only(ctx.Request().Context())
+
+	switch err.(type) {
+	case *ent.NotFoundError:
+		msg.Warning(ctx, "The link is either invalid or has expired.")
+		return c.Redirect(ctx, routeNameLogin)
+	case nil:
+	default:
+		return c.Fail(err, "unable to query user to unlock")
+	}
+
+	if err = c.Container.Auth.UnlockLogin(ctx.Request().Context(), email); err != nil {
+		return c.Fail(err, "unable to unlock account")
+	}
+
+	err = audit.Record(ctx.Request().Context(), c.Container.ORM, audit.Event{
+		Action:      audit.AccountUnlocked,
+		UserID:      u.ID,
+		SubjectType: user.Label,
+		SubjectID:   u.ID,
+	})
+	if err != nil {
+		return c.Fail(err, "unable to record audit event")
+	}
+
+	msg.Success(ctx, "Your account has been unlocked. You can now log in.")
+	return c.Redirect(ctx, routeNameLogin)
+}
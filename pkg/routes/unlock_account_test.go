@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnlockAccount(t *testing.T) {
+	usr, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	// Lock the account
+	for i := 0; i < c.Config.App.Login.MaxAttemptsPerEmail; i++ {
+		_, err = c.Auth.FailLogin(context.Background(), "192.0.2.20", usr.Email)
+		require.NoError(t, err)
+	}
+	throttle, err := c.Auth.LoginThrottle(context.Background(), "192.0.2.21", usr.Email)
+	require.NoError(t, err)
+	require.True(t, throttle.Locked())
+
+	token, err := c.Auth.GenerateUnlockToken(usr.Email)
+	require.NoError(t, err)
+
+	noRedirect := http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	request(t).
+		setClient(noRedirect).
+		setRoute(routeNameUnlockAccount, token).
+		get().
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameLogin)
+
+	throttle, err = c.Auth.LoginThrottle(context.Background(), "192.0.2.21", usr.Email)
+	require.NoError(t, err)
+	assert.False(t, throttle.Locked())
+
+	// Invalid tokens do not unlock anything
+	request(t).
+		setClient(noRedirect).
+		setRoute(routeNameUnlockAccount, "invalid").
+		get().
+		assertStatusCode(http.StatusFound).
+		assertRedirect(t, routeNameLogin)
+}
@@ -55,6 +55,22 @@ func (c *verifyEmail) Get(ctx echo.Context) error {
 		if err != nil {
 			return c.Fail(err, "failed to set user as verified")
 		}
+
+		// Welcome the user now that their account is verified
+		err = c.Container.Mail.
+			Compose().
+			To(usr.Email).
+			Template("welcome").
+			TemplateData(emailLink{
+				Name: usr.Name,
+				URL:  c.AbsoluteURL(ctx.Echo().Reverse(routeNameHome)),
+			}).
+			Locale(ctx.Request().Header.Get("Accept-Language")).
+			SendAsync()
+
+		if err != nil {
+			ctx.Logger().Errorf("unable to send welcome email: %v", err)
+		}
 	}
 
 	msg.Success(ctx, "Your email has been successfully verified.")
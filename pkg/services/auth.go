@@ -12,6 +12,7 @@ import (
 	"github.com/mikestefanello/pagoda/ent"
 	"github.com/mikestefanello/pagoda/ent/passwordtoken"
 	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/audit"
 	"github.com/mikestefanello/pagoda/pkg/context"
 
 	"github.com/labstack/echo-contrib/session"
@@ -28,6 +29,25 @@ const (
 
 	// authSessionKeyAuthenticated stores the key used to store the authentication status in the session
 	authSessionKeyAuthenticated = "authenticated"
+
+	// authSessionKeyTwoFactorUserID stores the key used to store the ID of the user who must complete the second
+	// step of logging in, while the session is half-authenticated
+	authSessionKeyTwoFactorUserID = "two_factor_user_id"
+
+	// authSessionKeyTwoFactorStartedAt stores the key used to store when the second step of logging in started
+	authSessionKeyTwoFactorStartedAt = "two_factor_started_at"
+
+	// authSessionKeyPasskeyRegistration stores the key used to store the challenge of a passkey being registered
+	authSessionKeyPasskeyRegistration = "passkey_registration"
+
+	// authSessionKeyPasskeyLogin stores the key used to store the challenge of logging in with a passkey
+	authSessionKeyPasskeyLogin = "passkey_login"
+
+	// subscriberTokenConfirm is the purpose of tokens which confirm a newsletter subscription
+	subscriberTokenConfirm = "confirm"
+
+	// subscriberTokenUnsubscribe is the purpose of tokens which unsubscribe from the newsletter
+	subscriberTokenUnsubscribe = "unsubscribe"
 )
 
 // NotAuthenticatedError is an error returned when a user is not authenticated
@@ -48,15 +68,19 @@ func (e InvalidPasswordTokenError) Error() string {
 
 // AuthClient is the client that handles authentication requests
 type AuthClient struct {
-	config *config.Config
-	orm    *ent.Client
+	config   *config.Config
+	orm      *ent.Client
+	cache    *CacheClient
+	sessions *SessionStore
 }
 
 // NewAuthClient creates a new authentication client
-func NewAuthClient(cfg *config.Config, orm *ent.Client) *AuthClient {
+func NewAuthClient(cfg *config.Config, orm *ent.Client, cache *CacheClient, sessions *SessionStore) *AuthClient {
 	return &AuthClient{
-		config: cfg,
-		orm:    orm,
+		config:   cfg,
+		orm:      orm,
+		cache:    cache,
+		sessions: sessions,
 	}
 }
 
@@ -66,19 +90,64 @@ func (c *AuthClient) Login(ctx echo.Context, userID int) error {
 	if err != nil {
 		return err
 	}
+
+	// Start a new session so an ID obtained before logging in cannot be used to take it over
+	if sess.ID != "" {
+		if err = c.sessions.Delete(ctx.Request().Context(), sess.ID); err != nil {
+			return err
+		}
+		sess.ID = ""
+	}
+
 	sess.Values[authSessionKeyUserID] = userID
 	sess.Values[authSessionKeyAuthenticated] = true
-	return sess.Save(ctx.Request(), ctx.Response())
+	delete(sess.Values, authSessionKeyTwoFactorUserID)
+	delete(sess.Values, authSessionKeyTwoFactorStartedAt)
+	if err = sess.Save(ctx.Request(), ctx.Response()); err != nil {
+		return err
+	}
+
+	// The ID is only set by stores which keep sessions on the server, which are the only ones that can be revoked
+	if sess.ID != "" {
+		if err = c.touchSession(ctx, userID, sess.ID); err != nil {
+			return err
+		}
+	}
+
+	return audit.Record(ctx.Request().Context(), c.orm, audit.Event{
+		Action: audit.Login,
+		UserID: userID,
+	})
 }
 
-// Logout logs the requesting user out
+// Logout logs the requesting user out and deletes their session
 func (c *AuthClient) Logout(ctx echo.Context) error {
 	sess, err := session.Get(authSessionName, ctx)
 	if err != nil {
 		return err
 	}
+
+	userID, ok := sess.Values[authSessionKeyUserID].(int)
+	if ok && sess.ID != "" {
+		if err = c.untrackSession(ctx.Request().Context(), userID, sess.ID); err != nil {
+			return err
+		}
+	}
+
 	sess.Values[authSessionKeyAuthenticated] = false
-	return sess.Save(ctx.Request(), ctx.Response())
+	sess.Options.MaxAge = -1
+	if err = sess.Save(ctx.Request(), ctx.Response()); err != nil {
+		return err
+	}
+
+	if !ok {
+		return nil
+	}
+
+	return audit.Record(ctx.Request().Context(), c.orm, audit.Event{
+		Action: audit.Logout,
+		UserID: userID,
+	})
 }
 
 // GetAuthenticatedUserID returns the authenticated user's ID, if the user is logged in
@@ -89,18 +158,32 @@ func (c *AuthClient) GetAuthenticatedUserID(ctx echo.Context) (int, error) {
 	}
 
 	if sess.Values[authSessionKeyAuthenticated] == true {
-		return sess.Values[authSessionKeyUserID].(int), nil
+		userID := sess.Values[authSessionKeyUserID].(int)
+
+		if sess.ID != "" {
+			if err = c.touchSession(ctx, userID, sess.ID); err != nil {
+				return 0, err
+			}
+		}
+
+		return userID, nil
 	}
 
 	return 0, NotAuthenticatedError{}
 }
 
 // GetAuthenticatedUser returns the authenticated user if the user is logged in
+// Users who have been banned are not considered to be logged in
 func (c *AuthClient) GetAuthenticatedUser(ctx echo.Context) (*ent.User, error) {
 	if userID, err := c.GetAuthenticatedUserID(ctx); err == nil {
-		return c.orm.User.Query().
+		u, err := c.orm.User.Query().
 			Where(user.ID(userID)).
 			Only(ctx.Request().Context())
+
+		if err == nil && u.BannedAt != nil {
+			return nil, NotAuthenticatedError{}
+		}
+		return u, err
 	}
 
 	return nil, NotAuthenticatedError{}
@@ -202,17 +285,82 @@ func (c *AuthClient) RandomToken(length int) (string, error) {
 // GenerateEmailVerificationToken generates an email verification token for a given email address using JWT which
 // is set to expire based on the duration stored in configuration
 func (c *AuthClient) GenerateEmailVerificationToken(email string) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	return c.signToken(jwt.MapClaims{
 		"email": email,
 		"exp":   time.Now().Add(c.config.App.EmailVerificationTokenExpiration).Unix(),
 	})
-
-	return token.SignedString([]byte(c.config.App.EncryptionKey))
 }
 
 // ValidateEmailVerificationToken validates an email verification token and returns the associated email address if
 // the token is valid and has not expired
 func (c *AuthClient) ValidateEmailVerificationToken(token string) (string, error) {
+	claims, err := c.parseToken(token)
+	if err != nil {
+		return "", err
+	}
+
+	if email, ok := claims["email"].(string); ok {
+		return email, nil
+	}
+
+	return "", errors.New("invalid or expired token")
+}
+
+// GenerateSubscriptionToken generates a token, using JWT, which confirms the newsletter subscription of a given
+// email address and is set to expire based on the duration stored in configuration
+func (c *AuthClient) GenerateSubscriptionToken(email string) (string, error) {
+	return c.signToken(jwt.MapClaims{
+		"subscriber": email,
+		"purpose":    subscriberTokenConfirm,
+		"exp":        time.Now().Add(c.config.Newsletter.ConfirmationTokenExpiration).Unix(),
+	})
+}
+
+// ValidateSubscriptionToken validates a newsletter subscription confirmation token and returns the associated
+// email address if the token is valid and has not expired
+func (c *AuthClient) ValidateSubscriptionToken(token string) (string, error) {
+	return c.validateSubscriberToken(token, subscriberTokenConfirm)
+}
+
+// GenerateUnsubscribeToken generates a token, using JWT, which unsubscribes a given email address from the
+// newsletter. These tokens do not expire so unsubscribe links continue to work.
+func (c *AuthClient) GenerateUnsubscribeToken(email string) (string, error) {
+	return c.signToken(jwt.MapClaims{
+		"subscriber": email,
+		"purpose":    subscriberTokenUnsubscribe,
+	})
+}
+
+// ValidateUnsubscribeToken validates a newsletter unsubscribe token and returns the associated email address if
+// the token is valid
+func (c *AuthClient) ValidateUnsubscribeToken(token string) (string, error) {
+	return c.validateSubscriberToken(token, subscriberTokenUnsubscribe)
+}
+
+// validateSubscriberToken validates a newsletter token issued for a given purpose and returns the associated
+// email address
+func (c *AuthClient) validateSubscriberToken(token, purpose string) (string, error) {
+	claims, err := c.parseToken(token)
+	if err != nil {
+		return "", err
+	}
+
+	email, ok := claims["subscriber"].(string)
+	if !ok || claims["purpose"] != purpose {
+		return "", errors.New("invalid token")
+	}
+
+	return email, nil
+}
+
+// signToken signs a JWT containing the given claims
+func (c *AuthClient) signToken(claims jwt.MapClaims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).
+		SignedString([]byte(c.config.App.EncryptionKey))
+}
+
+// parseToken validates a JWT and returns the claims it contains
+func (c *AuthClient) parseToken(token string) (jwt.MapClaims, error) {
 	t, err := jwt.Parse(token, func(t *jwt.Token) (any, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
@@ -222,12 +370,12 @@ func (c *AuthClient) ValidateEmailVerificationToken(token string) (string, error
 	})
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if claims, ok := t.Claims.(jwt.MapClaims); ok && t.Valid {
-		return claims["email"].(string), nil
+		return claims, nil
 	}
 
-	return "", errors.New("invalid or expired token")
+	return nil, errors.New("invalid or expired token")
 }
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	// loginAttemptsIPKeyPrefix is the prefix of the cache keys which store the failed login attempts of each IP
+	// address
+	loginAttemptsIPKeyPrefix = "auth:login:ip:"
+
+	// loginAttemptsEmailKeyPrefix is the prefix of the cache keys which store the failed login attempts for each
+	// email address
+	loginAttemptsEmailKeyPrefix = "auth:login:email:"
+
+	// loginLockKeyPrefix is the prefix of the cache keys which indicate that the account of an email address is locked
+	loginLockKeyPrefix = "auth:login:lock:"
+)
+
+// LoginThrottle describes how a login attempt is limited by the failed attempts which preceded it
+type LoginThrottle struct {
+	// Delay is how long to wait before checking the password
+	Delay time.Duration
+
+	// Blocked indicates that the IP address has made too many failed attempts, so the attempt must be refused
+	Blocked bool
+
+	// LockedFor is how much longer the account remains locked, if it is
+	LockedFor time.Duration
+}
+
+// Locked indicates if the account is locked, so the attempt must be refused
+func (t LoginThrottle) Locked() bool {
+	return t.LockedFor > 0
+}
+
+// LoginThrottle returns how a login attempt from a given IP address for a given email address is limited, based on
+// the failed attempts within the window stored in configuration
+func (c *AuthClient) LoginThrottle(ctx context.Context, ip, email string) (LoginThrottle, error) {
+	cfg := c.config.App.Login
+	ipKey, emailKey := loginAttemptsIPKey(ip), loginAttemptsEmailKey(email)
+	since := strconv.FormatInt(time.Now().Add(-cfg.Window).UnixNano(), 10)
+
+	var ipCount, emailCount *redis.IntCmd
+	var lock *redis.DurationCmd
+	_, err := c.cache.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, ipKey, "-inf", "("+since)
+		pipe.ZRemRangeByScore(ctx, emailKey, "-inf", "("+since)
+		ipCount = pipe.ZCard(ctx, ipKey)
+		emailCount = pipe.ZCard(ctx, emailKey)
+		lock = pipe.PTTL(ctx, loginLockKey(email))
+		return nil
+	})
+	if err != nil {
+		return LoginThrottle{}, err
+	}
+
+	var t LoginThrottle
+	t.Blocked = cfg.MaxAttemptsPerIP > 0 && ipCount.Val() >= int64(cfg.MaxAttemptsPerIP)
+
+	// The remaining time is negative if the key does not exist or has no expiration
+	if lock.Val() > 0 {
+		t.LockedFor = lock.Val()
+	}
+
+	// Delay the attempt progressively, based on whichever has failed the most
+	failures := ipCount.Val()
+	if emailCount.Val() > failures {
+		failures = emailCount.Val()
+	}
+	if cfg.DelayAfter > 0 && failures >= int64(cfg.DelayAfter) {
+		t.Delay = cfg.Delay
+		for i := int64(cfg.DelayAfter); i < failures && t.Delay < cfg.MaxDelay; i++ {
+			t.Delay *= 2
+		}
+		if t.Delay > cfg.MaxDelay {
+			t.Delay = cfg.MaxDelay
+		}
+	}
+
+	return t, nil
+}
+
+// FailLogin records a failed login attempt from a given IP address for a given email address.
+// If there have been too many failed attempts for the email address within the window, the account is locked for
+// the duration stored in configuration and true is returned, only for the attempt which locked it.
+func (c *AuthClient) FailLogin(ctx context.Context, ip, email string) (bool, error) {
+	cfg := c.config.App.Login
+	ipKey, emailKey := loginAttemptsIPKey(ip), loginAttemptsEmailKey(email)
+	now := time.Now().UnixNano()
+	since := strconv.FormatInt(now-cfg.Window.Nanoseconds(), 10)
+	attempt := &redis.Z{Score: float64(now), Member: now}
+
+	var emailCount *redis.IntCmd
+	_, err := c.cache.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, ipKey, attempt)
+		pipe.ZRemRangeByScore(ctx, ipKey, "-inf", "("+since)
+		pipe.Expire(ctx, ipKey, cfg.Window)
+		pipe.ZAdd(ctx, emailKey, attempt)
+		pipe.ZRemRangeByScore(ctx, emailKey, "-inf", "("+since)
+		pipe.Expire(ctx, emailKey, cfg.Window)
+		emailCount = pipe.ZCard(ctx, emailKey)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if cfg.MaxAttemptsPerEmail <= 0 || emailCount.Val() < int64(cfg.MaxAttemptsPerEmail) {
+		return false, nil
+	}
+
+	locked, err := c.cache.Client.SetNX(ctx, loginLockKey(email), now, cfg.LockoutDuration).Result()
+	if err != nil {
+		return false, err
+	}
+
+	// Start counting again once the account is unlocked
+	if err = c.cache.Client.Del(ctx, emailKey).Err(); err != nil {
+		return false, err
+	}
+
+	return locked, nil
+}
+
+// ClearLoginAttempts clears the failed login attempts for a given email address, such as once the user has
+// logged in. The attempts of the IP addresses they were made from are kept.
+func (c *AuthClient) ClearLoginAttempts(ctx context.Context, email string) error {
+	return c.cache.Client.Del(ctx, loginAttemptsEmailKey(email)).Err()
+}
+
+// UnlockLogin unlocks the account of a given email address and clears its failed login attempts
+func (c *AuthClient) UnlockLogin(ctx context.Context, email string) error {
+	return c.cache.Client.Del(ctx, loginLockKey(email), loginAttemptsEmailKey(email)).Err()
+}
+
+// GenerateUnlockToken generates a token, using JWT, which unlocks the account of a given email address and is
+// set to expire when the lockout does
+func (c *AuthClient) GenerateUnlockToken(email string) (string, error) {
+	return c.signToken(jwt.MapClaims{
+		"unlock": strings.ToLower(email),
+		"exp":    time.Now().Add(c.config.App.Login.LockoutDuration).Unix(),
+	})
+}
+
+// ValidateUnlockToken validates an unlock token and returns the associated email address if the token is valid
+// and has not expired
+func (c *AuthClient) ValidateUnlockToken(token string) (string, error) {
+	claims, err := c.parseToken(token)
+	if err != nil {
+		return "", err
+	}
+
+	if email, ok := claims["unlock"].(string); ok {
+		return email, nil
+	}
+
+	return "", errors.New("invalid or expired token")
+}
+
+// loginAttemptsIPKey returns the cache key which stores the failed login attempts of a given IP address
+func loginAttemptsIPKey(ip string) string {
+	return fmt.Sprintf("%s%s", loginAttemptsIPKeyPrefix, ip)
+}
+
+// loginAttemptsEmailKey returns the cache key which stores the failed login attempts for a given email address
+func loginAttemptsEmailKey(email string) string {
+	return fmt.Sprintf("%s%s", loginAttemptsEmailKeyPrefix, strings.ToLower(email))
+}
+
+// loginLockKey returns the cache key which indicates that the account of a given email address is locked
+func loginLockKey(email string) string {
+	return fmt.Sprintf("%s%s", loginLockKeyPrefix, strings.ToLower(email))
+}
@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/credential"
+	"github.com/mikestefanello/pagoda/ent/user"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+)
+
+// passkeyCeremonyTimeout is how long a user has to complete registering or logging in with a passkey
+const passkeyCeremonyTimeout = 5 * time.Minute
+
+// InvalidPasskeyError is an error returned when a passkey cannot be registered or used to log in
+type InvalidPasskeyError struct{}
+
+// Error implements the error interface.
+func (e InvalidPasskeyError) Error() string {
+	return "invalid passkey"
+}
+
+// passkeyUser adapts a user and their credentials to the webauthn.User interface
+type passkeyUser struct {
+	user        *ent.User
+	credentials []*ent.Credential
+}
+
+// WebAuthnID returns the user handle, which is stored on passkeys so they can identify the user when logging in
+func (u passkeyUser) WebAuthnID() []byte {
+	return passkeyUserHandle(u.user.ID)
+}
+
+// WebAuthnName returns the name of the user account shown by authenticators
+func (u passkeyUser) WebAuthnName() string {
+	return u.user.Email
+}
+
+// WebAuthnDisplayName returns the display name of the user account shown by authenticators
+func (u passkeyUser) WebAuthnDisplayName() string {
+	return u.user.Name
+}
+
+// WebAuthnIcon is deprecated and not used
+func (u passkeyUser) WebAuthnIcon() string {
+	return ""
+}
+
+// WebAuthnCredentials returns the user's passkeys
+func (u passkeyUser) WebAuthnCredentials() []webauthn.Credential {
+	creds := make([]webauthn.Credential, 0, len(u.credentials))
+	for _, cred := range u.credentials {
+		transports := make([]protocol.AuthenticatorTransport, 0, len(cred.Transports))
+		for _, t := range cred.Transports {
+			transports = append(transports, protocol.AuthenticatorTransport(t))
+		}
+
+		creds = append(creds, webauthn.Credential{
+			ID:              cred.CredentialID,
+			PublicKey:       cred.PublicKey,
+			AttestationType: cred.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: cred.BackupEligible,
+				BackupState:    cred.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    cred.Aaguid,
+				SignCount: cred.SignCount,
+			},
+		})
+	}
+	return creds
+}
+
+// BeginPasskeyRegistration starts registering a new passkey for a given user and returns the options to pass to
+// navigator.credentials.create() in the browser. The challenge is stored in the session.
+func (c *AuthClient) BeginPasskeyRegistration(ctx echo.Context, u *ent.User) (*protocol.CredentialCreation, error) {
+	w, err := c.webAuthn()
+	if err != nil {
+		return nil, err
+	}
+
+	pu, err := c.getPasskeyUser(ctx.Request().Context(), u)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prevent registering the same authenticator twice
+	exclusions := make([]protocol.CredentialDescriptor, 0, len(pu.credentials))
+	for _, cred := range pu.WebAuthnCredentials() {
+		exclusions = append(exclusions, cred.Descriptor())
+	}
+
+	creation, data, err := w.BeginRegistration(
+		pu,
+		webauthn.WithExclusions(exclusions),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.saveCeremony(ctx, authSessionKeyPasskeyRegistration, data); err != nil {
+		return nil, err
+	}
+
+	return creation, nil
+}
+
+// FinishPasskeyRegistration verifies the response of navigator.credentials.create(), as JSON, against the
+// challenge in the session and saves the passkey for a given user with a given name
+func (c *AuthClient) FinishPasskeyRegistration(ctx echo.Context, u *ent.User, name, response string) (*ent.Credential, error) {
+	w, err := c.webAuthn()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.takeCeremony(ctx, authSessionKeyPasskeyRegistration)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBody(strings.NewReader(response))
+	if err != nil {
+		return nil, InvalidPasskeyError{}
+	}
+
+	pu, err := c.getPasskeyUser(ctx.Request().Context(), u)
+	if err != nil {
+		return nil, err
+	}
+
+	cred, err := w.CreateCredential(pu, *data, parsed)
+	if err != nil {
+		return nil, InvalidPasskeyError{}
+	}
+
+	transports := make([]string, 0, len(cred.Transport))
+	for _, t := range cred.Transport {
+		transports = append(transports, string(t))
+	}
+
+	saved, err := c.orm.Credential.
+		Create().
+		SetCredentialID(cred.ID).
+		SetPublicKey(cred.PublicKey).
+		SetName(name).
+		SetAttestationType(cred.AttestationType).
+		SetTransports(transports).
+		SetAaguid(cred.Authenticator.AAGUID).
+		SetSignCount(cred.Authenticator.SignCount).
+		SetBackupEligible(cred.Flags.BackupEligible).
+		SetBackupState(cred.Flags.BackupState).
+		SetUserID(u.ID).
+		Save(ctx.Request().Context())
+
+	// The passkey is already registered
+	if ent.IsConstraintError(err) {
+		return nil, InvalidPasskeyError{}
+	}
+
+	return saved, err
+}
+
+// BeginPasskeyLogin starts logging in with a passkey and returns the options to pass to navigator.credentials.get()
+// in the browser. The user is not known until the passkey they choose is returned. The challenge is stored in the
+// session.
+func (c *AuthClient) BeginPasskeyLogin(ctx echo.Context) (*protocol.CredentialAssertion, error) {
+	w, err := c.webAuthn()
+	if err != nil {
+		return nil, err
+	}
+
+	assertion, data, err := w.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.saveCeremony(ctx, authSessionKeyPasskeyLogin, data); err != nil {
+		return nil, err
+	}
+
+	return assertion, nil
+}
+
+// FinishPasskeyLogin verifies the response of navigator.credentials.get(), as JSON, against the challenge in the
+// session and returns the user the passkey belongs to. The user is not logged in; Login must be called.
+func (c *AuthClient) FinishPasskeyLogin(ctx echo.Context, response string) (*ent.User, error) {
+	w, err := c.webAuthn()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.takeCeremony(ctx, authSessionKeyPasskeyLogin)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(strings.NewReader(response))
+	if err != nil {
+		return nil, InvalidPasskeyError{}
+	}
+
+	// Load the user identified by the user handle stored on the passkey
+	var pu passkeyUser
+	handler := func(rawID, userHandle []byte) (webauthn.User, error) {
+		if len(userHandle) != 8 {
+			return nil, InvalidPasskeyError{}
+		}
+
+		u, err := c.orm.User.Get(ctx.Request().Context(), int(binary.BigEndian.Uint64(userHandle)))
+		if err != nil {
+			return nil, err
+		}
+
+		pu, err = c.getPasskeyUser(ctx.Request().Context(), u)
+		return pu, err
+	}
+
+	cred, err := w.ValidateDiscoverableLogin(handler, *data, parsed)
+	if err != nil {
+		return nil, InvalidPasskeyError{}
+	}
+
+	// A sign count lower than the stored one indicates the passkey may have been cloned
+	if cred.Authenticator.CloneWarning {
+		return nil, InvalidPasskeyError{}
+	}
+
+	err = c.orm.Credential.
+		Update().
+		Where(credential.CredentialID(cred.ID)).
+		SetSignCount(cred.Authenticator.SignCount).
+		SetBackupState(cred.Flags.BackupState).
+		SetLastUsedAt(time.Now()).
+		Exec(ctx.Request().Context())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pu.user, nil
+}
+
+// getPasskeyUser loads the passkeys of a given user
+func (c *AuthClient) getPasskeyUser(ctx context.Context, u *ent.User) (passkeyUser, error) {
+	creds, err := c.orm.Credential.
+		Query().
+		Where(credential.HasUserWith(user.ID(u.ID))).
+		All(ctx)
+
+	return passkeyUser{user: u, credentials: creds}, err
+}
+
+// webAuthn returns a WebAuthn relying party for the application, identified by the host of the application URL
+func (c *AuthClient) webAuthn() (*webauthn.WebAuthn, error) {
+	u, err := url.Parse(c.config.App.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := webauthn.TimeoutConfig{
+		Enforce:    true,
+		Timeout:    passkeyCeremonyTimeout,
+		TimeoutUVD: passkeyCeremonyTimeout,
+	}
+
+	return webauthn.New(&webauthn.Config{
+		RPID:          u.Hostname(),
+		RPDisplayName: c.config.App.Name,
+		RPOrigins:     []string{u.Scheme + "://" + u.Host},
+		Timeouts: webauthn.TimeoutsConfig{
+			Login:        timeout,
+			Registration: timeout,
+		},
+	})
+}
+
+// saveCeremony stores the data of a passkey registration or login ceremony in the session under a given key
+func (c *AuthClient) saveCeremony(ctx echo.Context, key string, data *webauthn.SessionData) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	sess, err := session.Get(authSessionName, ctx)
+	if err != nil {
+		return err
+	}
+	sess.Values[key] = string(b)
+	return sess.Save(ctx.Request(), ctx.Response())
+}
+
+// takeCeremony removes and returns the data of a passkey registration or login ceremony from the session, so each
+// challenge can only be used once
+func (c *AuthClient) takeCeremony(ctx echo.Context, key string) (*webauthn.SessionData, error) {
+	sess, err := session.Get(authSessionName, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := sess.Values[key].(string)
+	if !ok {
+		return nil, InvalidPasskeyError{}
+	}
+
+	delete(sess.Values, key)
+	if err = sess.Save(ctx.Request(), ctx.Response()); err != nil {
+		return nil, err
+	}
+
+	var data webauthn.SessionData
+	if err = json.Unmarshal([]byte(raw), &data); err != nil {
+		return nil, InvalidPasskeyError{}
+	}
+
+	return &data, nil
+}
+
+// passkeyUserHandle returns the user handle of a user of a given ID
+func passkeyUserHandle(userID int) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(userID))
+	return b
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// authSessionsKeyPrefix is the prefix of the cache keys which index the sessions each user is logged in to
+	authSessionsKeyPrefix = "auth:sessions:"
+
+	// authSessionTouchInterval is how often the last seen details of a session are updated
+	authSessionTouchInterval = time.Minute
+)
+
+// ErrAuthSessionNotFound is returned when a user is not logged in to a given session
+var ErrAuthSessionNotFound = errors.New("session not found")
+
+type (
+	// AuthSession is a session in which a user is logged in
+	AuthSession struct {
+		// Key identifies the session without revealing the session ID
+		Key string `json:"-"`
+
+		// UserAgent is the user agent of the most recent request
+		UserAgent string `json:"user_agent"`
+
+		// IP is the IP address of the most recent request
+		IP string `json:"ip"`
+
+		// CreatedAt is when the user logged in
+		CreatedAt time.Time `json:"created_at"`
+
+		// LastSeenAt is when the session was last used, to within authSessionTouchInterval
+		LastSeenAt time.Time `json:"last_seen_at"`
+
+		// Current indicates if this is the session of the request it was loaded in
+		Current bool `json:"-"`
+	}
+
+	// authSessionRecord is an AuthSession as stored in the cache
+	authSessionRecord struct {
+		SessionID string `json:"session_id"`
+		AuthSession
+	}
+)
+
+// Device returns a short description of the browser and operating system in the user agent of the session
+func (s AuthSession) Device() string {
+	ua := s.UserAgent
+
+	var browser string
+	switch {
+	case strings.Contains(ua, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "OPR/"):
+		browser = "Opera"
+	case strings.Contains(ua, "Firefox/"), strings.Contains(ua, "FxiOS/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "Chrome/"), strings.Contains(ua, "CriOS/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "Safari/"):
+		browser = "Safari"
+	}
+
+	var os string
+	switch {
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"):
+		os = "iOS"
+	case strings.Contains(ua, "Android"):
+		os = "Android"
+	case strings.Contains(ua, "Windows"):
+		os = "Windows"
+	case strings.Contains(ua, "Mac OS X"):
+		os = "macOS"
+	case strings.Contains(ua, "Linux"):
+		os = "Linux"
+	}
+
+	switch {
+	case browser != "" && os != "":
+		return fmt.Sprintf("%s on %s", browser, os)
+	case browser != "":
+		return browser
+	case os != "":
+		return os
+	default:
+		return "Unknown device"
+	}
+}
+
+// Sessions returns the sessions a given user is logged in to, most recently used first
+func (c *AuthClient) Sessions(ctx echo.Context, userID int) ([]AuthSession, error) {
+	rctx := ctx.Request().Context()
+	indexKey := authSessionsKey(userID)
+
+	items, err := c.cache.Client.HGetAll(rctx, indexKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	currentID, err := c.sessionID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]AuthSession, 0, len(items))
+	var expired []string
+	for key, item := range items {
+		var record authSessionRecord
+		if err = json.Unmarshal([]byte(item), &record); err != nil {
+			return nil, err
+		}
+
+		// Sessions which expired remain in the index until they are found here
+		exists, err := c.sessions.Exists(rctx, record.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			expired = append(expired, key)
+			continue
+		}
+
+		s := record.AuthSession
+		s.Key = key
+		s.Current = record.SessionID == currentID
+		sessions = append(sessions, s)
+	}
+
+	if len(expired) > 0 {
+		if err = c.cache.Client.HDel(rctx, indexKey, expired...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// RevokeSession logs a given user out of the session with a given key
+func (c *AuthClient) RevokeSession(ctx context.Context, userID int, key string) error {
+	indexKey := authSessionsKey(userID)
+
+	item, err := c.cache.Client.HGet(ctx, indexKey, key).Bytes()
+	switch {
+	case err == nil:
+	case errors.Is(err, redis.Nil):
+		return ErrAuthSessionNotFound
+	default:
+		return err
+	}
+
+	var record authSessionRecord
+	if err = json.Unmarshal(item, &record); err != nil {
+		return err
+	}
+
+	if err = c.sessions.Delete(ctx, record.SessionID); err != nil {
+		return err
+	}
+
+	return c.cache.Client.HDel(ctx, indexKey, key).Err()
+}
+
+// RevokeSessions logs a given user out of every session they are logged in to.
+// This should be called after the user's password is changed.
+func (c *AuthClient) RevokeSessions(ctx context.Context, userID int) error {
+	indexKey := authSessionsKey(userID)
+
+	items, err := c.cache.Client.HGetAll(ctx, indexKey).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		var record authSessionRecord
+		if err = json.Unmarshal([]byte(item), &record); err != nil {
+			return err
+		}
+
+		if err = c.sessions.Delete(ctx, record.SessionID); err != nil {
+			return err
+		}
+	}
+
+	return c.cache.Client.Del(ctx, indexKey).Err()
+}
+
+// touchSession adds a given session to the index of the sessions a user is logged in to, or updates its last
+// seen details if they have not been updated within authSessionTouchInterval
+func (c *AuthClient) touchSession(ctx echo.Context, userID int, sessionID string) error {
+	rctx := ctx.Request().Context()
+	indexKey := authSessionsKey(userID)
+	key := authSessionKey(sessionID)
+	now := time.Now()
+
+	record := authSessionRecord{
+		SessionID: sessionID,
+		AuthSession: AuthSession{
+			CreatedAt: now,
+		},
+	}
+
+	item, err := c.cache.Client.HGet(rctx, indexKey, key).Bytes()
+	switch {
+	case err == nil:
+		if err = json.Unmarshal(item, &record); err != nil {
+			return err
+		}
+
+		if now.Sub(record.LastSeenAt) < authSessionTouchInterval {
+			return nil
+		}
+	case errors.Is(err, redis.Nil):
+	default:
+		return err
+	}
+
+	record.UserAgent = ctx.Request().UserAgent()
+	record.IP = ctx.RealIP()
+	record.LastSeenAt = now
+
+	item, err = json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.cache.Client.TxPipelined(rctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(rctx, indexKey, key, item)
+		pipe.Expire(rctx, indexKey, c.sessions.Expiration())
+		return nil
+	})
+	return err
+}
+
+// untrackSession removes a given session from the index of the sessions a user is logged in to
+func (c *AuthClient) untrackSession(ctx context.Context, userID int, sessionID string) error {
+	return c.cache.Client.HDel(ctx, authSessionsKey(userID), authSessionKey(sessionID)).Err()
+}
+
+// sessionID returns the ID of the authentication session of the request.
+// This is empty if the session is new or the session store does not keep sessions on the server.
+func (c *AuthClient) sessionID(ctx echo.Context) (string, error) {
+	sess, err := session.Get(authSessionName, ctx)
+	if err != nil {
+		return "", err
+	}
+	return sess.ID, nil
+}
+
+// authSessionsKey returns the cache key of the index of the sessions a given user is logged in to
+func authSessionsKey(userID int) string {
+	return fmt.Sprintf("%s%d", authSessionsKeyPrefix, userID)
+}
+
+// authSessionKey returns the key which identifies a given session within the index, so the session ID itself
+// is never exposed
+func authSessionKey(sessionID string) string {
+	h := sha256.Sum256([]byte(sessionID))
+	return hex.EncodeToString(h[:16])
+}
@@ -3,11 +3,22 @@ package services
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/mikestefanello/pagoda/ent/auditevent"
 	"github.com/mikestefanello/pagoda/ent/passwordtoken"
+	"github.com/mikestefanello/pagoda/ent/recoverycode"
 	"github.com/mikestefanello/pagoda/ent/user"
+	"github.com/mikestefanello/pagoda/pkg/audit"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+	"github.com/pquerna/otp/totp"
 
 	"github.com/stretchr/testify/require"
 
@@ -24,8 +35,23 @@ func TestAuthClient_Auth(t *testing.T) {
 
 	assertNoAuth()
 
+	// Logging in and out is recorded in the audit log
+	countEvents := func(action audit.Action) int {
+		count, err := c.ORM.AuditEvent.
+			Query().
+			Where(
+				auditevent.Action(string(action)),
+				auditevent.HasUserWith(user.ID(usr.ID)),
+			).
+			Count(context.Background())
+		require.NoError(t, err)
+		return count
+	}
+	logins, logouts := countEvents(audit.Login), countEvents(audit.Logout)
+
 	err := c.Auth.Login(ctx, usr.ID)
 	require.NoError(t, err)
+	assert.Equal(t, logins+1, countEvents(audit.Login))
 
 	uid, err := c.Auth.GetAuthenticatedUserID(ctx)
 	require.NoError(t, err)
@@ -37,10 +63,31 @@ func TestAuthClient_Auth(t *testing.T) {
 
 	err = c.Auth.Logout(ctx)
 	require.NoError(t, err)
+	assert.Equal(t, logouts+1, countEvents(audit.Logout))
 
 	assertNoAuth()
 }
 
+func TestAuthClient_Banned(t *testing.T) {
+	banned, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	err = c.Auth.Login(ctx, banned.ID)
+	require.NoError(t, err)
+
+	err = banned.Update().
+		SetBannedAt(time.Now()).
+		Exec(context.Background())
+	require.NoError(t, err)
+
+	// Banned users are no longer logged in
+	_, err = c.Auth.GetAuthenticatedUser(ctx)
+	assert.True(t, errors.Is(err, NotAuthenticatedError{}))
+
+	err = c.Auth.Logout(ctx)
+	require.NoError(t, err)
+}
+
 func TestAuthClient_PasswordHashing(t *testing.T) {
 	pw := "testcheckpassword"
 	hash, err := c.Auth.HashPassword(pw)
@@ -144,3 +191,386 @@ func TestAuthClient_EmailVerificationToken(t *testing.T) {
 		c.Config.App.EmailVerificationTokenExpiration = time.Hour * 12
 	})
 }
+
+func TestAuthClient_SubscriberTokens(t *testing.T) {
+	email := "subscriber@localhost.com"
+
+	confirm, err := c.Auth.GenerateSubscriptionToken(email)
+	require.NoError(t, err)
+	unsubscribe, err := c.Auth.GenerateUnsubscribeToken(email)
+	require.NoError(t, err)
+
+	tokenEmail, err := c.Auth.ValidateSubscriptionToken(confirm)
+	require.NoError(t, err)
+	assert.Equal(t, email, tokenEmail)
+
+	tokenEmail, err = c.Auth.ValidateUnsubscribeToken(unsubscribe)
+	require.NoError(t, err)
+	assert.Equal(t, email, tokenEmail)
+
+	// Tokens cannot be used for another purpose
+	_, err = c.Auth.ValidateSubscriptionToken(unsubscribe)
+	assert.Error(t, err)
+	_, err = c.Auth.ValidateUnsubscribeToken(confirm)
+	assert.Error(t, err)
+	_, err = c.Auth.ValidateEmailVerificationToken(confirm)
+	assert.Error(t, err)
+
+	verify, err := c.Auth.GenerateEmailVerificationToken(email)
+	require.NoError(t, err)
+	_, err = c.Auth.ValidateSubscriptionToken(verify)
+	assert.Error(t, err)
+
+	// Confirmation tokens expire
+	c.Config.Newsletter.ConfirmationTokenExpiration = -time.Hour
+	confirm, err = c.Auth.GenerateSubscriptionToken(email)
+	require.NoError(t, err)
+	_, err = c.Auth.ValidateSubscriptionToken(confirm)
+	assert.Error(t, err)
+	c.Config.Newsletter.ConfirmationTokenExpiration = time.Hour * 48
+}
+
+func TestAuthClient_Sessions(t *testing.T) {
+	u, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	// newContext creates a context for a request from a device with the given session cookies
+	newContext := func(userAgent string, cookies ...*http.Cookie) (echo.Context, *httptest.ResponseRecorder) {
+		ctx, rec := tests.NewContext(c.Web, "/")
+		ctx.Request().Header.Set("User-Agent", userAgent)
+		for _, cookie := range cookies {
+			ctx.Request().AddCookie(cookie)
+		}
+		require.NoError(t, tests.ExecuteMiddleware(ctx, session.Middleware(c.Sessions)))
+		return ctx, rec
+	}
+
+	// Log in on two devices
+	laptop, rec := newContext("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) Firefox/120.0")
+	require.NoError(t, c.Auth.Login(laptop, u.ID))
+	laptopCookies := rec.Result().Cookies()
+
+	phone, rec := newContext("Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) Safari/604.1")
+	require.NoError(t, c.Auth.Login(phone, u.ID))
+	phoneCookies := rec.Result().Cookies()
+
+	sessions, err := c.Auth.Sessions(laptop, u.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	devices := map[string]bool{}
+	for _, s := range sessions {
+		devices[s.Device()] = s.Current
+	}
+	assert.Equal(t, map[string]bool{"Firefox on macOS": true, "Safari on iOS": false}, devices)
+
+	// Revoke the session on the phone
+	for _, s := range sessions {
+		if !s.Current {
+			require.NoError(t, c.Auth.RevokeSession(context.Background(), u.ID, s.Key))
+		}
+	}
+	assert.ErrorIs(t, c.Auth.RevokeSession(context.Background(), u.ID, "missing"), ErrAuthSessionNotFound)
+
+	phone, _ = newContext("", phoneCookies...)
+	_, err = c.Auth.GetAuthenticatedUserID(phone)
+	assert.True(t, errors.Is(err, NotAuthenticatedError{}))
+
+	laptop, _ = newContext("", laptopCookies...)
+	uid, err := c.Auth.GetAuthenticatedUserID(laptop)
+	require.NoError(t, err)
+	assert.Equal(t, u.ID, uid)
+
+	sessions, err = c.Auth.Sessions(laptop, u.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+
+	// Log out everywhere
+	require.NoError(t, c.Auth.RevokeSessions(context.Background(), u.ID))
+	laptop, _ = newContext("", laptopCookies...)
+	_, err = c.Auth.GetAuthenticatedUserID(laptop)
+	assert.True(t, errors.Is(err, NotAuthenticatedError{}))
+
+	sessions, err = c.Auth.Sessions(laptop, u.ID)
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
+
+func TestAuthClient_LoginThrottle(t *testing.T) {
+	cfg := c.Config.App.Login
+	defer func() {
+		c.Config.App.Login = cfg
+	}()
+	c.Config.App.Login.MaxAttemptsPerIP = 5
+	c.Config.App.Login.MaxAttemptsPerEmail = 3
+	c.Config.App.Login.DelayAfter = 2
+	c.Config.App.Login.Delay = time.Second
+	c.Config.App.Login.MaxDelay = 3 * time.Second
+
+	ctx := context.Background()
+	ip, email := "192.0.2.10", "Throttle@localhost.localhost"
+
+	throttle, err := c.Auth.LoginThrottle(ctx, ip, email)
+	require.NoError(t, err)
+	assert.Equal(t, LoginThrottle{}, throttle)
+
+	fail := func(ip, email string) bool {
+		locked, err := c.Auth.FailLogin(ctx, ip, email)
+		require.NoError(t, err)
+		return locked
+	}
+
+	// Attempts are delayed progressively
+	assert.False(t, fail(ip, email))
+	throttle, err = c.Auth.LoginThrottle(ctx, ip, email)
+	require.NoError(t, err)
+	assert.Zero(t, throttle.Delay)
+
+	assert.False(t, fail(ip, email))
+	throttle, err = c.Auth.LoginThrottle(ctx, ip, email)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, throttle.Delay)
+	assert.False(t, throttle.Locked())
+
+	// The account is locked once the email address has too many failed attempts, which is only reported once
+	assert.True(t, fail(ip, email))
+	throttle, err = c.Auth.LoginThrottle(ctx, ip, strings.ToLower(email))
+	require.NoError(t, err)
+	assert.True(t, throttle.Locked())
+	assert.False(t, throttle.Blocked)
+	assert.Equal(t, 2*time.Second, throttle.Delay)
+
+	assert.False(t, fail(ip, email))
+	assert.False(t, fail(ip, email))
+	assert.False(t, fail(ip, email))
+
+	// Other email addresses are not locked, but the IP address now has too many failed attempts
+	throttle, err = c.Auth.LoginThrottle(ctx, ip, "other@localhost.localhost")
+	require.NoError(t, err)
+	assert.False(t, throttle.Locked())
+	assert.True(t, throttle.Blocked)
+
+	// Unlocking the account clears its attempts, but not those of the IP address
+	require.NoError(t, c.Auth.UnlockLogin(ctx, email))
+	throttle, err = c.Auth.LoginThrottle(ctx, "192.0.2.11", email)
+	require.NoError(t, err)
+	assert.Equal(t, LoginThrottle{}, throttle)
+
+	assert.False(t, fail("192.0.2.11", email))
+	require.NoError(t, c.Auth.ClearLoginAttempts(ctx, email))
+	throttle, err = c.Auth.LoginThrottle(ctx, "192.0.2.12", email)
+	require.NoError(t, err)
+	assert.Equal(t, LoginThrottle{}, throttle)
+}
+
+func TestAuthClient_UnlockToken(t *testing.T) {
+	token, err := c.Auth.GenerateUnlockToken("Unlock@localhost.localhost")
+	require.NoError(t, err)
+
+	email, err := c.Auth.ValidateUnlockToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "unlock@localhost.localhost", email)
+
+	// Other tokens cannot unlock accounts
+	verify, err := c.Auth.GenerateEmailVerificationToken(email)
+	require.NoError(t, err)
+	_, err = c.Auth.ValidateUnlockToken(verify)
+	assert.Error(t, err)
+
+	_, err = c.Auth.ValidateEmailVerificationToken(token)
+	assert.Error(t, err)
+
+	// Tokens expire
+	lockout := c.Config.App.Login.LockoutDuration
+	c.Config.App.Login.LockoutDuration = -time.Hour
+	token, err = c.Auth.GenerateUnlockToken(email)
+	require.NoError(t, err)
+	_, err = c.Auth.ValidateUnlockToken(token)
+	assert.Error(t, err)
+	c.Config.App.Login.LockoutDuration = lockout
+}
+
+func TestAuthSession_Device(t *testing.T) {
+	userAgents := map[string]string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36":           "Chrome on Windows",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0": "Edge on Windows",
+		"Mozilla/5.0 (X11; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0":                                                    "Firefox on Linux",
+		"Mozilla/5.0 (Linux; Android 14) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36":              "Chrome on Android",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Safari/605.1.15":     "Safari on macOS",
+		"curl/8.4.0": "Unknown device",
+		"":           "Unknown device",
+	}
+
+	for ua, device := range userAgents {
+		assert.Equal(t, device, AuthSession{UserAgent: ua}.Device(), ua)
+	}
+}
+
+func TestAuthClient_TwoFactorLogin(t *testing.T) {
+	u, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	ctx, _ := tests.NewContext(c.Web, "/")
+	tests.InitSession(ctx)
+
+	_, err = c.Auth.GetTwoFactorUserID(ctx)
+	assert.True(t, errors.Is(err, NotAuthenticatedError{}))
+
+	// The session is half-authenticated, so the user is not yet logged in
+	err = c.Auth.StartTwoFactorLogin(ctx, u.ID)
+	require.NoError(t, err)
+	uid, err := c.Auth.GetTwoFactorUserID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, u.ID, uid)
+	_, err = c.Auth.GetAuthenticatedUserID(ctx)
+	assert.True(t, errors.Is(err, NotAuthenticatedError{}))
+
+	// Too many incorrect codes
+	for i := 0; i < twoFactorLoginMaxAttempts; i++ {
+		require.NoError(t, c.Auth.FailTwoFactorLogin(context.Background(), u.ID))
+	}
+	_, err = c.Auth.GetTwoFactorUserID(ctx)
+	assert.True(t, errors.Is(err, NotAuthenticatedError{}))
+
+	// The incorrect codes are counted per user, so starting again, even with another session, does not reset them
+	ctx2, _ := tests.NewContext(c.Web, "/")
+	tests.InitSession(ctx2)
+	err = c.Auth.StartTwoFactorLogin(ctx2, u.ID)
+	require.NoError(t, err)
+	_, err = c.Auth.GetTwoFactorUserID(ctx2)
+	assert.True(t, errors.Is(err, NotAuthenticatedError{}))
+
+	// Logging in clears the half-authenticated state
+	err = c.Auth.StartTwoFactorLogin(ctx, u.ID)
+	require.NoError(t, err)
+	err = c.Auth.Login(ctx, u.ID)
+	require.NoError(t, err)
+	_, err = c.Auth.GetTwoFactorUserID(ctx)
+	assert.True(t, errors.Is(err, NotAuthenticatedError{}))
+}
+
+func TestAuthClient_TwoFactorCodes(t *testing.T) {
+	u, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	key, err := c.Auth.GenerateTOTPKey(u.Email)
+	require.NoError(t, err)
+	key2, err := c.Auth.GetTOTPKey(u.Email, key.Secret())
+	require.NoError(t, err)
+	assert.Equal(t, key.Secret(), key2.Secret())
+
+	code, err := totp.GenerateCode(key.Secret(), time.Now())
+	require.NoError(t, err)
+	assert.True(t, c.Auth.ValidateTOTPCode(key.Secret(), code))
+	assert.False(t, c.Auth.ValidateTOTPCode(key.Secret(), "000000x"))
+
+	recovery, err := c.Auth.EnableTOTP(context.Background(), u.ID, key.Secret())
+	require.NoError(t, err)
+	assert.Len(t, recovery, recoveryCodeCount)
+
+	u, err = c.ORM.User.Get(context.Background(), u.ID)
+	require.NoError(t, err)
+	require.NotNil(t, u.TotpSecret)
+
+	// TOTP code
+	used, err := c.Auth.ValidateTwoFactorCode(context.Background(), u, code)
+	require.NoError(t, err)
+	assert.False(t, used)
+
+	// A TOTP code cannot be used again, nor can a code for an earlier step
+	_, err = c.Auth.ValidateTwoFactorCode(context.Background(), u, code)
+	assert.Equal(t, InvalidTwoFactorCodeError{}, err)
+	previous, err := totp.GenerateCode(key.Secret(), time.Now().Add(-totpPeriod*time.Second))
+	require.NoError(t, err)
+	_, err = c.Auth.ValidateTwoFactorCode(context.Background(), u, previous)
+	assert.Equal(t, InvalidTwoFactorCodeError{}, err)
+
+	// Valid codes clear the incorrect codes entered
+	require.NoError(t, c.Auth.FailTwoFactorLogin(context.Background(), u.ID))
+	next, err := totp.GenerateCode(key.Secret(), time.Now().Add(totpPeriod*time.Second))
+	require.NoError(t, err)
+	_, err = c.Auth.ValidateTwoFactorCode(context.Background(), u, next)
+	require.NoError(t, err)
+	exists, err := c.Cache.Client.Exists(context.Background(), twoFactorAttemptsKey(u.ID)).Result()
+	require.NoError(t, err)
+	assert.Zero(t, exists)
+
+	// Recovery codes can only be used once, and are not case sensitive
+	used, err = c.Auth.ValidateTwoFactorCode(context.Background(), u, strings.ToUpper(recovery[0]))
+	require.NoError(t, err)
+	assert.True(t, used)
+	_, err = c.Auth.ValidateTwoFactorCode(context.Background(), u, recovery[0])
+	assert.Equal(t, InvalidTwoFactorCodeError{}, err)
+
+	// Regenerating replaces the previous codes
+	regenerated, err := c.Auth.GenerateRecoveryCodes(context.Background(), u.ID)
+	require.NoError(t, err)
+	_, err = c.Auth.ValidateTwoFactorCode(context.Background(), u, recovery[1])
+	assert.Equal(t, InvalidTwoFactorCodeError{}, err)
+
+	err = c.Auth.DisableTOTP(context.Background(), u.ID)
+	require.NoError(t, err)
+	count, err := c.ORM.RecoveryCode.Query().Where(recoverycode.HasUserWith(user.ID(u.ID))).Count(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	err = c.Auth.UseRecoveryCode(context.Background(), u.ID, regenerated[0])
+	assert.Equal(t, InvalidTwoFactorCodeError{}, err)
+}
+
+func TestAuthClient_Passkeys(t *testing.T) {
+	u, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+
+	ctx, _ := tests.NewContext(c.Web, "/")
+	tests.InitSession(ctx)
+	authenticator := tests.NewAuthenticator(c.Config.App.URL)
+
+	// A response cannot be used without starting the ceremony
+	_, err = c.Auth.FinishPasskeyLogin(ctx, "{}")
+	assert.Equal(t, InvalidPasskeyError{}, err)
+
+	// Register a passkey
+	creation, err := c.Auth.BeginPasskeyRegistration(ctx, u)
+	require.NoError(t, err)
+	response, err := authenticator.Create(creation)
+	require.NoError(t, err)
+	cred, err := c.Auth.FinishPasskeyRegistration(ctx, u, "Laptop", response)
+	require.NoError(t, err)
+	assert.Equal(t, "Laptop", cred.Name)
+	assert.Nil(t, cred.LastUsedAt)
+
+	// The challenge can only be used once
+	_, err = c.Auth.FinishPasskeyRegistration(ctx, u, "Laptop", response)
+	assert.Equal(t, InvalidPasskeyError{}, err)
+
+	// Log in with the passkey
+	assertion, err := c.Auth.BeginPasskeyLogin(ctx)
+	require.NoError(t, err)
+	response, err = authenticator.Get(assertion)
+	require.NoError(t, err)
+	got, err := c.Auth.FinishPasskeyLogin(ctx, response)
+	require.NoError(t, err)
+	assert.Equal(t, u.ID, got.ID)
+
+	cred, err = c.ORM.Credential.Get(context.Background(), cred.ID)
+	require.NoError(t, err)
+	assert.Equal(t, authenticator.SignCount, cred.SignCount)
+	assert.NotNil(t, cred.LastUsedAt)
+
+	// A sign count which did not increase indicates a cloned passkey
+	assertion, err = c.Auth.BeginPasskeyLogin(ctx)
+	require.NoError(t, err)
+	authenticator.SignCount--
+	response, err = authenticator.Get(assertion)
+	require.NoError(t, err)
+	_, err = c.Auth.FinishPasskeyLogin(ctx, response)
+	assert.Equal(t, InvalidPasskeyError{}, err)
+
+	// A passkey for another origin
+	authenticator.Origin = "https://example.com"
+	assertion, err = c.Auth.BeginPasskeyLogin(ctx)
+	require.NoError(t, err)
+	response, err = authenticator.Get(assertion)
+	require.NoError(t, err)
+	_, err = c.Auth.FinishPasskeyLogin(ctx, response)
+	assert.Equal(t, InvalidPasskeyError{}, err)
+}
@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/recoverycode"
+	"github.com/mikestefanello/pagoda/ent/user"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	// twoFactorLoginExpiration is how long a user has to complete the second step of logging in
+	twoFactorLoginExpiration = 5 * time.Minute
+
+	// twoFactorLoginMaxAttempts is the amount of incorrect codes which can be entered for a user during the second
+	// step of logging in, after which they must wait until no incorrect code has been entered for
+	// twoFactorLoginExpiration before starting again
+	twoFactorLoginMaxAttempts = 5
+
+	// twoFactorAttemptsKeyPrefix is the prefix of the cache keys which store the amount of incorrect codes entered
+	// for each user during the second step of logging in
+	twoFactorAttemptsKeyPrefix = "auth:2fa:attempts:"
+
+	// totpPeriod is the amount of seconds each TOTP code is valid for
+	totpPeriod = 30
+
+	// recoveryCodeCount is the amount of recovery codes generated for each user
+	recoveryCodeCount = 10
+
+	// recoveryCodeLength is the amount of characters within each recovery code
+	recoveryCodeLength = 10
+)
+
+// recoveryCodeEncoding is the encoding of the random bytes within recovery codes
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// InvalidTwoFactorCodeError is an error returned when an invalid TOTP or recovery code is provided
+type InvalidTwoFactorCodeError struct{}
+
+// Error implements the error interface.
+func (e InvalidTwoFactorCodeError) Error() string {
+	return "invalid two-factor code"
+}
+
+// StartTwoFactorLogin starts logging in a user of a given ID who has two-factor authentication enabled.
+// The session is held half-authenticated, in which the user is not yet logged in, until Login is called once
+// a code has been verified.
+func (c *AuthClient) StartTwoFactorLogin(ctx echo.Context, userID int) error {
+	sess, err := session.Get(authSessionName, ctx)
+	if err != nil {
+		return err
+	}
+	sess.Values[authSessionKeyAuthenticated] = false
+	sess.Values[authSessionKeyTwoFactorUserID] = userID
+	sess.Values[authSessionKeyTwoFactorStartedAt] = time.Now().Unix()
+	return sess.Save(ctx.Request(), ctx.Response())
+}
+
+// GetTwoFactorUserID returns the ID of the user who must complete the second step of logging in, if the session
+// is half-authenticated and has not expired, and too many incorrect codes have not been entered for the user
+func (c *AuthClient) GetTwoFactorUserID(ctx echo.Context) (int, error) {
+	sess, err := session.Get(authSessionName, ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	userID, ok := sess.Values[authSessionKeyTwoFactorUserID].(int)
+	if !ok {
+		return 0, NotAuthenticatedError{}
+	}
+
+	startedAt, _ := sess.Values[authSessionKeyTwoFactorStartedAt].(int64)
+	if time.Since(time.Unix(startedAt, 0)) > twoFactorLoginExpiration {
+		return 0, NotAuthenticatedError{}
+	}
+
+	// Attempts are counted per user rather than per session, so starting again does not allow more codes to be
+	// entered
+	attempts, err := c.cache.Client.Get(ctx.Request().Context(), twoFactorAttemptsKey(userID)).Int()
+	switch {
+	case err == redis.Nil:
+	case err != nil:
+		return 0, err
+	case attempts >= twoFactorLoginMaxAttempts:
+		return 0, NotAuthenticatedError{}
+	}
+
+	return userID, nil
+}
+
+// FailTwoFactorLogin records an incorrect code entered for a user of a given ID during the second step of
+// logging in
+func (c *AuthClient) FailTwoFactorLogin(ctx context.Context, userID int) error {
+	key := twoFactorAttemptsKey(userID)
+	_, err := c.cache.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, twoFactorLoginExpiration)
+		return nil
+	})
+	return err
+}
+
+// GenerateTOTPKey generates a new TOTP key for a given email address
+func (c *AuthClient) GenerateTOTPKey(email string) (*otp.Key, error) {
+	return totp.Generate(totp.GenerateOpts{
+		Issuer:      c.config.App.Name,
+		AccountName: email,
+	})
+}
+
+// GetTOTPKey returns the TOTP key of a given secret for a given email address
+func (c *AuthClient) GetTOTPKey(email, secret string) (*otp.Key, error) {
+	raw, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return nil, err
+	}
+
+	return totp.Generate(totp.GenerateOpts{
+		Issuer:      c.config.App.Name,
+		AccountName: email,
+		Secret:      raw,
+	})
+}
+
+// ValidateTOTPCode determines if a given code is currently valid for a given TOTP secret
+func (c *AuthClient) ValidateTOTPCode(secret, code string) bool {
+	_, valid := totpStep(secret, code, time.Now())
+	return valid
+}
+
+// ValidateTwoFactorCode validates a code entered by a given user with two-factor authentication enabled, which can
+// either be a TOTP code or one of their recovery codes, which is then used up. Whether a recovery code was used is
+// returned. Once a valid code is entered, the incorrect codes entered for the user are cleared.
+func (c *AuthClient) ValidateTwoFactorCode(ctx context.Context, u *ent.User, code string) (bool, error) {
+	if u.TotpSecret == nil {
+		return false, InvalidTwoFactorCodeError{}
+	}
+
+	recovery := false
+	if step, valid := totpStep(*u.TotpSecret, code, time.Now()); valid {
+		// The step of the last code accepted is recorded, and codes for it or any before it are rejected, so a code
+		// cannot be used more than once. The condition is checked by the update so concurrent requests cannot both
+		// use the same code.
+		count, err := c.orm.User.
+			Update().
+			Where(
+				user.ID(u.ID),
+				user.Or(
+					user.TotpLastStepIsNil(),
+					user.TotpLastStepLT(step),
+				),
+			).
+			SetTotpLastStep(step).
+			Save(ctx)
+
+		if err != nil {
+			return false, err
+		}
+
+		if count == 0 {
+			return false, InvalidTwoFactorCodeError{}
+		}
+	} else {
+		if err := c.UseRecoveryCode(ctx, u.ID, code); err != nil {
+			return false, err
+		}
+		recovery = true
+	}
+
+	if err := c.cache.Client.Del(ctx, twoFactorAttemptsKey(u.ID)).Err(); err != nil {
+		return false, err
+	}
+
+	return recovery, nil
+}
+
+// EnableTOTP enables two-factor authentication for a given user with a given TOTP secret and returns newly
+// generated recovery codes
+func (c *AuthClient) EnableTOTP(ctx context.Context, userID int, secret string) ([]string, error) {
+	err := c.orm.User.
+		UpdateOneID(userID).
+		SetTotpSecret(secret).
+		Exec(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GenerateRecoveryCodes(ctx, userID)
+}
+
+// DisableTOTP disables two-factor authentication for a given user and deletes their recovery codes
+func (c *AuthClient) DisableTOTP(ctx context.Context, userID int) error {
+	err := c.orm.User.
+		UpdateOneID(userID).
+		ClearTotpSecret().
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.orm.RecoveryCode.
+		Delete().
+		Where(recoverycode.HasUserWith(user.ID(userID))).
+		Exec(ctx)
+
+	return err
+}
+
+// GenerateRecoveryCodes generates new recovery codes for a given user, replacing any they already have.
+// As with password tokens, only a hash of each code is stored so the codes are only available when generated.
+func (c *AuthClient) GenerateRecoveryCodes(ctx context.Context, userID int) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	builders := make([]*ent.RecoveryCodeCreate, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b)[:recoveryCodeLength])
+		codes[i] = code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:]
+
+		builders[i] = c.orm.RecoveryCode.
+			Create().
+			SetHash(hashRecoveryCode(code)).
+			SetUserID(userID)
+	}
+
+	_, err := c.orm.RecoveryCode.
+		Delete().
+		Where(recoverycode.HasUserWith(user.ID(userID))).
+		Exec(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = c.orm.RecoveryCode.CreateBulk(builders...).Save(ctx); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// UseRecoveryCode validates a recovery code for a given user and deletes it so it cannot be used again
+func (c *AuthClient) UseRecoveryCode(ctx context.Context, userID int, code string) error {
+	count, err := c.orm.RecoveryCode.
+		Delete().
+		Where(
+			recoverycode.Hash(hashRecoveryCode(code)),
+			recoverycode.HasUserWith(user.ID(userID)),
+		).
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return InvalidTwoFactorCodeError{}
+	}
+
+	return nil
+}
+
+// totpStep returns the time step, of a given time, which a given code is valid for with a given TOTP secret.
+// Codes for the steps either side of the current one are accepted to allow for clocks which have drifted.
+func totpStep(secret, code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	current := now.Unix() / totpPeriod
+
+	for step := current - 1; step <= current+1; step++ {
+		valid, err := totp.ValidateCustom(code, secret, time.Unix(step*totpPeriod, 0), totp.ValidateOpts{
+			Period:    totpPeriod,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err == nil && valid {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// twoFactorAttemptsKey returns the cache key which stores the amount of incorrect codes entered for a user of a
+// given ID during the second step of logging in
+func twoFactorAttemptsKey(userID int) string {
+	return fmt.Sprintf("%s%d", twoFactorAttemptsKeyPrefix, userID)
+}
+
+// hashRecoveryCode returns the hash of a given recovery code, ignoring case, spaces and dashes.
+// Unlike passwords, the codes are random and long enough that a fast hash is sufficient, which allows a code to
+// be looked up by its hash rather than compared against each of the user's codes.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	h := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(h[:])
+}
@@ -211,6 +211,7 @@ func (c *cacheFlush) Tags(tags ...string) *cacheFlush {
 }
 
 // Execute flushes the data from the cache
+// If a group is set without a key, all data within the group will be flushed
 func (c *cacheFlush) Execute(ctx context.Context) error {
 	if len(c.tags) > 0 {
 		if err := c.client.cache.Invalidate(ctx, store.InvalidateOptions{
@@ -224,5 +225,30 @@ func (c *cacheFlush) Execute(ctx context.Context) error {
 		return c.client.cache.Delete(ctx, c.client.cacheKey(c.group, c.key))
 	}
 
+	if c.group != "" {
+		return c.flushGroup(ctx)
+	}
+
 	return nil
 }
+
+// flushGroup deletes all keys within the group
+func (c *cacheFlush) flushGroup(ctx context.Context) error {
+	iter := c.client.Client.
+		Scan(ctx, 0, c.client.cacheKey(c.group, "*"), 100).
+		Iterator()
+
+	keys := make([]string, 0)
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return c.client.Client.Del(ctx, keys...).Err()
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikestefanello/pagoda/ent"
+)
+
+const (
+	// CacheTagTags is the cache tag applied to pages which list all tags
+	CacheTagTags = "tags"
+
+	// CacheTagFeeds is the cache tag applied to all feeds of posts
+	CacheTagFeeds = "feeds"
+)
+
+// CacheTagTag returns the cache tag applied to the archive page of a given tag slug
+func CacheTagTag(slug string) string {
+	return fmt.Sprintf("tag:%s", slug)
+}
+
+// CacheTagCategory returns the cache tag applied to the archive page of a given category slug
+func CacheTagCategory(slug string) string {
+	return fmt.Sprintf("category:%s", slug)
+}
+
+// FlushPostArchives flushes the cached archive pages of the given tags and categories, as well as pages
+// which list all tags and the feeds, so they reflect changes made to a post
+func (c *CacheClient) FlushPostArchives(ctx context.Context, tags []*ent.Tag, categories []*ent.Category) error {
+	cacheTags := []string{CacheTagTags, CacheTagFeeds}
+	for _, t := range tags {
+		cacheTags = append(cacheTags, CacheTagTag(t.Slug))
+	}
+	for _, cat := range categories {
+		cacheTags = append(cacheTags, CacheTagCategory(cat.Slug))
+	}
+
+	return c.
+		Flush().
+		Tags(cacheTags...).
+		Execute(ctx)
+}
@@ -87,6 +87,25 @@ func TestCacheClient(t *testing.T) {
 	// The data should be gone
 	assertFlushed()
 
+	// Set within the group
+	err = c.Cache.
+		Set().
+		Group(group).
+		Key(key).
+		Data(data).
+		Save(context.Background())
+	require.NoError(t, err)
+
+	// Flush the group
+	err = c.Cache.
+		Flush().
+		Group(group).
+		Execute(context.Background())
+	require.NoError(t, err)
+
+	// The data should be gone
+	assertFlushed()
+
 	// Set with expiration
 	err = c.Cache.
 		Set().
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
@@ -16,11 +18,34 @@ import (
 
 	"github.com/mikestefanello/pagoda/config"
 	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/pkg/storage"
 
 	// Require by ent
 	_ "github.com/mikestefanello/pagoda/ent/runtime"
 )
 
+// postSearchSchema stores the statements which create the weighted full-text search column on posts, which is
+// generated by Postgres and therefore not part of the Ent schema, along with the index used to query it.
+// Generated columns cannot be altered, so a column built by an older version of the expression, which indexed the
+// body with its Markdown syntax, is dropped to be added again.
+var postSearchSchema = []string{
+	`DO $$ BEGIN
+		IF EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = 'posts' AND column_name = 'search_vector'
+				AND generation_expression NOT LIKE '%body_text%'
+		) THEN
+			ALTER TABLE posts DROP COLUMN search_vector;
+		END IF;
+	END $$`,
+	`ALTER TABLE posts ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(excerpt, '')), 'B') ||
+		setweight(to_tsvector('english', coalesce(body_text, body, '')), 'C')
+	) STORED`,
+	`CREATE INDEX IF NOT EXISTS posts_search_vector ON posts USING GIN (search_vector)`,
+}
+
 // Container contains all services used by the application and provides an easy way to handle dependency
 // injection including within tests
 type Container struct {
@@ -36,6 +61,9 @@ type Container struct {
 	// Cache contains the cache client
 	Cache *CacheClient
 
+	// Sessions stores the session store, which keeps session data in the cache
+	Sessions *SessionStore
+
 	// Database stores the connection to the database
 	Database *sql.DB
 
@@ -48,11 +76,20 @@ type Container struct {
 	// Auth stores an authentication client
 	Auth *AuthClient
 
+	// OIDC stores a client to sign in with OpenID Connect providers
+	OIDC *OIDCClient
+
 	// TemplateRenderer stores a service to easily render and cache templates
 	TemplateRenderer *TemplateRenderer
 
 	// Tasks stores the task client
 	Tasks *TaskClient
+
+	// Markdown stores a client to render Markdown in to sanitized HTML
+	Markdown *MarkdownClient
+
+	// Storage stores the storage that uploaded media is stored in
+	Storage storage.Storage
 }
 
 // NewContainer creates and initializes a new Container
@@ -62,12 +99,16 @@ func NewContainer() *Container {
 	c.initValidator()
 	c.initWeb()
 	c.initCache()
+	c.initSessions()
 	c.initDatabase()
 	c.initORM()
 	c.initAuth()
+	c.initOIDC()
 	c.initTemplateRenderer()
-	c.initMail()
 	c.initTasks()
+	c.initMail()
+	c.initMarkdown()
+	c.initStorage()
 	return c
 }
 
@@ -126,6 +167,11 @@ func (c *Container) initCache() {
 	}
 }
 
+// initSessions initializes the session store
+func (c *Container) initSessions() {
+	c.Sessions = NewSessionStore(c.Config, c.Cache)
+}
+
 // initDatabase initializes the database
 // If the environment is set to test, the test database will be used and will be dropped, recreated and migrated
 func (c *Container) initDatabase() {
@@ -174,11 +220,23 @@ func (c *Container) initORM() {
 	if err := c.ORM.Schema.Create(context.Background(), schema.WithAtlas(true)); err != nil {
 		panic(fmt.Sprintf("failed to create database schema: %v", err))
 	}
+
+	// Add what cannot be expressed in the Ent schema
+	for _, stmt := range postSearchSchema {
+		if _, err := c.Database.Exec(stmt); err != nil {
+			panic(fmt.Sprintf("failed to create search schema: %v", err))
+		}
+	}
 }
 
 // initAuth initializes the authentication client
 func (c *Container) initAuth() {
-	c.Auth = NewAuthClient(c.Config, c.ORM)
+	c.Auth = NewAuthClient(c.Config, c.ORM, c.Cache, c.Sessions)
+}
+
+// initOIDC initializes the OpenID Connect client
+func (c *Container) initOIDC() {
+	c.OIDC = NewOIDCClient(c.Config, c.ORM, c.Auth)
 }
 
 // initTemplateRenderer initializes the template renderer
@@ -189,7 +247,7 @@ func (c *Container) initTemplateRenderer() {
 // initMail initialize the mail client
 func (c *Container) initMail() {
 	var err error
-	c.Mail, err = NewMailClient(c.Config, c.TemplateRenderer)
+	c.Mail, err = NewMailClient(c.Config, c.TemplateRenderer, c.Tasks, c.Cache, c.Web.Logger)
 	if err != nil {
 		panic(fmt.Sprintf("failed to create mail client: %v", err))
 	}
@@ -199,3 +257,30 @@ func (c *Container) initMail() {
 func (c *Container) initTasks() {
 	c.Tasks = NewTaskClient(c.Config)
 }
+
+// initMarkdown initializes the Markdown client
+func (c *Container) initMarkdown() {
+	c.Markdown = NewMarkdownClient(c.Config, c.Cache)
+}
+
+// initStorage initializes the storage for uploaded media based on the configured driver
+// If the environment is set to test, files will be stored in a temporary directory on the local filesystem
+func (c *Container) initStorage() {
+	var err error
+
+	switch {
+	case c.Config.App.Environment == config.EnvTest:
+		dir := filepath.Join(os.TempDir(), "pagoda-test-uploads")
+		c.Storage, err = storage.NewLocalStorage(dir, c.Config.Storage.Local.URLPrefix)
+	case c.Config.Storage.Driver == config.StorageDriverLocal:
+		c.Storage, err = storage.NewLocalStorage(c.Config.Storage.Local.Directory, c.Config.Storage.Local.URLPrefix)
+	case c.Config.Storage.Driver == config.StorageDriverS3:
+		c.Storage, err = storage.NewS3Storage(c.Config.Storage.S3)
+	default:
+		err = fmt.Errorf("unknown driver: %s", c.Config.Storage.Driver)
+	}
+
+	if err != nil {
+		panic(fmt.Sprintf("failed to create storage: %v", err))
+	}
+}
@@ -17,4 +17,5 @@ func TestNewContainer(t *testing.T) {
 	assert.NotNil(t, c.Auth)
 	assert.NotNil(t, c.TemplateRenderer)
 	assert.NotNil(t, c.Tasks)
+	assert.NotNil(t, c.Storage)
 }
@@ -1,25 +1,45 @@
 package services
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/pkg/mailer"
 
 	"github.com/labstack/echo/v4"
 )
 
+// TypeSendMail is the type of the task which sends email queued via SendAsync()
+const TypeSendMail = "send_mail"
+
 type (
-	// MailClient provides a client for sending email
-	// This is purposely not completed because there are many different methods and services
-	// for sending email, many of which are very different. Choose what works best for you
-	// and populate the methods below
+	// MailClient provides a client for sending email via SMTP
 	MailClient struct {
 		// config stores application configuration
 		config *config.Config
 
 		// templates stores the template renderer
 		templates *TemplateRenderer
+
+		// tasks stores the task client used to queue email
+		tasks *TaskClient
+
+		// logger stores the logger used to report on mail sending
+		logger echo.Logger
+
+		// sender stores the sender which delivers email
+		sender mailer.Sender
+
+		// Catcher stores the mail catcher which email is sent to, rather than the mail server, in the local
+		// and development environments. This is nil in all other environments.
+		Catcher *MailCatcher
 	}
 
 	// mail represents an email to be sent
@@ -27,19 +47,57 @@ type (
 		client       *MailClient
 		from         string
 		to           string
+		cc           []string
+		bcc          []string
+		replyTo      string
 		subject      string
 		body         string
 		template     string
 		templateData any
+		locale       string
+		attachments  []mailer.Attachment
+		headers      map[string]string
+	}
+
+	// mailTemplateData is the data passed to email templates
+	mailTemplateData struct {
+		// AppName stores the name of the application
+		AppName string
+
+		// Subject stores the subject line of the email
+		Subject string
+
+		// Locale stores the locale the subject was localized to, if any
+		Locale string
+
+		// Data stores the data provided via TemplateData()
+		Data any
+	}
+
+	// SendMailPayload is the payload of the task which sends email queued via SendAsync()
+	SendMailPayload struct {
+		Message *mailer.Message `json:"message"`
 	}
 )
 
 // NewMailClient creates a new MailClient
-func NewMailClient(cfg *config.Config, templates *TemplateRenderer) (*MailClient, error) {
-	return &MailClient{
+// In the local and development environments, email is sent to a MailCatcher rather than the mail server.
+func NewMailClient(cfg *config.Config, templates *TemplateRenderer, tasks *TaskClient, cache *CacheClient, logger echo.Logger) (*MailClient, error) {
+	m := &MailClient{
 		config:    cfg,
 		templates: templates,
-	}, nil
+		tasks:     tasks,
+		logger:    logger,
+		sender:    mailer.NewSMTPSender(cfg.Mail),
+	}
+
+	switch cfg.App.Environment {
+	case config.EnvLocal, config.EnvDevelop:
+		m.Catcher = NewMailCatcher(cache)
+		m.sender = m.Catcher
+	}
+
+	return m, nil
 }
 
 // Compose creates a new email
@@ -51,44 +109,188 @@ func (m *MailClient) Compose() *mail {
 }
 
 // skipSend determines if mail sending should be skipped
+// Email is only sent in production, or to the mail catcher, if there is one.
 func (m *MailClient) skipSend() bool {
-	return m.config.App.Environment != config.EnvProduction
+	return m.config.App.Environment != config.EnvProduction && m.Catcher == nil
 }
 
-// send attempts to send the email
-func (m *MailClient) send(email *mail, ctx echo.Context) error {
+// compose builds the message of an email, rendering the template if one was provided
+func (m *MailClient) compose(email *mail) (*mailer.Message, error) {
 	switch {
 	case email.to == "":
-		return errors.New("email cannot be sent without a to address")
+		return nil, errors.New("email cannot be sent without a to address")
 	case email.body == "" && email.template == "":
-		return errors.New("email cannot be sent without a body or template")
+		return nil, errors.New("email cannot be sent without a body or template")
+	}
+
+	msg := &mailer.Message{
+		From:        email.from,
+		To:          []string{email.to},
+		CC:          email.cc,
+		BCC:         email.bcc,
+		ReplyTo:     email.replyTo,
+		Subject:     email.subject,
+		Text:        email.body,
+		Attachments: email.attachments,
+		Headers:     email.headers,
 	}
 
 	// Check if a template was supplied
 	if email.template != "" {
-		// Parse and execute template
-		buf, err := m.templates.
-			Parse().
-			Group("mail").
-			Key(email.template).
-			Base(email.template).
-			Files(fmt.Sprintf("emails/%s", email.template)).
-			Execute(email.templateData)
-
-		if err != nil {
-			return err
+		if err := m.render(email, msg); err != nil {
+			return nil, err
+		}
+	}
+
+	return msg, nil
+}
+
+// render renders the template of an email in to the message.
+// The HTML body is rendered within the mail layout and the CSS is inlined. The plain text body is rendered
+// from the text companion template, if one exists, otherwise it is derived from the HTML. If a subject was not
+// provided, the subject is rendered from the template, localized if possible.
+func (m *MailClient) render(email *mail, msg *mailer.Message) error {
+	tpl, err := m.templates.
+		Parse().
+		Group("mail").
+		Key(email.template).
+		Base("mail").
+		Files("emails/layouts/mail", fmt.Sprintf("emails/%s", email.template)).
+		Directories("emails/components").
+		Store()
+
+	if err != nil {
+		return err
+	}
+
+	data := mailTemplateData{
+		AppName: m.config.App.Name,
+		Data:    email.templateData,
+	}
+
+	if msg.Subject == "" {
+		name := "subject"
+		for _, locale := range parseLocales(email.locale) {
+			if tpl.Template.Lookup("subject:"+locale) != nil {
+				name = "subject:" + locale
+				data.Locale = locale
+				break
+			}
+		}
+
+		if tpl.Template.Lookup(name) != nil {
+			buf := new(bytes.Buffer)
+			if err = tpl.Template.ExecuteTemplate(buf, name, data); err != nil {
+				return err
+			}
+			msg.Subject = strings.TrimSpace(html.UnescapeString(buf.String()))
+		}
+	}
+	data.Subject = msg.Subject
+
+	buf, err := tpl.Execute(data)
+	if err != nil {
+		return err
+	}
+
+	if msg.HTML, err = mailer.InlineCSS(buf.String()); err != nil {
+		return err
+	}
+
+	// Render the text companion template, if one exists, otherwise derive the text from the HTML
+	text := fmt.Sprintf("emails/%s.txt", email.template)
+	if !m.templates.Exists(text) {
+		msg.Text = mailer.HTMLToText(msg.HTML)
+		return nil
+	}
+
+	buf, err = m.templates.
+		Parse().
+		Group("mail").
+		Key(email.template+".txt").
+		Base("mail.txt").
+		Files("emails/layouts/mail.txt", text).
+		Execute(data)
+
+	if err != nil {
+		return err
+	}
+
+	// The templates are parsed as HTML so the output must be unescaped since it is plain text
+	msg.Text = strings.TrimSpace(html.UnescapeString(buf.String()))
+	return nil
+}
+
+// parseLocales parses a locale, or a list of languages in the format of the Accept-Language header, and
+// returns the locales in order of preference, each followed by its base language
+func parseLocales(value string) []string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+
+	var languages []language
+	for _, part := range strings.Split(value, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
 		}
 
-		email.body = buf.String()
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = v
+			}
+		}
+		languages = append(languages, language{tag: tag, quality: quality})
 	}
 
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+
+	locales := make([]string, 0, len(languages))
+	for _, l := range languages {
+		locales = append(locales, l.tag)
+		if base, _, ok := strings.Cut(l.tag, "-"); ok {
+			locales = append(locales, base)
+		}
+	}
+	return locales
+}
+
+// Queue queues a composed message to be sent by the worker
+// Use Compose() and SendAsync() to build and queue email rather than calling this directly. This is used to
+// retry sending failed email.
+func (m *MailClient) Queue(msg *mailer.Message) error {
+	return m.tasks.
+		New(TypeSendMail).
+		Payload(SendMailPayload{Message: msg}).
+		MaxRetries(m.config.Mail.MaxRetries).
+		Save()
+}
+
+// Deliver sends a composed message
+// Use Compose() to build and send email rather than calling this directly. This is used by the task
+// processor which sends email queued via SendAsync().
+func (m *MailClient) Deliver(ctx context.Context, msg *mailer.Message) error {
 	// Check if mail sending should be skipped
 	if m.skipSend() {
-		ctx.Logger().Debugf("skipping email sent to: %s", email.to)
+		m.logger.Debugf("skipping email sent to: %s", strings.Join(msg.To, ", "))
+		return nil
+	}
+
+	if err := m.sender.Send(ctx, msg); err != nil {
+		return fmt.Errorf("unable to send email: %w", err)
+	}
+
+	if m.Catcher != nil {
+		m.logger.Infof("email caught for: %s", strings.Join(msg.To, ", "))
 		return nil
 	}
 
-	// TODO: Finish based on your mail sender of choice!
+	m.logger.Infof("email sent to: %s", strings.Join(msg.To, ", "))
 	return nil
 }
 
@@ -104,14 +306,32 @@ func (m *mail) To(to string) *mail {
 	return m
 }
 
+// CC sets the email addresses which receive a copy of this email
+func (m *mail) CC(addresses ...string) *mail {
+	m.cc = addresses
+	return m
+}
+
+// BCC sets the email addresses which receive a copy of this email without being visible to other recipients
+func (m *mail) BCC(addresses ...string) *mail {
+	m.bcc = addresses
+	return m
+}
+
+// ReplyTo sets the email address replies to this email should be sent to
+func (m *mail) ReplyTo(replyTo string) *mail {
+	m.replyTo = replyTo
+	return m
+}
+
 // Subject sets the subject line of the email
 func (m *mail) Subject(subject string) *mail {
 	m.subject = subject
 	return m
 }
 
-// Body sets the body of the email
-// This is not required and will be ignored if a template via Template()
+// Body sets the plain text body of the email
+// This is not required and will be ignored if a template is provided via Template()
 func (m *mail) Body(body string) *mail {
 	m.body = body
 	return m
@@ -119,9 +339,13 @@ func (m *mail) Body(body string) *mail {
 
 // Template sets the template to be used to produce the body of the email
 // The template name should only include the filename without the extension or directory.
-// The template must reside within the emails sub-directory.
+// The template must reside within the emails sub-directory and define the "content" which is rendered within
+// the mail layout. It can also define the "subject", which is used if Subject() is not called, along with
+// localized subjects, such as "subject:es", which are selected via Locale().
+// A plain text alternative is rendered from the companion template, such as welcome.txt for welcome, if one
+// exists, otherwise it is derived from the HTML.
 // The funcmap will be automatically added to the template.
-// Use TemplateData() to supply the data that will be passed in to the template.
+// Use TemplateData() to supply the data that will be passed in to the template as Data.
 func (m *mail) Template(template string) *mail {
 	m.template = template
 	return m
@@ -133,7 +357,50 @@ func (m *mail) TemplateData(data any) *mail {
 	return m
 }
 
+// Locale sets the locale used to select a localized subject from the template, such as es or pt-BR
+// The value of an Accept-Language header can also be provided, in which case the most preferred language
+// with a localized subject is used. If there are none, the default subject is used.
+func (m *mail) Locale(locale string) *mail {
+	m.locale = locale
+	return m
+}
+
+// Header sets an additional header on the email, such as List-Unsubscribe
+func (m *mail) Header(name, value string) *mail {
+	if m.headers == nil {
+		m.headers = make(map[string]string)
+	}
+	m.headers[name] = value
+	return m
+}
+
+// Attach attaches a file to the email
+func (m *mail) Attach(filename, contentType string, data []byte) *mail {
+	m.attachments = append(m.attachments, mailer.Attachment{
+		Filename:    filename,
+		ContentType: contentType,
+		Data:        data,
+	})
+	return m
+}
+
 // Send attempts to send the email
-func (m *mail) Send(ctx echo.Context) error {
-	return m.client.send(m, ctx)
+// This does not require a web request so emails can also be sent from within task processors
+func (m *mail) Send(ctx context.Context) error {
+	msg, err := m.client.compose(m)
+	if err != nil {
+		return err
+	}
+	return m.client.Deliver(ctx, msg)
+}
+
+// SendAsync queues the email to be sent by the worker so the caller does not have to wait on the mail server
+// The email is composed, including rendering the template, before it is queued. Failed attempts are retried
+// and email which cannot be delivered is stored as a FailedMail entity.
+func (m *mail) SendAsync() error {
+	msg, err := m.client.compose(m)
+	if err != nil {
+		return err
+	}
+	return m.client.Queue(msg)
 }
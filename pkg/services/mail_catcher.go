@@ -0,0 +1,120 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/mikestefanello/pagoda/pkg/mailer"
+)
+
+const (
+	// mailCatcherKey is the cache key of the list of caught email
+	mailCatcherKey = "mail:catcher"
+
+	// mailCatcherLimit is the maximum amount of email kept by the mail catcher, after which the oldest is removed
+	mailCatcherLimit = 100
+)
+
+// ErrCaughtMailNotFound is returned when a caught email does not exist
+var ErrCaughtMailNotFound = errors.New("caught mail not found")
+
+type (
+	// MailCatcher is a mailer.Sender which, rather than sending email, stores it in the cache so it can be
+	// viewed in the development mail inbox.
+	// The cache is used, rather than memory, so email sent by the worker is also caught.
+	MailCatcher struct {
+		// cache stores the cache client
+		cache *CacheClient
+	}
+
+	// CaughtMail is an email caught by the MailCatcher
+	CaughtMail struct {
+		ID      string          `json:"id"`
+		SentAt  time.Time       `json:"sent_at"`
+		Headers string          `json:"headers"`
+		Message *mailer.Message `json:"message"`
+	}
+)
+
+// NewMailCatcher creates a new MailCatcher
+func NewMailCatcher(cache *CacheClient) *MailCatcher {
+	return &MailCatcher{
+		cache: cache,
+	}
+}
+
+// Send stores the email rather than sending it
+func (c *MailCatcher) Send(ctx context.Context, msg *mailer.Message) error {
+	// Format the message to validate it and capture the headers as they would have been sent
+	data, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+	headers, _, _ := bytes.Cut(data, []byte("\r\n\r\n"))
+
+	id := make([]byte, 8)
+	if _, err = rand.Read(id); err != nil {
+		return err
+	}
+
+	caught, err := json.Marshal(CaughtMail{
+		ID:      hex.EncodeToString(id),
+		SentAt:  time.Now(),
+		Headers: string(bytes.ReplaceAll(headers, []byte("\r\n"), []byte("\n"))),
+		Message: msg,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.cache.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, mailCatcherKey, caught)
+		pipe.LTrim(ctx, mailCatcherKey, 0, mailCatcherLimit-1)
+		return nil
+	})
+	return err
+}
+
+// List returns all caught email, most recent first
+func (c *MailCatcher) List(ctx context.Context) ([]*CaughtMail, error) {
+	items, err := c.cache.Client.LRange(ctx, mailCatcherKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	mails := make([]*CaughtMail, 0, len(items))
+	for _, item := range items {
+		var m CaughtMail
+		if err = json.Unmarshal([]byte(item), &m); err != nil {
+			return nil, err
+		}
+		mails = append(mails, &m)
+	}
+	return mails, nil
+}
+
+// Get returns a caught email by ID
+func (c *MailCatcher) Get(ctx context.Context, id string) (*CaughtMail, error) {
+	mails, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range mails {
+		if m.ID == id {
+			return m, nil
+		}
+	}
+	return nil, ErrCaughtMailNotFound
+}
+
+// Clear deletes all caught email
+func (c *MailCatcher) Clear(ctx context.Context) error {
+	return c.cache.Client.Del(ctx, mailCatcherKey).Err()
+}
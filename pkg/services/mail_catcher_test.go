@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/pkg/mailer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMailCatcher(t *testing.T) {
+	ctx := context.Background()
+	catcher := NewMailCatcher(c.Cache)
+	require.NoError(t, catcher.Clear(ctx))
+
+	msg := &mailer.Message{
+		From:    "admin@localhost",
+		To:      []string{"to@localhost"},
+		Subject: "Caught",
+		Text:    "Hello",
+	}
+	require.NoError(t, catcher.Send(ctx, msg))
+	msg.Subject = "Caught again"
+	require.NoError(t, catcher.Send(ctx, msg))
+
+	// Invalid email is rejected as it would be by the mail server
+	assert.Error(t, catcher.Send(ctx, &mailer.Message{From: "admin@localhost"}))
+
+	mails, err := catcher.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, mails, 2)
+	assert.Equal(t, "Caught again", mails[0].Message.Subject)
+	assert.Equal(t, "Caught", mails[1].Message.Subject)
+	assert.Contains(t, mails[1].Headers, "Subject: Caught\n")
+	assert.NotEqual(t, mails[0].ID, mails[1].ID)
+
+	got, err := catcher.Get(ctx, mails[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, mails[1], got)
+
+	_, err = catcher.Get(ctx, "missing")
+	assert.ErrorIs(t, err, ErrCaughtMailNotFound)
+
+	require.NoError(t, catcher.Clear(ctx))
+	mails, err = catcher.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, mails)
+}
+
+func TestMailClient_Catcher(t *testing.T) {
+	cfg := *c.Config
+	cfg.App.Environment = config.EnvDevelop
+	client, err := NewMailClient(&cfg, c.TemplateRenderer, c.Tasks, c.Cache, c.Web.Logger)
+	require.NoError(t, err)
+	require.NotNil(t, client.Catcher)
+	require.NoError(t, client.Catcher.Clear(context.Background()))
+
+	err = client.
+		Compose().
+		To("to@localhost").
+		Subject("Test").
+		Body("Hello").
+		Send(context.Background())
+	require.NoError(t, err)
+
+	mails, err := client.Catcher.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, mails, 1)
+	assert.Equal(t, "Hello", mails[0].Message.Text)
+
+	// Email is not caught in other environments
+	client, err = NewMailClient(c.Config, c.TemplateRenderer, c.Tasks, c.Cache, c.Web.Logger)
+	require.NoError(t, err)
+	assert.Nil(t, client.Catcher)
+}
@@ -1,3 +1,107 @@
 package services
 
-// Fill this in once you implement your mail client
+import (
+	"context"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/pkg/mailer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSender records the messages it is asked to send
+type testSender struct {
+	messages []*mailer.Message
+}
+
+func (s *testSender) Send(_ context.Context, msg *mailer.Message) error {
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func TestMailClient_Send(t *testing.T) {
+	cfg := *c.Config
+	cfg.App.Environment = config.EnvProduction
+	client, err := NewMailClient(&cfg, c.TemplateRenderer, c.Tasks, c.Cache, c.Web.Logger)
+	require.NoError(t, err)
+	sender := &testSender{}
+	client.sender = sender
+
+	err = client.
+		Compose().
+		To("to@localhost").
+		CC("cc1@localhost", "cc2@localhost").
+		BCC("bcc@localhost").
+		ReplyTo("reply@localhost").
+		Subject("Test").
+		Template("test").
+		Attach("a.txt", "text/plain", []byte("abc")).
+		Send(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, sender.messages, 1)
+	msg := sender.messages[0]
+	assert.Equal(t, cfg.Mail.FromAddress, msg.From)
+	assert.Equal(t, []string{"to@localhost"}, msg.To)
+	assert.Equal(t, []string{"cc1@localhost", "cc2@localhost"}, msg.CC)
+	assert.Equal(t, []string{"bcc@localhost"}, msg.BCC)
+	assert.Equal(t, "reply@localhost", msg.ReplyTo)
+	assert.Equal(t, "Test", msg.Subject)
+	assert.Contains(t, msg.HTML, "Test email template.")
+	assert.Contains(t, msg.HTML, `style="`)
+	assert.NotContains(t, msg.HTML, "a.button")
+	assert.Equal(t, "Test email template. See the documentation for more information: https://github.com/mikestefanello/pagoda\n\n--\n"+cfg.App.Name, msg.Text)
+	require.Len(t, msg.Attachments, 1)
+	assert.Equal(t, "a.txt", msg.Attachments[0].Filename)
+
+	// A body is sent as plain text only
+	err = client.
+		Compose().
+		To("to@localhost").
+		Subject("Test").
+		Body("Hello").
+		Send(context.Background())
+	require.NoError(t, err)
+	require.Len(t, sender.messages, 2)
+	assert.Equal(t, "Hello", sender.messages[1].Text)
+	assert.Empty(t, sender.messages[1].HTML)
+
+	// The subject is rendered from the template, localized if possible
+	err = client.
+		Compose().
+		To("to@localhost").
+		Template("test").
+		Send(context.Background())
+	require.NoError(t, err)
+	require.Len(t, sender.messages, 3)
+	assert.Equal(t, "Test email", sender.messages[2].Subject)
+
+	err = client.
+		Compose().
+		To("to@localhost").
+		Template("test").
+		Locale("fr-CA, es-MX;q=0.9, en;q=0.8").
+		Send(context.Background())
+	require.NoError(t, err)
+	require.Len(t, sender.messages, 4)
+	assert.Equal(t, "Correo electrónico de prueba", sender.messages[3].Subject)
+
+	// Sending is skipped outside of production
+	client.config = c.Config
+	err = client.Compose().To("to@localhost").Body("Hello").Send(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, sender.messages, 4)
+
+	// A recipient and body are required
+	assert.Error(t, client.Compose().Body("Hello").Send(context.Background()))
+	assert.Error(t, client.Compose().To("to@localhost").Send(context.Background()))
+}
+
+func TestParseLocales(t *testing.T) {
+	assert.Equal(t, []string{"es"}, parseLocales("es"))
+	assert.Equal(t, []string{"pt-br", "pt"}, parseLocales("pt-BR"))
+	assert.Equal(t, []string{"es-mx", "es", "fr", "en"}, parseLocales("en;q=0.5, es-MX, *;q=0.1, fr;q=0.8"))
+	assert.Empty(t, parseLocales(""))
+}
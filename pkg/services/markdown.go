@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/pkg/markdown"
+)
+
+// MarkdownCacheGroup stores the cache group for rendered Markdown
+const MarkdownCacheGroup = "markdown"
+
+// MarkdownClient renders Markdown in to sanitized HTML and caches the output of posts
+type MarkdownClient struct {
+	// config stores application configuration
+	config *config.Config
+
+	// cache stores the cache client
+	cache *CacheClient
+
+	// renderer stores the Markdown renderer
+	renderer *markdown.Renderer
+}
+
+// NewMarkdownClient creates a new MarkdownClient
+func NewMarkdownClient(cfg *config.Config, cache *CacheClient) *MarkdownClient {
+	return &MarkdownClient{
+		config:   cfg,
+		cache:    cache,
+		renderer: markdown.NewRenderer(),
+	}
+}
+
+// Render renders Markdown source without caching
+func (m *MarkdownClient) Render(source string) (*markdown.Document, error) {
+	return m.renderer.Render(source)
+}
+
+// RenderPost renders the body of a given post.
+// The output is cached per revision of the post, so it only needs to be rendered once after each save.
+// Cache errors are not returned since the post can always be rendered directly.
+func (m *MarkdownClient) RenderPost(ctx context.Context, p *ent.Post) (*markdown.Document, error) {
+	key := markdownCacheKey(p)
+
+	res, err := m.cache.
+		Get().
+		Group(MarkdownCacheGroup).
+		Key(key).
+		Type(new(markdown.Document)).
+		Fetch(ctx)
+
+	if err == nil {
+		if doc, ok := res.(*markdown.Document); ok {
+			return doc, nil
+		}
+	}
+
+	doc, err := m.renderer.Render(p.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Failing to cache the output is not fatal
+	_ = m.cache.
+		Set().
+		Group(MarkdownCacheGroup).
+		Key(key).
+		Tags(fmt.Sprintf("post:%d", p.ID)).
+		Expiration(m.config.Cache.Expiration.Markdown).
+		Data(doc).
+		Save(ctx)
+
+	return doc, nil
+}
+
+// markdownCacheKey gets the cache key for the rendered body of a given post revision
+func markdownCacheKey(p *ent.Post) string {
+	return fmt.Sprintf("post:%d:%d", p.ID, p.UpdatedAt.UnixNano())
+}
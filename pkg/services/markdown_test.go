@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/pkg/markdown"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownClient_RenderPost(t *testing.T) {
+	p, err := tests.CreatePost(c.ORM, usr)
+	require.NoError(t, err)
+
+	doc, err := c.Markdown.RenderPost(context.Background(), p)
+	require.NoError(t, err)
+	assert.Contains(t, string(doc.HTML), p.Body)
+
+	// The rendered document should now be cached for this revision
+	res, err := c.Cache.
+		Get().
+		Group(MarkdownCacheGroup).
+		Key(markdownCacheKey(p)).
+		Type(new(markdown.Document)).
+		Fetch(context.Background())
+	require.NoError(t, err)
+	cached, ok := res.(*markdown.Document)
+	require.True(t, ok)
+	assert.Equal(t, doc.HTML, cached.HTML)
+}
@@ -0,0 +1,395 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/identity"
+	"github.com/mikestefanello/pagoda/ent/user"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// oidcSessionName stores the name of the session which contains the state of signing in with a provider
+	oidcSessionName = "oidc"
+
+	// oidcSessionKeyLogin stores the key used to store the state of signing in with a provider in the session
+	oidcSessionKeyLogin = "login"
+
+	// oidcLoginExpiration is how long a user has to sign in with a provider before they must start again
+	oidcLoginExpiration = 10 * time.Minute
+
+	// oidcRandomLength is the length of the random state, nonce and passwords generated when signing in
+	oidcRandomLength = 32
+)
+
+// OIDCProviderNotFoundError is an error returned when an OpenID Connect provider is not configured
+type OIDCProviderNotFoundError struct{}
+
+// Error implements the error interface.
+func (e OIDCProviderNotFoundError) Error() string {
+	return "oidc provider not found"
+}
+
+// InvalidOIDCLoginError is an error returned when the response of a provider cannot be verified, or the user
+// did not allow signing in
+type InvalidOIDCLoginError struct{}
+
+// Error implements the error interface.
+func (e InvalidOIDCLoginError) Error() string {
+	return "invalid oidc login"
+}
+
+// IdentityNotLinkedError is an error returned when an identity cannot be automatically linked to a user, because
+// either the provider has not verified the email address or the user with that email address has not
+type IdentityNotLinkedError struct{}
+
+// Error implements the error interface.
+func (e IdentityNotLinkedError) Error() string {
+	return "identity cannot be linked automatically"
+}
+
+// IdentityLinkedError is an error returned when an identity is already linked to a user, or the user has already
+// linked an identity from the same provider
+type IdentityLinkedError struct{}
+
+// Error implements the error interface.
+func (e IdentityLinkedError) Error() string {
+	return "identity already linked"
+}
+
+type (
+	// OIDCClient is the client that handles signing in with OpenID Connect providers
+	OIDCClient struct {
+		config    *config.Config
+		orm       *ent.Client
+		auth      *AuthClient
+		mu        sync.Mutex
+		providers map[string]*oidc.Provider
+	}
+
+	// OIDCClaims are the claims about a user within the ID token issued by a provider
+	OIDCClaims struct {
+		Subject       string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+
+	// oidcLogin is the state of signing in with a provider, which is stored in the session
+	oidcLogin struct {
+		Provider  string
+		State     string
+		Nonce     string
+		Verifier  string
+		Link      bool
+		StartedAt time.Time
+	}
+)
+
+// NewOIDCClient creates a new OpenID Connect client
+func NewOIDCClient(cfg *config.Config, orm *ent.Client, auth *AuthClient) *OIDCClient {
+	return &OIDCClient{
+		config:    cfg,
+		orm:       orm,
+		auth:      auth,
+		providers: make(map[string]*oidc.Provider),
+	}
+}
+
+// Providers returns the configured providers
+func (c *OIDCClient) Providers() []config.OIDCProviderConfig {
+	return c.config.OIDC.Providers
+}
+
+// Provider returns the configuration of the provider with a given name
+func (c *OIDCClient) Provider(name string) (config.OIDCProviderConfig, error) {
+	for _, p := range c.config.OIDC.Providers {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return config.OIDCProviderConfig{}, OIDCProviderNotFoundError{}
+}
+
+// AuthCodeURL starts signing in with the provider of a given name and returns the URL to redirect the user to.
+// The provider redirects back to a given URL, where Exchange must be called. If link is true, the account with
+// the provider is to be linked to the authenticated user rather than logging in.
+func (c *OIDCClient) AuthCodeURL(ctx echo.Context, name, redirectURL string, link bool) (string, error) {
+	cfg, err := c.Provider(name)
+	if err != nil {
+		return "", err
+	}
+
+	oauth, _, err := c.client(ctx.Request().Context(), cfg, redirectURL)
+	if err != nil {
+		return "", err
+	}
+
+	login := oidcLogin{
+		Provider:  name,
+		Verifier:  oauth2.GenerateVerifier(),
+		Link:      link,
+		StartedAt: time.Now(),
+	}
+
+	if login.State, err = c.auth.RandomToken(oidcRandomLength); err != nil {
+		return "", err
+	}
+
+	if login.Nonce, err = c.auth.RandomToken(oidcRandomLength); err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(login)
+	if err != nil {
+		return "", err
+	}
+
+	sess, err := session.Get(oidcSessionName, ctx)
+	if err != nil {
+		return "", err
+	}
+	sess.Values[oidcSessionKeyLogin] = string(b)
+	if err = sess.Save(ctx.Request(), ctx.Response()); err != nil {
+		return "", err
+	}
+
+	return oauth.AuthCodeURL(login.State, oidc.Nonce(login.Nonce), oauth2.S256ChallengeOption(login.Verifier)), nil
+}
+
+// Exchange completes signing in with the provider of a given name, once the provider has redirected back to a
+// given URL. The state, PKCE verifier and nonce stored in the session by AuthCodeURL are used to verify the response
+// and are removed so they cannot be used again. The claims of the ID token are returned, along with whether the
+// account is to be linked to the authenticated user.
+func (c *OIDCClient) Exchange(ctx echo.Context, name, redirectURL string) (*OIDCClaims, bool, error) {
+	cfg, err := c.Provider(name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	sess, err := session.Get(oidcSessionName, ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	raw, ok := sess.Values[oidcSessionKeyLogin].(string)
+	if !ok {
+		return nil, false, InvalidOIDCLoginError{}
+	}
+
+	delete(sess.Values, oidcSessionKeyLogin)
+	if err = sess.Save(ctx.Request(), ctx.Response()); err != nil {
+		return nil, false, err
+	}
+
+	var login oidcLogin
+	if err = json.Unmarshal([]byte(raw), &login); err != nil {
+		return nil, false, InvalidOIDCLoginError{}
+	}
+
+	switch {
+	case login.Provider != name,
+		time.Since(login.StartedAt) > oidcLoginExpiration,
+		subtle.ConstantTimeCompare([]byte(login.State), []byte(ctx.QueryParam("state"))) != 1,
+		ctx.QueryParam("error") != "":
+		return nil, false, InvalidOIDCLoginError{}
+	}
+
+	oauth, verifier, err := c.client(ctx.Request().Context(), cfg, redirectURL)
+	if err != nil {
+		return nil, false, err
+	}
+
+	token, err := oauth.Exchange(ctx.Request().Context(), ctx.QueryParam("code"), oauth2.VerifierOption(login.Verifier))
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) {
+			return nil, false, InvalidOIDCLoginError{}
+		}
+		return nil, false, err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, false, InvalidOIDCLoginError{}
+	}
+
+	idToken, err := verifier.Verify(ctx.Request().Context(), rawIDToken)
+	if err != nil {
+		return nil, false, InvalidOIDCLoginError{}
+	}
+
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(login.Nonce)) != 1 {
+		return nil, false, InvalidOIDCLoginError{}
+	}
+
+	var claims OIDCClaims
+	if err = idToken.Claims(&claims); err != nil {
+		return nil, false, InvalidOIDCLoginError{}
+	}
+
+	return &claims, login.Link, nil
+}
+
+// GetUser returns the user to log in as with an account from a given provider. This is the user the account is
+// linked to, otherwise the account is linked to the user with the same email address, which must be verified by both
+// the provider and the user, otherwise a new user is created.
+func (c *OIDCClient) GetUser(ctx context.Context, provider string, claims *OIDCClaims) (*ent.User, error) {
+	u, err := c.orm.User.
+		Query().
+		Where(user.HasIdentitiesWith(
+			identity.Provider(provider),
+			identity.Subject(claims.Subject),
+		)).
+		Only(ctx)
+
+	switch err.(type) {
+	case nil:
+		return u, nil
+	case *ent.NotFoundError:
+	default:
+		return nil, err
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, IdentityNotLinkedError{}
+	}
+
+	tx, err := c.orm.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err = tx.User.
+		Query().
+		Where(user.Email(strings.ToLower(claims.Email))).
+		Only(ctx)
+
+	switch err.(type) {
+	case nil:
+		// Otherwise whoever registered with the email address may not own it, and would be able to log in
+		if !u.Verified {
+			_ = tx.Rollback()
+			return nil, IdentityNotLinkedError{}
+		}
+	case *ent.NotFoundError:
+		if u, err = c.createUser(ctx, tx, claims); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	default:
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err = c.linkIdentity(ctx, tx.Client(), u.ID, provider, claims); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	return u, tx.Commit()
+}
+
+// LinkIdentity links the account from a given provider to a user of a given ID
+func (c *OIDCClient) LinkIdentity(ctx context.Context, userID int, provider string, claims *OIDCClaims) error {
+	return c.linkIdentity(ctx, c.orm, userID, provider, claims)
+}
+
+func (c *OIDCClient) linkIdentity(ctx context.Context, orm *ent.Client, userID int, provider string, claims *OIDCClaims) error {
+	err := orm.Identity.
+		Create().
+		SetProvider(provider).
+		SetSubject(claims.Subject).
+		SetEmail(claims.Email).
+		SetUserID(userID).
+		Exec(ctx)
+
+	if ent.IsConstraintError(err) {
+		return IdentityLinkedError{}
+	}
+
+	return err
+}
+
+// createUser creates a verified user from the claims of a provider. A random password is set, which the user can
+// reset if they wish to log in with a password. Until then, the provider is the only way the user can log in.
+func (c *OIDCClient) createUser(ctx context.Context, tx *ent.Tx, claims *OIDCClaims) (*ent.User, error) {
+	password, err := c.auth.RandomToken(oidcRandomLength)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := c.auth.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	name := claims.Name
+	if name == "" {
+		name, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	return tx.User.
+		Create().
+		SetName(name).
+		SetEmail(claims.Email).
+		SetPassword(hash).
+		SetPasswordSet(false).
+		SetVerified(true).
+		Save(ctx)
+}
+
+// client returns the OAuth2 client and ID token verifier for a given provider, which redirects back to a given URL.
+// The provider's configuration is discovered from its issuer on first use. Discovery happens outside the lock so a
+// slow issuer does not hold up signing in with other providers; if two requests discover the same issuer at once,
+// the first to finish is kept.
+func (c *OIDCClient) client(ctx context.Context, cfg config.OIDCProviderConfig, redirectURL string) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	c.mu.Lock()
+	p, ok := c.providers[cfg.Issuer]
+	c.mu.Unlock()
+
+	if !ok {
+		discovered, err := oidc.NewProvider(ctx, cfg.Issuer)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		c.mu.Lock()
+		if p, ok = c.providers[cfg.Issuer]; !ok {
+			p = discovered
+			c.providers[cfg.Issuer] = p
+		}
+		c.mu.Unlock()
+	}
+
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"email", "profile"}
+	}
+	if !slices.Contains(scopes, oidc.ScopeOpenID) {
+		scopes = append([]string{oidc.ScopeOpenID}, scopes...)
+	}
+
+	oauth := &oauth2.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		Endpoint:     p.Endpoint(),
+		RedirectURL:  redirectURL,
+		Scopes:       scopes,
+	}
+
+	return oauth, p.Verifier(&oidc.Config{ClientID: cfg.ClientID}), nil
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const oidcTestRedirectURL = "http://localhost/user/oidc/test/callback"
+
+func TestOIDCClient_Exchange(t *testing.T) {
+	issuer := newOIDCIssuer(t)
+
+	_, _, err := c.OIDC.Exchange(ctx, "missing", oidcTestRedirectURL)
+	assert.Equal(t, OIDCProviderNotFoundError{}, err)
+
+	// Sign in with the provider
+	claims, link, rec := oidcSignIn(t, issuer, false)
+	assert.False(t, link)
+	assert.Equal(t, issuer.Subject, claims.Subject)
+	assert.Equal(t, issuer.Email, claims.Email)
+	assert.True(t, claims.EmailVerified)
+	assert.Equal(t, issuer.Name, claims.Name)
+
+	// The response cannot be used again
+	callback, _ := oidcCallbackContext(rec, url.Values{})
+	_, _, err = c.OIDC.Exchange(callback, "test", oidcTestRedirectURL)
+	assert.Equal(t, InvalidOIDCLoginError{}, err)
+
+	// Linking is remembered
+	_, link, _ = oidcSignIn(t, issuer, true)
+	assert.True(t, link)
+
+	// The state must match
+	err = oidcExchange(t, issuer, func(q url.Values) {
+		q.Set("state", "wrong")
+	})
+	assert.Equal(t, InvalidOIDCLoginError{}, err)
+
+	// The user did not allow signing in
+	err = oidcExchange(t, issuer, func(q url.Values) {
+		q.Set("error", "access_denied")
+	})
+	assert.Equal(t, InvalidOIDCLoginError{}, err)
+
+	// The code must be issued by the provider
+	err = oidcExchange(t, issuer, func(q url.Values) {
+		q.Set("code", "wrong")
+	})
+	assert.Equal(t, InvalidOIDCLoginError{}, err)
+}
+
+func TestOIDCClient_AuthCodeURL(t *testing.T) {
+	newOIDCIssuer(t)
+
+	scope := func() string {
+		start, _ := tests.NewContext(c.Web, "/")
+		tests.InitSession(start)
+		authURL, err := c.OIDC.AuthCodeURL(start, "test", oidcTestRedirectURL, false)
+		require.NoError(t, err)
+		u, err := url.Parse(authURL)
+		require.NoError(t, err)
+		return u.Query().Get("scope")
+	}
+
+	// The openid scope is always requested
+	assert.Equal(t, "openid email profile", scope())
+
+	// But only once, if it is configured
+	c.Config.OIDC.Providers[0].Scopes = []string{"email", "openid"}
+	assert.Equal(t, "email openid", scope())
+}
+
+func TestOIDCClient_GetUser(t *testing.T) {
+	ctx := context.Background()
+	claims := &OIDCClaims{
+		Subject:       "new",
+		Email:         "oidc-new@localhost.localhost",
+		EmailVerified: true,
+		Name:          "New User",
+	}
+
+	// A new user is created
+	u, err := c.OIDC.GetUser(ctx, "test", claims)
+	require.NoError(t, err)
+	assert.Equal(t, claims.Email, u.Email)
+	assert.Equal(t, claims.Name, u.Name)
+	assert.True(t, u.Verified)
+
+	// The same user is returned by the linked identity, even if the email address changes
+	claims.Email = "oidc-changed@localhost.localhost"
+	got, err := c.OIDC.GetUser(ctx, "test", claims)
+	require.NoError(t, err)
+	assert.Equal(t, u.ID, got.ID)
+
+	// Another provider cannot be linked automatically if the user has not verified their email address
+	existing, err := tests.CreateUser(c.ORM)
+	require.NoError(t, err)
+	claims = &OIDCClaims{Subject: "existing", Email: existing.Email, EmailVerified: true}
+	_, err = c.OIDC.GetUser(ctx, "test", claims)
+	assert.Equal(t, IdentityNotLinkedError{}, err)
+
+	// Or if the provider has not verified it
+	existing, err = existing.Update().SetVerified(true).Save(ctx)
+	require.NoError(t, err)
+	claims.EmailVerified = false
+	_, err = c.OIDC.GetUser(ctx, "test", claims)
+	assert.Equal(t, IdentityNotLinkedError{}, err)
+
+	// Otherwise the identity is linked to the user with the same email address
+	claims.EmailVerified = true
+	got, err = c.OIDC.GetUser(ctx, "test", claims)
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, got.ID)
+
+	// A user can only link one account per provider
+	err = c.OIDC.LinkIdentity(ctx, existing.ID, "test", &OIDCClaims{Subject: "another"})
+	assert.Equal(t, IdentityLinkedError{}, err)
+
+	// And an account can only be linked to one user
+	err = c.OIDC.LinkIdentity(ctx, usr.ID, "test", claims)
+	assert.Equal(t, IdentityLinkedError{}, err)
+
+	err = c.OIDC.LinkIdentity(ctx, usr.ID, "other", claims)
+	assert.NoError(t, err)
+}
+
+// newOIDCIssuer starts a mock issuer and configures it as the provider named "test"
+func newOIDCIssuer(t *testing.T) *tests.OIDCIssuer {
+	issuer, err := tests.NewOIDCIssuer()
+	require.NoError(t, err)
+
+	providers := c.Config.OIDC.Providers
+	c.Config.OIDC.Providers = []config.OIDCProviderConfig{{
+		Name:         "test",
+		Label:        "Test",
+		Issuer:       issuer.URL,
+		ClientID:     issuer.ClientID,
+		ClientSecret: issuer.ClientSecret,
+	}}
+
+	t.Cleanup(func() {
+		issuer.Close()
+		c.Config.OIDC.Providers = providers
+	})
+
+	return issuer
+}
+
+// oidcSignIn signs in with the mock issuer and returns the response of the exchange
+func oidcSignIn(t *testing.T, issuer *tests.OIDCIssuer, link bool) (*OIDCClaims, bool, *httptest.ResponseRecorder) {
+	start, rec := tests.NewContext(c.Web, "/")
+	tests.InitSession(start)
+
+	authURL, err := c.OIDC.AuthCodeURL(start, "test", oidcTestRedirectURL, link)
+	require.NoError(t, err)
+
+	q, err := issuer.Authorize(authURL)
+	require.NoError(t, err)
+
+	callback, rec := oidcCallbackContext(rec, q)
+	claims, link, err := c.OIDC.Exchange(callback, "test", oidcTestRedirectURL)
+	require.NoError(t, err)
+
+	return claims, link, rec
+}
+
+// oidcExchange signs in with the mock issuer after modifying the parameters it redirects back with, and returns the
+// error of the exchange
+func oidcExchange(t *testing.T, issuer *tests.OIDCIssuer, modify func(url.Values)) error {
+	start, rec := tests.NewContext(c.Web, "/")
+	tests.InitSession(start)
+
+	authURL, err := c.OIDC.AuthCodeURL(start, "test", oidcTestRedirectURL, false)
+	require.NoError(t, err)
+
+	q, err := issuer.Authorize(authURL)
+	require.NoError(t, err)
+	modify(q)
+
+	callback, _ := oidcCallbackContext(rec, q)
+	_, _, err = c.OIDC.Exchange(callback, "test", oidcTestRedirectURL)
+	return err
+}
+
+// oidcCallbackContext creates the context of the provider redirecting back with given parameters, in the session
+// of a previous response
+func oidcCallbackContext(prev *httptest.ResponseRecorder, q url.Values) (echo.Context, *httptest.ResponseRecorder) {
+	callback, rec := tests.NewContext(c.Web, "/?"+q.Encode())
+	for _, cookie := range prev.Result().Cookies() {
+		callback.Request().AddCookie(cookie)
+	}
+	tests.InitSession(callback)
+	return callback, rec
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gorilla/securecookie"
+	"github.com/gorilla/sessions"
+	"github.com/mikestefanello/pagoda/config"
+)
+
+const (
+	// sessionKeyPrefix is the prefix of the cache keys which store session data
+	sessionKeyPrefix = "session:"
+
+	// sessionIDLength is the amount of random bytes within a session ID
+	sessionIDLength = 32
+)
+
+// SessionStore is a sessions.Store which keeps session data in the cache and only a signed session ID in the
+// cookie. Unlike cookie sessions, these can be revoked on the server by deleting them.
+type SessionStore struct {
+	// cache stores the cache client
+	cache *CacheClient
+
+	// codecs stores the codecs used to sign the session ID within the cookie
+	codecs []securecookie.Codec
+
+	// options stores the default options of new sessions
+	options *sessions.Options
+}
+
+// NewSessionStore creates a new SessionStore
+func NewSessionStore(cfg *config.Config, cache *CacheClient) *SessionStore {
+	s := &SessionStore{
+		cache:  cache,
+		codecs: securecookie.CodecsFromPairs([]byte(cfg.App.EncryptionKey)),
+		options: &sessions.Options{
+			Path:     "/",
+			MaxAge:   int(cfg.App.Session.Expiration.Seconds()),
+			Secure:   cfg.HTTP.TLS.Enabled,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		},
+	}
+
+	// Signed session IDs should not outlive the session
+	for _, codec := range s.codecs {
+		if sc, ok := codec.(*securecookie.SecureCookie); ok {
+			sc.MaxAge(s.options.MaxAge)
+		}
+	}
+
+	return s
+}
+
+// Get returns a session for the given name after adding it to the registry
+func (s *SessionStore) Get(r *http.Request, name string) (*sessions.Session, error) {
+	return sessions.GetRegistry(r).Get(s, name)
+}
+
+// New returns a session for the given name without adding it to the registry.
+// If the cookie contains the ID of a session which has expired or been revoked, a new session is returned.
+func (s *SessionStore) New(r *http.Request, name string) (*sessions.Session, error) {
+	sess := sessions.NewSession(s, name)
+	opts := *s.options
+	sess.Options = &opts
+	sess.IsNew = true
+
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return sess, nil
+	}
+
+	if err = securecookie.DecodeMulti(name, cookie.Value, &sess.ID, s.codecs...); err != nil {
+		sess.ID = ""
+		return sess, err
+	}
+
+	data, err := s.cache.Client.Get(r.Context(), sessionKeyPrefix+sess.ID).Bytes()
+	switch {
+	case err == nil:
+	case errors.Is(err, redis.Nil):
+		sess.ID = ""
+		return sess, nil
+	default:
+		sess.ID = ""
+		return sess, err
+	}
+
+	if err = (securecookie.GobEncoder{}).Deserialize(data, &sess.Values); err != nil {
+		return sess, err
+	}
+	sess.IsNew = false
+
+	return sess, nil
+}
+
+// Save stores the session data in the cache and writes the signed session ID to the cookie.
+// A negative MaxAge deletes the session.
+func (s *SessionStore) Save(r *http.Request, w http.ResponseWriter, sess *sessions.Session) error {
+	if sess.Options.MaxAge < 0 {
+		if sess.ID != "" {
+			if err := s.Delete(r.Context(), sess.ID); err != nil {
+				return err
+			}
+		}
+		http.SetCookie(w, sessions.NewCookie(sess.Name(), "", sess.Options))
+		return nil
+	}
+
+	if sess.ID == "" {
+		id := make([]byte, sessionIDLength)
+		if _, err := rand.Read(id); err != nil {
+			return err
+		}
+		sess.ID = hex.EncodeToString(id)
+	}
+
+	data, err := securecookie.GobEncoder{}.Serialize(sess.Values)
+	if err != nil {
+		return err
+	}
+
+	// Browser sessions, without a MaxAge, still expire on the server
+	maxAge := sess.Options.MaxAge
+	if maxAge == 0 {
+		maxAge = s.options.MaxAge
+	}
+
+	err = s.cache.Client.
+		Set(r.Context(), sessionKeyPrefix+sess.ID, data, time.Duration(maxAge)*time.Second).
+		Err()
+	if err != nil {
+		return err
+	}
+
+	encoded, err := securecookie.EncodeMulti(sess.Name(), sess.ID, s.codecs...)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, sessions.NewCookie(sess.Name(), encoded, sess.Options))
+
+	return nil
+}
+
+// Exists determines if a session of a given ID exists
+func (s *SessionStore) Exists(ctx context.Context, id string) (bool, error) {
+	n, err := s.cache.Client.Exists(ctx, sessionKeyPrefix+id).Result()
+	return n > 0, err
+}
+
+// Delete deletes a session of a given ID, which revokes it
+func (s *SessionStore) Delete(ctx context.Context, id string) error {
+	return s.cache.Client.Del(ctx, sessionKeyPrefix+id).Err()
+}
+
+// Expiration returns how long sessions last for
+func (s *SessionStore) Expiration() time.Duration {
+	return time.Duration(s.options.MaxAge) * time.Second
+}
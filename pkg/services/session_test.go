@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionStore(t *testing.T) {
+	// Save a new session
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	sess, err := c.Sessions.New(req, "test")
+	require.NoError(t, err)
+	assert.True(t, sess.IsNew)
+	sess.Values["a"] = 1
+	require.NoError(t, c.Sessions.Save(req, rec, sess))
+	require.NotEmpty(t, sess.ID)
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.NotContains(t, cookies[0].Value, sess.ID)
+	assert.True(t, cookies[0].HttpOnly)
+
+	load := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(cookies[0])
+		return req
+	}
+
+	// The session is loaded from the cache using the ID in the cookie
+	req = load()
+	loaded, err := c.Sessions.New(req, "test")
+	require.NoError(t, err)
+	assert.False(t, loaded.IsNew)
+	assert.Equal(t, sess.ID, loaded.ID)
+	assert.Equal(t, 1, loaded.Values["a"])
+
+	// A deleted session cannot be loaded
+	exists, err := c.Sessions.Exists(context.Background(), sess.ID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	require.NoError(t, c.Sessions.Delete(context.Background(), sess.ID))
+
+	req = load()
+	loaded, err = c.Sessions.New(req, "test")
+	require.NoError(t, err)
+	assert.True(t, loaded.IsNew)
+	assert.Empty(t, loaded.ID)
+	assert.Empty(t, loaded.Values)
+
+	// A tampered cookie is rejected
+	cookies[0].Value += "x"
+	req = load()
+	_, err = c.Sessions.New(req, "test")
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,6 +10,9 @@ import (
 	"github.com/mikestefanello/pagoda/config"
 )
 
+// ErrTaskActive is returned when deleting a task which is being processed, since it cannot be deleted
+var ErrTaskActive = errors.New("task is active")
+
 type (
 	// TaskClient is that client that allows you to queue or schedule task execution
 	TaskClient struct {
@@ -17,6 +21,9 @@ type (
 
 		// scheduler stores the asynq scheduler
 		scheduler *asynq.Scheduler
+
+		// inspector stores the asynq inspector
+		inspector *asynq.Inspector
 	}
 
 	// task handles task creation operations
@@ -24,6 +31,7 @@ type (
 		client     *TaskClient
 		typ        string
 		payload    any
+		id         *string
 		periodic   *string
 		queue      *string
 		maxRetries *int
@@ -52,11 +60,15 @@ func NewTaskClient(cfg *config.Config) *TaskClient {
 	return &TaskClient{
 		client:    asynq.NewClient(conn),
 		scheduler: asynq.NewScheduler(conn, nil),
+		inspector: asynq.NewInspector(conn),
 	}
 }
 
 // Close closes the connection to the task service
 func (t *TaskClient) Close() error {
+	if err := t.inspector.Close(); err != nil {
+		return err
+	}
 	return t.client.Close()
 }
 
@@ -74,6 +86,52 @@ func (t *TaskClient) New(typ string) *task {
 	}
 }
 
+// Delete deletes a queued task by its ID so it will not be executed
+// The default queue will be used if an empty queue name is provided.
+// No error is returned if the task does not exist, and ErrTaskActive is returned if it is being processed.
+func (t *TaskClient) Delete(queue, id string) error {
+	if queue == "" {
+		queue = "default"
+	}
+
+	err := t.inspector.DeleteTask(queue, id)
+	if err == nil || errors.Is(err, asynq.ErrTaskNotFound) || errors.Is(err, asynq.ErrQueueNotFound) {
+		return nil
+	}
+
+	// The inspector does not distinguish tasks being processed from other failures
+	if info, infoErr := t.inspector.GetTaskInfo(queue, id); infoErr == nil && info.State == asynq.TaskStateActive {
+		return ErrTaskActive
+	}
+	return err
+}
+
+// Queues returns the current statistics of each queue
+func (t *TaskClient) Queues() ([]*asynq.QueueInfo, error) {
+	names, err := t.inspector.Queues()
+	if err != nil {
+		return nil, err
+	}
+
+	queues := make([]*asynq.QueueInfo, 0, len(names))
+	for _, name := range names {
+		info, err := t.inspector.GetQueueInfo(name)
+		if err != nil {
+			return nil, err
+		}
+		queues = append(queues, info)
+	}
+
+	return queues, nil
+}
+
+// ID sets a unique ID for the task which prevents another task with the same ID from being queued
+// until this one has been processed and allows the task to be deleted via TaskClient.Delete()
+func (t *task) ID(id string) *task {
+	t.id = &id
+	return t
+}
+
 // Payload sets the task payload data which will be sent to the task handler
 func (t *task) Payload(payload any) *task {
 	t.payload = payload
@@ -144,6 +202,9 @@ func (t *task) Save() error {
 
 	// Build the task options
 	opts := make([]asynq.Option, 0)
+	if t.id != nil {
+		opts = append(opts, asynq.TaskID(*t.id))
+	}
 	if t.queue != nil {
 		opts = append(opts, asynq.Queue(*t.queue))
 	}
@@ -1,16 +1,21 @@
 package services
 
 import (
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/hibiken/asynq"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTaskClient_New(t *testing.T) {
 	now := time.Now()
 	tk := c.Tasks.
 		New("task1").
+		ID("id").
 		Payload("payload").
 		Queue("queue").
 		Periodic("@every 5s").
@@ -22,6 +27,7 @@ func TestTaskClient_New(t *testing.T) {
 		Retain(7 * time.Second)
 
 	assert.Equal(t, "task1", tk.typ)
+	assert.Equal(t, "id", *tk.id)
 	assert.Equal(t, "payload", tk.payload.(string))
 	assert.Equal(t, "queue", *tk.queue)
 	assert.Equal(t, "@every 5s", *tk.periodic)
@@ -33,3 +39,88 @@ func TestTaskClient_New(t *testing.T) {
 	assert.Equal(t, 7*time.Second, *tk.retain)
 	assert.NoError(t, tk.Save())
 }
+
+func TestTaskClient_Delete(t *testing.T) {
+	err := c.Tasks.
+		New("task2").
+		ID("task2-id").
+		Wait(time.Hour).
+		Save()
+	require.NoError(t, err)
+
+	// A task with the same ID cannot be queued while the first remains
+	err = c.Tasks.
+		New("task2").
+		ID("task2-id").
+		Wait(time.Hour).
+		Save()
+	assert.Error(t, err)
+
+	assert.NoError(t, c.Tasks.Delete("", "task2-id"))
+	assert.NoError(t, c.Tasks.Delete("", "task2-id"))
+
+	err = c.Tasks.
+		New("task2").
+		ID("task2-id").
+		Wait(time.Hour).
+		Save()
+	assert.NoError(t, err)
+}
+
+func TestTaskClient_Delete_Active(t *testing.T) {
+	// Process tasks in a queue of their own so the task is held while it is active
+	started, done := make(chan struct{}), make(chan struct{})
+	srv := asynq.NewServer(
+		asynq.RedisClientOpt{
+			Addr:     fmt.Sprintf("%s:%d", c.Config.Cache.Hostname, c.Config.Cache.Port),
+			Password: c.Config.Cache.Password,
+			DB:       c.Config.Cache.TestDatabase,
+		},
+		asynq.Config{Queues: map[string]int{"task4-queue": 1}},
+	)
+	err := srv.Start(asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+		close(started)
+		<-done
+		return nil
+	}))
+	require.NoError(t, err)
+	defer srv.Shutdown()
+
+	err = c.Tasks.
+		New("task4").
+		ID("task4-id").
+		Queue("task4-queue").
+		Save()
+	require.NoError(t, err)
+
+	select {
+	case <-started:
+	case <-time.After(10 * time.Second):
+		t.Fatal("task was not processed")
+	}
+
+	// Tasks being processed cannot be deleted
+	assert.ErrorIs(t, c.Tasks.Delete("task4-queue", "task4-id"), ErrTaskActive)
+	close(done)
+}
+
+func TestTaskClient_Queues(t *testing.T) {
+	err := c.Tasks.
+		New("task3").
+		Queue("task3-queue").
+		Wait(time.Hour).
+		Save()
+	require.NoError(t, err)
+
+	queues, err := c.Tasks.Queues()
+	require.NoError(t, err)
+
+	var found bool
+	for _, q := range queues {
+		if q.Queue == "task3-queue" {
+			found = true
+			assert.Equal(t, 1, q.Scheduled)
+		}
+	}
+	assert.True(t, found)
+}
@@ -114,16 +114,8 @@ func (t *TemplateRenderer) parse(build *templateBuild) (*TemplateParsed, error)
 			build.directories[k] = fmt.Sprintf("%s/*%s", v, config.TemplateExt)
 		}
 
-		// Get the templates
-		var tpl fs.FS
-		if t.config.App.Environment == config.EnvLocal {
-			tpl = templates.GetOS()
-		} else {
-			tpl = templates.Get()
-		}
-
 		// Parse the templates
-		parsed, err = parsed.ParseFS(tpl, append(build.files, build.directories...)...)
+		parsed, err = parsed.ParseFS(t.getFS(), append(build.files, build.directories...)...)
 		if err != nil {
 			return nil, err
 		}
@@ -139,6 +131,22 @@ func (t *TemplateRenderer) parse(build *templateBuild) (*TemplateParsed, error)
 	return tp, nil
 }
 
+// getFS returns the file system containing the templates
+// If the application environment is set to local, the templates are loaded directly from the operating system.
+func (t *TemplateRenderer) getFS() fs.FS {
+	if t.config.App.Environment == config.EnvLocal {
+		return templates.GetOS()
+	}
+	return templates.Get()
+}
+
+// Exists determines if a template file exists.
+// This should not include the file extension and the path should be relative to the templates directory.
+func (t *TemplateRenderer) Exists(file string) bool {
+	_, err := fs.Stat(t.getFS(), file+config.TemplateExt)
+	return err == nil
+}
+
 // Load loads a template from the cache
 func (t *TemplateRenderer) Load(group, key string) (*TemplateParsed, error) {
 	load, ok := t.templateCache.Load(t.getCacheKey(group, key))
@@ -70,3 +70,9 @@ func TestTemplateRenderer(t *testing.T) {
 	require.NotNil(t, buf)
 	assert.Contains(t, buf.String(), "Please try again")
 }
+
+func TestTemplateRenderer_Exists(t *testing.T) {
+	assert.True(t, c.TemplateRenderer.Exists("pages/error"))
+	assert.True(t, c.TemplateRenderer.Exists("emails/test.txt"))
+	assert.False(t, c.TemplateRenderer.Exists("emails/missing"))
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage stores files in a directory on the local filesystem
+type LocalStorage struct {
+	// directory stores the directory files are stored in
+	directory string
+
+	// urlPrefix stores the URL path prefix the directory is served from
+	urlPrefix string
+}
+
+// NewLocalStorage creates a new LocalStorage which stores files in a given directory, creating it if needed,
+// which is expected to be served from a given URL prefix
+func NewLocalStorage(directory, urlPrefix string) (*LocalStorage, error) {
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &LocalStorage{
+		directory: directory,
+		urlPrefix: strings.TrimSuffix(urlPrefix, "/"),
+	}, nil
+}
+
+// Directory returns the directory files are stored in
+func (s *LocalStorage) Directory() string {
+	return s.directory
+}
+
+// Put stores the contents of a reader under a key
+// The file is written to a temporary file first so a partially written file is never served
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), p)
+}
+
+// Get opens the file stored under a key
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+// Delete removes the file stored under a key
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// URL returns the URL the file stored under a key is served from
+func (s *LocalStorage) URL(key string) string {
+	return s.urlPrefix + "/" + key
+}
+
+// path returns the path on the filesystem of the file stored under a key
+func (s *LocalStorage) path(key string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.directory, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mikestefanello/pagoda/config"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Storage stores files in a bucket of an S3-compatible service, such as MinIO
+type S3Storage struct {
+	// client stores the S3 client
+	client *minio.Client
+
+	// bucket stores the name of the bucket files are stored in
+	bucket string
+
+	// publicURL stores the base URL objects in the bucket are publicly served from
+	publicURL string
+}
+
+// NewS3Storage creates a new S3Storage
+// Buckets are addressed by path, rather than subdomain, so any S3-compatible service can be used.
+func NewS3Storage(cfg config.S3StorageConfig) (*S3Storage, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure:       cfg.UseSSL,
+		Region:       cfg.Region,
+		BucketLookup: minio.BucketLookupPath,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publicURL := cfg.PublicURL
+	if publicURL == "" {
+		scheme := "http"
+		if cfg.UseSSL {
+			scheme = "https"
+		}
+		publicURL = fmt.Sprintf("%s://%s/%s", scheme, cfg.Endpoint, cfg.Bucket)
+	}
+
+	return &S3Storage{
+		client:    client,
+		bucket:    cfg.Bucket,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+	}, nil
+}
+
+// Put stores the contents of a reader as an object under a key
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.PutObject(ctx, s.bucket, key, r, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
+
+// Get opens the object stored under a key
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s.error(err)
+	}
+
+	// The object is fetched lazily so stat it in order to know if it exists
+	if _, err = obj.Stat(); err != nil {
+		_ = obj.Close()
+		return nil, s.error(err)
+	}
+
+	return obj, nil
+}
+
+// Delete removes the object stored under a key
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	return s.error(s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}))
+}
+
+// URL returns the public URL of the object stored under a key
+func (s *S3Storage) URL(key string) string {
+	return s.publicURL + "/" + key
+}
+
+// error converts errors returned by the service which indicate a missing object to ErrNotFound
+func (s *S3Storage) error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrNotFound
+	}
+	return err
+}
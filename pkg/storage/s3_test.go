@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is a minimal in-memory stand-in for an S3-compatible service, such as MinIO, which supports
+// the object operations used by S3Storage on a single bucket addressed by path
+type fakeS3 struct {
+	bucket  string
+	mu      sync.Mutex
+	objects map[string]fakeS3Object
+}
+
+type fakeS3Object struct {
+	body        []byte
+	contentType string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+f.bucket+"/")
+	if !ok || key == "" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("X-Amz-Content-Sha256") == "STREAMING-AWS4-HMAC-SHA256-PAYLOAD" {
+			body = decodeAWSChunked(body)
+		}
+		f.objects[key] = fakeS3Object{body: body, contentType: r.Header.Get("Content-Type")}
+		w.Header().Set("ETag", `"etag"`)
+
+	case http.MethodHead, http.MethodGet:
+		obj, exists := f.objects[key]
+		if !exists {
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>Not found</Message></Error>`))
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.body)))
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Header().Set("ETag", `"etag"`)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(obj.body)
+		}
+
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// decodeAWSChunked decodes a body sent using the streaming signature, where each chunk is prefixed
+// by its size in hex along with its signature
+func decodeAWSChunked(body []byte) []byte {
+	var out bytes.Buffer
+	r := bufio.NewReader(bytes.NewReader(body))
+	for {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			break
+		}
+		size, err := strconv.ParseInt(strings.SplitN(strings.TrimSpace(header), ";", 2)[0], 16, 64)
+		if err != nil || size == 0 {
+			break
+		}
+		if _, err = io.CopyN(&out, r, size); err != nil {
+			break
+		}
+		_, _ = r.ReadString('\n')
+	}
+	return out.Bytes()
+}
+
+func TestS3Storage(t *testing.T) {
+	fake := &fakeS3{bucket: "media", objects: make(map[string]fakeS3Object)}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	cfg := config.S3StorageConfig{
+		Endpoint:  strings.TrimPrefix(srv.URL, "http://"),
+		Region:    "us-east-1",
+		Bucket:    "media",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}
+	s, err := NewS3Storage(cfg)
+	require.NoError(t, err)
+	testStorage(t, s)
+
+	assert.Equal(t, "text/plain", fake.objects["a/b.txt"].contentType)
+	assert.Equal(t, srv.URL+"/media/a/b.png", s.URL("a/b.png"))
+
+	cfg.PublicURL = "https://cdn.localhost/"
+	s, err = NewS3Storage(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.localhost/a/b.png", s.URL("a/b.png"))
+}
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// ErrNotFound is returned when a file does not exist in storage
+var ErrNotFound = errors.New("file not found")
+
+// Storage stores files, such as uploaded media, outside of the application
+type Storage interface {
+	// Put stores the contents of a reader, of a given size and content type, under a key
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// Get opens the file stored under a key, returning ErrNotFound if it does not exist
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the file stored under a key, which does not fail if it does not exist
+	Delete(ctx context.Context, key string) error
+
+	// URL returns the URL that the file stored under a key can be accessed at
+	URL(key string) string
+}
+
+// cleanKey validates a key and returns it in a normalized form, ensuring that it cannot reference
+// anything outside of the storage
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean("/" + strings.ReplaceAll(key, "\\", "/"))
+	if cleaned == "/" || cleaned != "/"+key {
+		return "", fmt.Errorf("invalid storage key: %q", key)
+	}
+	return strings.TrimPrefix(cleaned, "/"), nil
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanKey(t *testing.T) {
+	key, err := cleanKey("2024/01/abc.png")
+	require.NoError(t, err)
+	assert.Equal(t, "2024/01/abc.png", key)
+
+	for _, k := range []string{"", "/abc.png", "../abc.png", "a/../../abc.png", "a//b.png", "a\\..\\b.png", "a/"} {
+		_, err = cleanKey(k)
+		assert.Error(t, err, k)
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(filepath.Join(dir, "uploads"), "/uploads/")
+	require.NoError(t, err)
+	testStorage(t, s)
+
+	assert.Equal(t, "/uploads/a/b.png", s.URL("a/b.png"))
+
+	b, err := os.ReadFile(filepath.Join(dir, "uploads", "a", "b.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "updated", string(b))
+
+	// No temporary files should remain
+	entries, err := os.ReadDir(filepath.Join(dir, "uploads", "a"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+// testStorage tests the behavior that all Storage implementations must provide
+func testStorage(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	_, err := s.Get(ctx, "a/b.txt")
+	assert.Equal(t, ErrNotFound, err)
+
+	require.NoError(t, s.Put(ctx, "a/b.txt", strings.NewReader("hello"), 5, "text/plain"))
+	assertContents(t, s, "a/b.txt", "hello")
+
+	require.NoError(t, s.Put(ctx, "a/b.txt", strings.NewReader("updated"), 7, "text/plain"))
+	assertContents(t, s, "a/b.txt", "updated")
+
+	require.NoError(t, s.Put(ctx, "a/c.txt", strings.NewReader("other"), 5, "text/plain"))
+	require.NoError(t, s.Delete(ctx, "a/c.txt"))
+	_, err = s.Get(ctx, "a/c.txt")
+	assert.Equal(t, ErrNotFound, err)
+	assert.NoError(t, s.Delete(ctx, "a/c.txt"))
+
+	assert.Error(t, s.Put(ctx, "../x.txt", strings.NewReader("x"), 1, "text/plain"))
+}
+
+func assertContents(t *testing.T, s Storage, key, expected string) {
+	r, err := s.Get(context.Background(), key)
+	require.NoError(t, err)
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, expected, string(b))
+}
@@ -0,0 +1,88 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/comment"
+	"github.com/mikestefanello/pagoda/pkg/paths"
+	"github.com/mikestefanello/pagoda/pkg/services"
+
+	"github.com/hibiken/asynq"
+)
+
+// TypeCommentNotification is the type for the task which notifies a post author of a new comment
+const TypeCommentNotification = "comment_notification"
+
+type (
+	// CommentNotificationPayload is the payload of the comment notification task
+	CommentNotificationPayload struct {
+		CommentID int `json:"comment_id"`
+	}
+
+	// CommentNotificationProcessor processes comment notification tasks
+	CommentNotificationProcessor struct {
+		orm    *ent.Client
+		mail   *services.MailClient
+		config *config.Config
+	}
+
+	// commentNotification is the template data of the comment notification email
+	commentNotification struct {
+		*ent.Comment
+		ModerationURL string
+	}
+)
+
+// NewCommentNotificationProcessor creates a new CommentNotificationProcessor
+func NewCommentNotificationProcessor(c *services.Container) *CommentNotificationProcessor {
+	return &CommentNotificationProcessor{
+		orm:    c.ORM,
+		mail:   c.Mail,
+		config: c.Config,
+	}
+}
+
+// ProcessTask handles the processing of the task
+func (p *CommentNotificationProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var payload CommentNotificationPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("unable to parse payload: %v: %w", err, asynq.SkipRetry)
+	}
+
+	cm, err := p.orm.Comment.
+		Query().
+		Where(comment.ID(payload.CommentID)).
+		WithAuthor().
+		WithPost(func(q *ent.PostQuery) {
+			q.WithAuthor()
+		}).
+		Only(ctx)
+
+	switch err.(type) {
+	case nil:
+	case *ent.NotFoundError:
+		// The comment was deleted before the notification was sent
+		return nil
+	default:
+		return err
+	}
+
+	// Only comments awaiting moderation require the attention of the author
+	if cm.Status != comment.StatusPending {
+		return nil
+	}
+
+	return p.mail.
+		Compose().
+		To(cm.Edges.Post.Edges.Author.Email).
+		Template("comment-notification").
+		TemplateData(commentNotification{
+			Comment:       cm,
+			ModerationURL: paths.Absolute(p.config.App.URL, paths.CommentModeration),
+		}).
+		Send(ctx)
+}
@@ -0,0 +1,98 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/pkg/mailer"
+	"github.com/mikestefanello/pagoda/pkg/services"
+
+	"github.com/hibiken/asynq"
+)
+
+// TypeSendMail is the type for the task which sends email queued via SendAsync()
+const TypeSendMail = services.TypeSendMail
+
+const (
+	// sendMailBaseDelay is the delay before the first retry of a failed send, which doubles with each retry
+	sendMailBaseDelay = 30 * time.Second
+
+	// sendMailMaxDelay is the maximum delay between retries of a failed send
+	sendMailMaxDelay = 6 * time.Hour
+)
+
+type (
+	// SendMailProcessor processes send mail tasks.
+	// Sends which fail permanently, or which have exhausted their retries, are stored as FailedMail entities.
+	SendMailProcessor struct {
+		orm  *ent.Client
+		mail mailDeliverer
+	}
+
+	// mailDeliverer delivers composed email, such as the MailClient
+	mailDeliverer interface {
+		Deliver(ctx context.Context, msg *mailer.Message) error
+	}
+)
+
+// NewSendMailProcessor creates a new SendMailProcessor
+func NewSendMailProcessor(c *services.Container) *SendMailProcessor {
+	return &SendMailProcessor{
+		orm:  c.ORM,
+		mail: c.Mail,
+	}
+}
+
+// SendMailRetryDelay returns the delay before retrying a failed send, which grows exponentially with the
+// amount of times the send has been retried
+func SendMailRetryDelay(retried int) time.Duration {
+	if retried >= 16 {
+		return sendMailMaxDelay
+	}
+	return min(sendMailBaseDelay<<retried, sendMailMaxDelay)
+}
+
+// ProcessTask handles the processing of the task
+func (p *SendMailProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var payload services.SendMailPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil || payload.Message == nil {
+		return fmt.Errorf("unable to parse payload: %v: %w", err, asynq.SkipRetry)
+	}
+
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	return p.send(ctx, payload.Message, retried, maxRetry)
+}
+
+// send delivers a message which has been retried a given amount of times, returning an error so it will be
+// retried again if the delivery failed, unless the failure is permanent or it was the final attempt
+func (p *SendMailProcessor) send(ctx context.Context, msg *mailer.Message, retried, maxRetry int) error {
+	err := p.mail.Deliver(ctx, msg)
+	if err == nil {
+		return nil
+	}
+
+	if !mailer.IsPermanent(err) && retried < maxRetry {
+		return err
+	}
+
+	// Store the email so it can be inspected and retried rather than leaving it archived in the queue
+	err = p.orm.FailedMail.
+		Create().
+		SetTo(strings.Join(msg.To, ", ")).
+		SetSubject(msg.Subject).
+		SetMessage(msg).
+		SetError(err.Error()).
+		SetAttempts(retried + 1).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("unable to store failed mail: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent/failedmail"
+	"github.com/mikestefanello/pagoda/pkg/mailer"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDeliverer fails to deliver messages with a given error
+type testDeliverer struct {
+	err       error
+	delivered int
+}
+
+func (d *testDeliverer) Deliver(_ context.Context, _ *mailer.Message) error {
+	d.delivered++
+	return d.err
+}
+
+func TestSendMailRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, SendMailRetryDelay(0))
+	assert.Equal(t, time.Minute, SendMailRetryDelay(1))
+	assert.Equal(t, 4*time.Minute, SendMailRetryDelay(3))
+	assert.Equal(t, 6*time.Hour, SendMailRetryDelay(12))
+	assert.Equal(t, 6*time.Hour, SendMailRetryDelay(100))
+}
+
+func TestSendMailProcessor_ProcessTask(t *testing.T) {
+	ctx := context.Background()
+	deliverer := &testDeliverer{}
+	p := &SendMailProcessor{
+		orm:  c.ORM,
+		mail: deliverer,
+	}
+
+	message := func(name string) *mailer.Message {
+		return &mailer.Message{
+			From:    "from@localhost.localhost",
+			To:      []string{fmt.Sprintf("%s-%d@localhost.localhost", name, time.Now().UnixNano())},
+			Subject: "Test " + name,
+			Text:    "Hello",
+		}
+	}
+
+	failed := func(msg *mailer.Message) int {
+		count, err := c.ORM.FailedMail.
+			Query().
+			Where(failedmail.To(msg.To[0])).
+			Count(ctx)
+		require.NoError(t, err)
+		return count
+	}
+
+	// A payload which cannot be parsed is never retried
+	err := p.ProcessTask(ctx, asynq.NewTask(TypeSendMail, []byte("invalid")))
+	assert.ErrorIs(t, err, asynq.SkipRetry)
+	assert.Equal(t, 0, deliverer.delivered)
+
+	// A delivered message is not stored
+	msg := message("delivered")
+	require.NoError(t, p.send(ctx, msg, 0, 3))
+	assert.Equal(t, 1, deliverer.delivered)
+	assert.Equal(t, 0, failed(msg))
+
+	// A temporary failure is returned so the task is retried
+	deliverer.err = errors.New("connection refused")
+	msg = message("retried")
+	assert.Equal(t, deliverer.err, p.send(ctx, msg, 2, 3))
+	assert.Equal(t, 0, failed(msg))
+
+	// The final attempt stores the message rather than being retried
+	assert.NoError(t, p.send(ctx, msg, 3, 3))
+	fm, err := c.ORM.FailedMail.
+		Query().
+		Where(failedmail.To(msg.To[0])).
+		Only(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, msg.Subject, fm.Subject)
+	assert.Equal(t, msg.Text, fm.Message.Text)
+	assert.Equal(t, "connection refused", fm.Error)
+	assert.Equal(t, 4, fm.Attempts)
+
+	// A permanent failure is stored without being retried
+	deliverer.err = &textproto.Error{Code: 550, Msg: "mailbox unavailable"}
+	msg = message("rejected")
+	assert.NoError(t, p.send(ctx, msg, 0, 3))
+	assert.Equal(t, 1, failed(msg))
+}
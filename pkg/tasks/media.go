@@ -0,0 +1,182 @@
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/pkg/imaging"
+	"github.com/mikestefanello/pagoda/pkg/services"
+	"github.com/mikestefanello/pagoda/pkg/storage"
+
+	"github.com/hibiken/asynq"
+)
+
+// TypeProcessMedia is the type for the task which processes uploaded images
+const TypeProcessMedia = "process_media"
+
+type (
+	// ProcessMediaPayload is the payload of the process media task
+	ProcessMediaPayload struct {
+		MediaID int `json:"media_id"`
+	}
+
+	// ProcessMediaProcessor processes process media tasks.
+	// The dimensions of uploaded images are recorded and resized variants are generated for each of the
+	// imaging.Sizes, in JPEG or, for images with transparency, PNG, and in WebP when the WebP encoder is registered,
+	// which the worker does. Metadata has already been removed from the images when they were uploaded.
+	ProcessMediaProcessor struct {
+		orm     *ent.Client
+		storage storage.Storage
+	}
+)
+
+// NewProcessMediaProcessor creates a new ProcessMediaProcessor
+func NewProcessMediaProcessor(c *services.Container) *ProcessMediaProcessor {
+	return &ProcessMediaProcessor{
+		orm:     c.ORM,
+		storage: c.Storage,
+	}
+}
+
+// ProcessTask handles the processing of the task
+func (p *ProcessMediaProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var payload ProcessMediaPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("unable to parse payload: %v: %w", err, asynq.SkipRetry)
+	}
+
+	m, err := p.orm.Media.Get(ctx, payload.MediaID)
+
+	switch err.(type) {
+	case nil:
+	case *ent.NotFoundError:
+		// The media was deleted before it was processed
+		return nil
+	default:
+		return err
+	}
+
+	if !imaging.IsSupported(m.ContentType) {
+		return nil
+	}
+
+	data, err := p.read(ctx, m.Key)
+	switch {
+	case err == nil:
+	case errors.Is(err, storage.ErrNotFound):
+		return nil
+	default:
+		return err
+	}
+
+	img, err := imaging.Decode(data)
+	if err != nil {
+		// Retrying will not help if the image cannot be decoded
+		return fmt.Errorf("unable to decode image: %v: %w", err, asynq.SkipRetry)
+	}
+
+	variants, err := p.generateVariants(ctx, m.Key, img)
+	if err != nil {
+		return err
+	}
+
+	err = m.Update().
+		SetWidth(img.Bounds().Dx()).
+		SetHeight(img.Bounds().Dy()).
+		SetVariants(variants).
+		Exec(ctx)
+
+	switch err.(type) {
+	case nil:
+		return nil
+	case *ent.NotFoundError:
+		// The media was deleted while it was being processed
+		for _, v := range variants {
+			if err := p.storage.Delete(ctx, v.Key); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return err
+	}
+}
+
+// generateVariants resizes an image to each of the sizes and stores a JPEG of each, or a PNG if the image has
+// transparency which JPEG does not support, along with a WebP if a WebP encoder has been registered. Sizes larger
+// than the image result in the same variant so only the first of those is kept.
+func (p *ProcessMediaProcessor) generateVariants(ctx context.Context, key string, img image.Image) ([]imaging.Variant, error) {
+	base := strings.TrimSuffix(key, path.Ext(key))
+	variants := make([]imaging.Variant, 0, len(imaging.Sizes)*2)
+	prevWidth := 0
+
+	type encoding struct {
+		ext         string
+		contentType string
+		encode      func(io.Writer, image.Image) error
+	}
+
+	for _, size := range imaging.Sizes {
+		resized := imaging.Resize(img, size.Width)
+		b := resized.Bounds()
+		if b.Dx() == prevWidth {
+			break
+		}
+		prevWidth = b.Dx()
+
+		fallback := encoding{ext: ".jpg", contentType: "image/jpeg", encode: imaging.EncodeJPEG}
+		if !imaging.IsOpaque(resized) {
+			fallback = encoding{ext: ".png", contentType: "image/png", encode: imaging.EncodePNG}
+		}
+
+		encodings := []encoding{fallback}
+		if imaging.CanEncodeWebP() {
+			encodings = append(encodings, encoding{ext: ".webp", contentType: "image/webp", encode: imaging.EncodeWebP})
+		}
+
+		for _, e := range encodings {
+			var buf bytes.Buffer
+			if err := e.encode(&buf, resized); err != nil {
+				return nil, err
+			}
+
+			v := imaging.Variant{
+				Name:        size.Name,
+				Key:         fmt.Sprintf("%s_%s%s", base, size.Name, e.ext),
+				ContentType: e.contentType,
+				Width:       b.Dx(),
+				Height:      b.Dy(),
+				Size:        int64(buf.Len()),
+			}
+			if err := p.put(ctx, v.Key, buf.Bytes(), v.ContentType); err != nil {
+				return nil, err
+			}
+			variants = append(variants, v)
+		}
+	}
+
+	return variants, nil
+}
+
+// read loads the file stored under a key
+func (p *ProcessMediaProcessor) read(ctx context.Context, key string) ([]byte, error) {
+	r, err := p.storage.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// put stores data under a key
+func (p *ProcessMediaProcessor) put(ctx context.Context, key string, data []byte, contentType string) error {
+	return p.storage.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType)
+}
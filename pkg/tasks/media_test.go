@@ -0,0 +1,82 @@
+package tasks
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"io"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/pkg/imaging"
+	"github.com/mikestefanello/pagoda/pkg/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessMediaProcessor_GenerateVariants(t *testing.T) {
+	s, err := storage.NewLocalStorage(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+	p := &ProcessMediaProcessor{storage: s}
+
+	img := image.NewNRGBA(image.Rect(0, 0, 1000, 500))
+	for i := 3; i < len(img.Pix); i += 4 {
+		img.Pix[i] = 0xff
+	}
+
+	// Sizes wider than the image are skipped
+	variants, err := p.generateVariants(context.Background(), "2024/01/abc.png", img)
+	require.NoError(t, err)
+	require.Len(t, variants, 3)
+	assert.Equal(t, imaging.Variant{
+		Name:        "thumbnail",
+		Key:         "2024/01/abc_thumbnail.jpg",
+		ContentType: "image/jpeg",
+		Width:       150,
+		Height:      75,
+		Size:        variants[0].Size,
+	}, variants[0])
+	assert.Equal(t, 768, variants[1].Width)
+	assert.Equal(t, "large", variants[2].Name)
+	assert.Equal(t, 1000, variants[2].Width)
+
+	for _, v := range variants {
+		r, err := s.Get(context.Background(), v.Key)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+	}
+
+	// Images with transparency are stored as PNG rather than JPEG
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 100; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{})
+		}
+	}
+	variants, err = p.generateVariants(context.Background(), "2024/01/def.png", img)
+	require.NoError(t, err)
+	require.Len(t, variants, 3)
+	for _, v := range variants {
+		assert.Equal(t, "image/png", v.ContentType)
+	}
+	assert.Equal(t, "2024/01/def_medium.png", variants[1].Key)
+
+	// A WebP of each size is also stored once an encoder is registered
+	imaging.RegisterWebPEncoder(func(w io.Writer, _ image.Image) error {
+		_, err := w.Write([]byte("webp"))
+		return err
+	})
+	defer imaging.RegisterWebPEncoder(nil)
+
+	variants, err = p.generateVariants(context.Background(), "2024/01/ghi.png", img)
+	require.NoError(t, err)
+	require.Len(t, variants, 6)
+	assert.Equal(t, imaging.Variant{
+		Name:        "thumbnail",
+		Key:         "2024/01/ghi_thumbnail.webp",
+		ContentType: "image/webp",
+		Width:       150,
+		Height:      75,
+		Size:        4,
+	}, variants[1])
+	assert.Equal(t, "2024/01/ghi_medium.png", variants[2].Key)
+}
@@ -0,0 +1,159 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/ent/subscriber"
+	"github.com/mikestefanello/pagoda/pkg/paths"
+	"github.com/mikestefanello/pagoda/pkg/services"
+
+	"github.com/hibiken/asynq"
+)
+
+// TypeNewsletterDigest is the type for the periodic task which emails subscribers the posts published since
+// they were last sent a digest
+const TypeNewsletterDigest = "newsletter_digest"
+
+type (
+	// NewsletterDigestProcessor processes newsletter digest tasks
+	NewsletterDigestProcessor struct {
+		orm    *ent.Client
+		mail   *services.MailClient
+		auth   *services.AuthClient
+		config *config.Config
+	}
+
+	// newsletterDigest is the template data of the newsletter digest email
+	newsletterDigest struct {
+		Posts          []newsletterDigestPost
+		UnsubscribeURL string
+	}
+
+	// newsletterDigestPost is a post included in the newsletter digest email
+	newsletterDigestPost struct {
+		Title   string
+		URL     string
+		Excerpt string
+	}
+)
+
+// NewNewsletterDigestProcessor creates a new NewsletterDigestProcessor
+func NewNewsletterDigestProcessor(c *services.Container) *NewsletterDigestProcessor {
+	return &NewsletterDigestProcessor{
+		orm:    c.ORM,
+		mail:   c.Mail,
+		auth:   c.Auth,
+		config: c.Config,
+	}
+}
+
+// ProcessTask handles the processing of the task
+func (p *NewsletterDigestProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	now := time.Now()
+	lastID := 0
+
+	// Load the confirmed subscribers in batches
+	for {
+		subs, err := p.orm.Subscriber.
+			Query().
+			Where(
+				subscriber.ConfirmedAtNotNil(),
+				subscriber.IDGT(lastID),
+			).
+			Order(ent.Asc(subscriber.FieldID)).
+			Limit(p.config.Newsletter.BatchSize).
+			All(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if len(subs) == 0 {
+			return nil
+		}
+		lastID = subs[len(subs)-1].ID
+
+		// Load the posts published since the earliest cutoff within the batch, which covers every subscriber
+		since := now
+		for _, sub := range subs {
+			if cutoff := digestCutoff(sub); cutoff.Before(since) {
+				since = cutoff
+			}
+		}
+
+		posts, err := p.orm.Post.
+			Query().
+			Where(
+				post.StatusEQ(post.StatusPublished),
+				post.PublishedAtGT(since),
+				post.PublishedAtLTE(now),
+			).
+			Order(ent.Desc(post.FieldPublishedAt)).
+			All(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		for _, sub := range subs {
+			if err = p.send(ctx, sub, posts, now); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// send sends a subscriber the digest of posts published after their cutoff, if there are any, and records
+// when it was sent so the posts are not sent again if the task is retried
+func (p *NewsletterDigestProcessor) send(ctx context.Context, sub *ent.Subscriber, posts []*ent.Post, now time.Time) error {
+	cutoff := digestCutoff(sub)
+	digest := newsletterDigest{}
+	for _, po := range posts {
+		if po.PublishedAt.After(cutoff) {
+			digest.Posts = append(digest.Posts, newsletterDigestPost{
+				Title:   po.Title,
+				URL:     paths.Absolute(p.config.App.URL, paths.Build(paths.Post, po.Slug)),
+				Excerpt: po.Excerpt,
+			})
+		}
+	}
+
+	if len(digest.Posts) == 0 {
+		return nil
+	}
+
+	token, err := p.auth.GenerateUnsubscribeToken(sub.Email)
+	if err != nil {
+		return err
+	}
+	digest.UnsubscribeURL = paths.Absolute(p.config.App.URL, paths.Build(paths.NewsletterUnsubscribe, token))
+
+	err = p.mail.
+		Compose().
+		To(sub.Email).
+		Template("newsletter-digest").
+		TemplateData(digest).
+		Header("List-Unsubscribe", "<"+digest.UnsubscribeURL+">").
+		Header("List-Unsubscribe-Post", "List-Unsubscribe=One-Click").
+		SendAsync()
+
+	if err != nil {
+		return err
+	}
+
+	return sub.Update().
+		SetDigestSentAt(now).
+		Exec(ctx)
+}
+
+// digestCutoff returns the time after which posts have not yet been sent to a given subscriber
+func digestCutoff(sub *ent.Subscriber) time.Time {
+	if sub.DigestSentAt != nil && sub.DigestSentAt.After(*sub.ConfirmedAt) {
+		return *sub.DigestSentAt
+	}
+	return *sub.ConfirmedAt
+}
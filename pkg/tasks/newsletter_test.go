@@ -0,0 +1,93 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewsletterDigestProcessor_ProcessTask(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	// Load the subscribers in batches smaller than the amount of subscribers
+	batchSize := c.Config.Newsletter.BatchSize
+	c.Config.Newsletter.BatchSize = 2
+	defer func() {
+		c.Config.Newsletter.BatchSize = batchSize
+	}()
+
+	p, err := c.ORM.Post.
+		Create().
+		SetTitle("Newsletter digest post").
+		SetBody("Lorem ipsum dolor sit amet.").
+		SetStatus(post.StatusPublished).
+		SetPublishedAt(now.Add(-time.Hour)).
+		SetAuthor(usr).
+		Save(permission.SystemContext(ctx))
+	require.NoError(t, err)
+
+	subscribe := func(name string, confirmedAt, digestSentAt *time.Time) *ent.Subscriber {
+		sub, err := c.ORM.Subscriber.
+			Create().
+			SetEmail(fmt.Sprintf("%s-%d@localhost.localhost", name, now.UnixNano())).
+			SetNillableConfirmedAt(confirmedAt).
+			SetNillableDigestSentAt(digestSentAt).
+			Save(ctx)
+		require.NoError(t, err)
+		return sub
+	}
+
+	confirmedAt := now.Add(-2 * time.Hour)
+	sentAt := now.Add(-30 * time.Minute)
+	var subs []*ent.Subscriber
+	for i := 0; i < 3; i++ {
+		subs = append(subs, subscribe(fmt.Sprintf("digest%d", i), &confirmedAt, nil))
+	}
+	sent := subscribe("sent", &confirmedAt, &sentAt)
+	unconfirmed := subscribe("unconfirmed", nil, nil)
+
+	err = NewNewsletterDigestProcessor(c).ProcessTask(ctx, asynq.NewTask(TypeNewsletterDigest, nil))
+	require.NoError(t, err)
+
+	// Every confirmed subscriber across the batches is sent the post
+	for _, sub := range subs {
+		messages := queuedMail(t, sub.Email)
+		require.Len(t, messages, 1)
+		assert.Contains(t, messages[0].Text, p.Title)
+		assert.Contains(t, messages[0].Text, c.Config.App.URL+"/post/"+p.Slug)
+
+		sub, err = c.ORM.Subscriber.Get(ctx, sub.ID)
+		require.NoError(t, err)
+		require.NotNil(t, sub.DigestSentAt)
+		assert.True(t, sub.DigestSentAt.After(*p.PublishedAt))
+	}
+
+	// Subscribers already sent the post, or who have not confirmed, are skipped
+	assert.Empty(t, queuedMail(t, sent.Email))
+	sent, err = c.ORM.Subscriber.Get(ctx, sent.ID)
+	require.NoError(t, err)
+	require.NotNil(t, sent.DigestSentAt)
+	assert.WithinDuration(t, sentAt, *sent.DigestSentAt, time.Millisecond)
+
+	assert.Empty(t, queuedMail(t, unconfirmed.Email))
+	unconfirmed, err = c.ORM.Subscriber.Get(ctx, unconfirmed.ID)
+	require.NoError(t, err)
+	assert.Nil(t, unconfirmed.DigestSentAt)
+
+	// Processing again does not resend the post
+	err = NewNewsletterDigestProcessor(c).ProcessTask(ctx, asynq.NewTask(TypeNewsletterDigest, nil))
+	require.NoError(t, err)
+	for _, sub := range subs {
+		assert.Len(t, queuedMail(t, sub.Email), 1)
+	}
+}
@@ -0,0 +1,132 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+	"github.com/mikestefanello/pagoda/pkg/services"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	// TypePublishPost is the type for the task which publishes a scheduled post
+	TypePublishPost = "publish_post"
+
+	// publishPostRetries is the amount of times to retry replacing the task to publish a post while it is being
+	// processed, waiting publishPostRetryDelay between each attempt
+	publishPostRetries    = 10
+	publishPostRetryDelay = 100 * time.Millisecond
+)
+
+type (
+	// PublishPostPayload is the payload of the publish post task
+	PublishPostPayload struct {
+		PostID int `json:"post_id"`
+	}
+
+	// PublishPostProcessor processes publish post tasks
+	PublishPostProcessor struct {
+		orm   *ent.Client
+		cache *services.CacheClient
+	}
+)
+
+// publishPostTaskID returns the ID of the task which publishes a given post.
+// Only one of these tasks can be queued per post which allows it to be found and replaced.
+func publishPostTaskID(postID int) string {
+	return fmt.Sprintf("%s:%d", TypePublishPost, postID)
+}
+
+// SchedulePostPublish queues a task to publish a post at its scheduled time, replacing the task queued
+// for any previous time
+func SchedulePostPublish(client *services.TaskClient, p *ent.Post) error {
+	if p.PublishAt == nil {
+		return errors.New("post has no publish time")
+	}
+
+	// A task being processed cannot be replaced until it is done, which does not take long. It will not publish
+	// the post if it has been rescheduled, since the status and publish time are checked again.
+	for i := 0; ; i++ {
+		err := client.Delete("", publishPostTaskID(p.ID))
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, services.ErrTaskActive) || i == publishPostRetries {
+			return err
+		}
+		time.Sleep(publishPostRetryDelay)
+	}
+
+	return client.
+		New(TypePublishPost).
+		ID(publishPostTaskID(p.ID)).
+		Payload(PublishPostPayload{PostID: p.ID}).
+		At(*p.PublishAt).
+		Save()
+}
+
+// CancelPostPublish removes the queued task to publish a post, if one exists. A task which is being processed is
+// left to finish, since it will not publish the post unless it is still scheduled.
+func CancelPostPublish(client *services.TaskClient, postID int) error {
+	if err := client.Delete("", publishPostTaskID(postID)); !errors.Is(err, services.ErrTaskActive) {
+		return err
+	}
+	return nil
+}
+
+// NewPublishPostProcessor creates a new PublishPostProcessor
+func NewPublishPostProcessor(c *services.Container) *PublishPostProcessor {
+	return &PublishPostProcessor{
+		orm:   c.ORM,
+		cache: c.Cache,
+	}
+}
+
+// ProcessTask handles the processing of the task
+func (p *PublishPostProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var payload PublishPostPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("unable to parse payload: %v: %w", err, asynq.SkipRetry)
+	}
+
+	ps, err := p.orm.Post.
+		Query().
+		Where(post.ID(payload.PostID)).
+		WithTags().
+		WithCategories().
+		Only(ctx)
+
+	switch err.(type) {
+	case nil:
+	case *ent.NotFoundError:
+		// The post was deleted after it was scheduled
+		return nil
+	default:
+		return err
+	}
+
+	// The post may have been unscheduled or rescheduled since the task was queued
+	// A minute of leeway allows for clock differences between the web and worker servers
+	if ps.Status != post.StatusScheduled || ps.PublishAt == nil || ps.PublishAt.After(time.Now().Add(time.Minute)) {
+		return nil
+	}
+
+	err = ps.Update().
+		SetStatus(post.StatusPublished).
+		SetPublishedAt(*ps.PublishAt).
+		ClearPublishAt().
+		Exec(permission.SystemContext(ctx))
+
+	if err != nil {
+		return err
+	}
+
+	return p.cache.FlushPostArchives(ctx, ps.Edges.Tags, ps.Edges.Categories)
+}
@@ -0,0 +1,114 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/pkg/permission"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulePostPublish(t *testing.T) {
+	p := createScheduledPost(t, time.Now().Add(time.Hour))
+
+	inspector := newInspector()
+	defer inspector.Close()
+
+	// Rescheduling replaces the queued task rather than queuing another
+	require.NoError(t, SchedulePostPublish(c.Tasks, p))
+	at := time.Now().Add(2 * time.Hour)
+	p.PublishAt = &at
+	require.NoError(t, SchedulePostPublish(c.Tasks, p))
+
+	info, err := inspector.GetTaskInfo("default", publishPostTaskID(p.ID))
+	require.NoError(t, err)
+	assert.Equal(t, asynq.TaskStateScheduled, info.State)
+	assert.Equal(t, at.Unix(), info.NextProcessAt.Unix())
+
+	tasks, err := inspector.ListScheduledTasks("default", asynq.PageSize(1000))
+	require.NoError(t, err)
+	count := 0
+	for _, task := range tasks {
+		if task.Type == TypePublishPost && string(task.Payload) == fmt.Sprintf(`{"post_id":%d}`, p.ID) {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+
+	// Cancelling removes the queued task
+	require.NoError(t, CancelPostPublish(c.Tasks, p.ID))
+	_, err = inspector.GetTaskInfo("default", publishPostTaskID(p.ID))
+	assert.ErrorIs(t, err, asynq.ErrTaskNotFound)
+
+	// Cancelling when there is no task does not fail
+	assert.NoError(t, CancelPostPublish(c.Tasks, p.ID))
+}
+
+func TestPublishPostProcessor_ProcessTask(t *testing.T) {
+	ctx := context.Background()
+	processor := NewPublishPostProcessor(c)
+
+	process := func(p *ent.Post) *ent.Post {
+		payload, err := json.Marshal(PublishPostPayload{PostID: p.ID})
+		require.NoError(t, err)
+		require.NoError(t, processor.ProcessTask(ctx, asynq.NewTask(TypePublishPost, payload)))
+
+		p, err = c.ORM.Post.Get(permission.SystemContext(ctx), p.ID)
+		require.NoError(t, err)
+		return p
+	}
+
+	// A post which is due is published at its scheduled time
+	at := time.Now().Add(-time.Minute).Truncate(time.Microsecond)
+	p := process(createScheduledPost(t, at))
+	assert.Equal(t, post.StatusPublished, p.Status)
+	require.NotNil(t, p.PublishedAt)
+	assert.True(t, at.Equal(*p.PublishedAt))
+	assert.Nil(t, p.PublishAt)
+
+	// A post rescheduled after the task was queued is left for the task which replaced it
+	p = process(createScheduledPost(t, time.Now().Add(time.Hour)))
+	assert.Equal(t, post.StatusScheduled, p.Status)
+	assert.Nil(t, p.PublishedAt)
+	assert.NotNil(t, p.PublishAt)
+
+	// A post unscheduled after the task was queued is not published
+	p = createScheduledPost(t, at)
+	p, err := p.Update().
+		SetStatus(post.StatusDraft).
+		ClearPublishAt().
+		Save(permission.SystemContext(ctx))
+	require.NoError(t, err)
+	p = process(p)
+	assert.Equal(t, post.StatusDraft, p.Status)
+	assert.Nil(t, p.PublishedAt)
+
+	// A post deleted after the task was queued is ignored
+	p = createScheduledPost(t, at)
+	require.NoError(t, c.ORM.Post.DeleteOne(p).Exec(permission.SystemContext(ctx)))
+	payload, err := json.Marshal(PublishPostPayload{PostID: p.ID})
+	require.NoError(t, err)
+	assert.NoError(t, processor.ProcessTask(ctx, asynq.NewTask(TypePublishPost, payload)))
+}
+
+// createScheduledPost creates a post scheduled to be published at a given time
+func createScheduledPost(t *testing.T, at time.Time) *ent.Post {
+	p, err := c.ORM.Post.
+		Create().
+		SetTitle(fmt.Sprintf("Scheduled post %d", time.Now().UnixNano())).
+		SetBody("Lorem ipsum dolor sit amet.").
+		SetStatus(post.StatusScheduled).
+		SetPublishAt(at).
+		SetAuthor(usr).
+		Save(permission.SystemContext(context.Background()))
+	require.NoError(t, err)
+	return p
+}
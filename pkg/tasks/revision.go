@@ -0,0 +1,76 @@
+package tasks
+
+import (
+	"context"
+
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/postrevision"
+	"github.com/mikestefanello/pagoda/pkg/services"
+
+	"github.com/hibiken/asynq"
+)
+
+// TypePruneRevisions is the type for the periodic task which prunes old post revisions
+const TypePruneRevisions = "prune_revisions"
+
+// PruneRevisionsProcessor processes prune revisions tasks
+type PruneRevisionsProcessor struct {
+	orm   *ent.Client
+	limit int
+}
+
+// NewPruneRevisionsProcessor creates a new PruneRevisionsProcessor
+func NewPruneRevisionsProcessor(c *services.Container) *PruneRevisionsProcessor {
+	return &PruneRevisionsProcessor{
+		orm:   c.ORM,
+		limit: c.Config.App.PostRevisions.Limit,
+	}
+}
+
+// ProcessTask handles the processing of the task
+func (p *PruneRevisionsProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	// Find the posts which have more revisions than the limit
+	var posts []struct {
+		PostID int `json:"post_id"`
+		Count  int `json:"count"`
+	}
+
+	err := p.orm.PostRevision.
+		Query().
+		GroupBy(postrevision.FieldPostID).
+		Aggregate(ent.Count()).
+		Scan(ctx, &posts)
+
+	if err != nil {
+		return err
+	}
+
+	for _, ps := range posts {
+		if ps.Count <= p.limit {
+			continue
+		}
+
+		// Keep the newest revisions
+		ids, err := p.orm.PostRevision.
+			Query().
+			Where(postrevision.PostID(ps.PostID)).
+			Order(ent.Desc(postrevision.FieldCreatedAt), ent.Desc(postrevision.FieldID)).
+			Offset(p.limit).
+			IDs(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = p.orm.PostRevision.
+			Delete().
+			Where(postrevision.IDIn(ids...)).
+			Exec(ctx)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
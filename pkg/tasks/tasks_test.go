@@ -0,0 +1,77 @@
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/mikestefanello/pagoda/config"
+	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/pkg/mailer"
+	"github.com/mikestefanello/pagoda/pkg/services"
+	"github.com/mikestefanello/pagoda/pkg/tests"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	c   *services.Container
+	usr *ent.User
+)
+
+func TestMain(m *testing.M) {
+	// Set the environment to test
+	config.SwitchEnvironment(config.EnvTest)
+
+	// Create a new container
+	c = services.NewContainer()
+
+	// Create a test user
+	var err error
+	if usr, err = tests.CreateUser(c.ORM); err != nil {
+		panic(err)
+	}
+
+	// Run tests
+	exitVal := m.Run()
+
+	// Shutdown the container
+	if err = c.Shutdown(); err != nil {
+		panic(err)
+	}
+
+	os.Exit(exitVal)
+}
+
+// newInspector creates an inspector of the tasks which have been queued
+func newInspector() *asynq.Inspector {
+	return asynq.NewInspector(asynq.RedisClientOpt{
+		Addr:     fmt.Sprintf("%s:%d", c.Config.Cache.Hostname, c.Config.Cache.Port),
+		Password: c.Config.Cache.Password,
+		DB:       c.Config.Cache.TestDatabase,
+	})
+}
+
+// queuedMail returns the messages which have been queued to be sent to a given email address
+func queuedMail(t *testing.T, to string) []*mailer.Message {
+	inspector := newInspector()
+	defer inspector.Close()
+
+	tasks, err := inspector.ListPendingTasks("default", asynq.PageSize(1000))
+	require.NoError(t, err)
+
+	var messages []*mailer.Message
+	for _, task := range tasks {
+		if task.Type != TypeSendMail {
+			continue
+		}
+		var payload services.SendMailPayload
+		require.NoError(t, json.Unmarshal(task.Payload, &payload))
+		if len(payload.Message.To) > 0 && payload.Message.To[0] == to {
+			messages = append(messages, payload.Message)
+		}
+	}
+	return messages
+}
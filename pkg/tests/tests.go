@@ -1,9 +1,11 @@
 package tests
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/rand"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -11,6 +13,8 @@ import (
 	"time"
 
 	"github.com/mikestefanello/pagoda/ent"
+	"github.com/mikestefanello/pagoda/ent/post"
+	"github.com/mikestefanello/pagoda/pkg/permission"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,6 +31,22 @@ func NewContext(e *echo.Echo, url string) (echo.Context, *httptest.ResponseRecor
 	return e.NewContext(req, rec), rec
 }
 
+// NewUploadContext creates a new Echo context for tests using an HTTP test request containing a multipart form
+// with a single file and a response recorder
+func NewUploadContext(e *echo.Echo, url, key, filename string, content []byte) (echo.Context, *httptest.ResponseRecorder) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if part, err := w.CreateFormFile(key, filename); err == nil {
+		_, _ = part.Write(content)
+	}
+	_ = w.Close()
+
+	req := httptest.NewRequest(http.MethodPost, url, &body)
+	req.Header.Set(echo.HeaderContentType, w.FormDataContentType())
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
 // InitSession initializes a session for a given Echo context
 func InitSession(ctx echo.Context) {
 	mw := session.Middleware(sessions.NewCookieStore([]byte("secret")))
@@ -58,3 +78,40 @@ func CreateUser(orm *ent.Client) (*ent.User, error) {
 		SetName(fmt.Sprintf("Test User %s", seed)).
 		Save(context.Background())
 }
+
+// CreatePost creates a random, published post entity authored by a given user, bypassing privacy policies
+func CreatePost(orm *ent.Client, author *ent.User) (*ent.Post, error) {
+	seed := fmt.Sprintf("%d-%d", time.Now().UnixMilli(), rand.Intn(1000000))
+	return orm.Post.
+		Create().
+		SetTitle(fmt.Sprintf("Test Post %s", seed)).
+		SetBody("Lorem ipsum dolor sit amet, consectetur adipiscing elit.").
+		SetStatus(post.StatusPublished).
+		SetPublishedAt(time.Now()).
+		SetAuthor(author).
+		Save(permission.SystemContext(context.Background()))
+}
+
+// CreateComment creates a pending guest comment on a given post
+func CreateComment(orm *ent.Client, p *ent.Post) (*ent.Comment, error) {
+	return orm.Comment.
+		Create().
+		SetPost(p).
+		SetBody("Lorem ipsum dolor sit amet.").
+		SetGuestName("Guest").
+		SetGuestEmail("guest@localhost.localhost").
+		Save(context.Background())
+}
+
+// CreateMedia creates a media entity owned by a given user, without storing a file
+func CreateMedia(orm *ent.Client, owner *ent.User) (*ent.Media, error) {
+	seed := fmt.Sprintf("%d-%d", time.Now().UnixMilli(), rand.Intn(1000000))
+	return orm.Media.
+		Create().
+		SetKey(fmt.Sprintf("test/%s.png", seed)).
+		SetFilename("image.png").
+		SetContentType("image/png").
+		SetSize(100).
+		SetOwner(owner).
+		Save(context.Background())
+}
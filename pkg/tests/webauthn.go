@@ -0,0 +1,179 @@
+package tests
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+)
+
+const (
+	authenticatorFlagUserPresent  = 0x01
+	authenticatorFlagUserVerified = 0x04
+	authenticatorFlagAttestedData = 0x40
+)
+
+// Authenticator is a software WebAuthn authenticator which holds a single passkey, so passkey registration and
+// login can be tested without hardware or a browser
+type Authenticator struct {
+	// Origin is the origin of the page the browser would be on
+	Origin string
+
+	// SignCount is the signature counter of the passkey, which is incremented each time it is used
+	SignCount uint32
+
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	userHandle   []byte
+}
+
+// NewAuthenticator creates a new software authenticator for a given origin
+func NewAuthenticator(origin string) *Authenticator {
+	return &Authenticator{Origin: origin}
+}
+
+// Create creates a passkey for the given registration options, as navigator.credentials.create() would, and returns
+// the response as JSON
+func (a *Authenticator) Create(creation *protocol.CredentialCreation) (string, error) {
+	opts := creation.Response
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", err
+	}
+
+	credentialID := make([]byte, 16)
+	if _, err = rand.Read(credentialID); err != nil {
+		return "", err
+	}
+
+	// The user ID is encoded within the options as it is sent to the browser
+	userID, err := json.Marshal(opts.User.ID)
+	if err != nil {
+		return "", err
+	}
+	var userHandle protocol.URLEncodedBase64
+	if err = json.Unmarshal(userID, &userHandle); err != nil {
+		return "", err
+	}
+
+	publicKey, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.EllipticKey),
+			Algorithm: int64(webauthncose.AlgES256),
+		},
+		Curve:  int64(webauthncose.P256),
+		XCoord: key.X.FillBytes(make([]byte, 32)),
+		YCoord: key.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Attested credential data: AAGUID, credential ID length, credential ID and public key
+	attested := make([]byte, 16, 18+len(credentialID)+len(publicKey))
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(credentialID)))
+	attested = append(attested, credentialID...)
+	attested = append(attested, publicKey...)
+
+	authData := a.authData(opts.RelyingParty.ID, authenticatorFlagAttestedData, attested)
+
+	attestation, err := webauthncbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": authData,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	clientData, err := a.clientData(protocol.CreateCeremony, opts.Challenge)
+	if err != nil {
+		return "", err
+	}
+
+	a.key = key
+	a.credentialID = credentialID
+	a.userHandle = userHandle
+
+	return a.response(map[string]any{
+		"clientDataJSON":    encode(clientData),
+		"attestationObject": encode(attestation),
+		"transports":        []string{"internal"},
+	})
+}
+
+// Get signs the given login options with the passkey, as navigator.credentials.get() would, and returns the
+// response as JSON
+func (a *Authenticator) Get(assertion *protocol.CredentialAssertion) (string, error) {
+	if a.key == nil {
+		return "", errors.New("authenticator has no passkey")
+	}
+
+	opts := assertion.Response
+	a.SignCount++
+	authData := a.authData(opts.RelyingPartyID, 0, nil)
+
+	clientData, err := a.clientData(protocol.AssertCeremony, opts.Challenge)
+	if err != nil {
+		return "", err
+	}
+
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(authData, clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return a.response(map[string]any{
+		"clientDataJSON":    encode(clientData),
+		"authenticatorData": encode(authData),
+		"signature":         encode(signature),
+		"userHandle":        encode(a.userHandle),
+	})
+}
+
+// authData builds the authenticator data for a given relying party ID, indicating the user was present and verified
+func (a *Authenticator) authData(rpID string, flags byte, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append(rpIDHash[:], flags|authenticatorFlagUserPresent|authenticatorFlagUserVerified)
+	data = binary.BigEndian.AppendUint32(data, a.SignCount)
+	return append(data, attested...)
+}
+
+// clientData builds the client data the browser would provide for a given ceremony and challenge
+func (a *Authenticator) clientData(ceremony protocol.CeremonyType, challenge protocol.URLEncodedBase64) ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"type":      ceremony,
+		"challenge": challenge.String(),
+		"origin":    a.Origin,
+	})
+}
+
+// response builds the public key credential the browser would return containing a given response
+func (a *Authenticator) response(response map[string]any) (string, error) {
+	b, err := json.Marshal(map[string]any{
+		"id":       encode(a.credentialID),
+		"rawId":    encode(a.credentialID),
+		"type":     "public-key",
+		"response": response,
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to encode response: %w", err)
+	}
+	return string(b), nil
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -20,15 +20,42 @@ const (
 )
 
 const (
-	PageAbout          Page = "about"
-	PageContact        Page = "contact"
-	PageError          Page = "error"
-	PageForgotPassword Page = "forgot-password"
-	PageHome           Page = "home"
-	PageLogin          Page = "login"
-	PageRegister       Page = "register"
-	PageResetPassword  Page = "reset-password"
-	PageSearch         Page = "search"
+	PageAbout                 Page = "about"
+	PageAdmin                 Page = "admin"
+	PageAdminAudit            Page = "admin-audit"
+	PageAdminCache            Page = "admin-cache"
+	PageAdminComments         Page = "admin-comments"
+	PageAdminMail             Page = "admin-mail"
+	PageAdminMailView         Page = "admin-mail-view"
+	PageAdminPosts            Page = "admin-posts"
+	PageAdminTasks            Page = "admin-tasks"
+	PageAdminUserEdit         Page = "admin-user-edit"
+	PageAdminUsers            Page = "admin-users"
+	PageArchive               Page = "archive"
+	PageCommentForm           Page = "comment-form"
+	PageComments              Page = "comments"
+	PageContact               Page = "contact"
+	PageDevMail               Page = "dev-mail"
+	PageDevMailView           Page = "dev-mail-view"
+	PageError                 Page = "error"
+	PageForgotPassword        Page = "forgot-password"
+	PageHome                  Page = "home"
+	PageIdentities            Page = "identities"
+	PageLogin                 Page = "login"
+	PageLoginTwoFactor        Page = "login-two-factor"
+	PageMedia                 Page = "media"
+	PageNewsletter            Page = "newsletter"
+	PageNewsletterUnsubscribe Page = "newsletter-unsubscribe"
+	PagePasskeys              Page = "passkeys"
+	PagePost                  Page = "post"
+	PagePostEdit              Page = "post-edit"
+	PagePostRevisions         Page = "post-revisions"
+	PageRegister              Page = "register"
+	PageResetPassword         Page = "reset-password"
+	PageSessions              Page = "sessions"
+	PageSearch                Page = "search"
+	PageTags                  Page = "tags"
+	PageTwoFactor             Page = "two-factor"
 )
 
 //go:embed *